@@ -2,10 +2,17 @@ package main
 
 import (
 	"log"
+	"time"
+	_ "time/tzdata"
 
 	"mentorsphere-api/internal/config"
 	"mentorsphere-api/internal/database"
+	"mentorsphere-api/internal/delivery"
+	"mentorsphere-api/internal/events"
+	"mentorsphere-api/internal/llm"
 	"mentorsphere-api/internal/router"
+	"mentorsphere-api/internal/scheduler"
+	"mentorsphere-api/internal/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -29,6 +36,50 @@ func main() {
 		log.Println("Running in mock mode...")
 	}
 
+	// Refuse to sign certificates with a missing or malformed key
+	if err := services.CheckCertificateSigningKey(cfg); err != nil {
+		log.Fatalf("Invalid CERTIFICATE_SIGNING_KEY: %v", err)
+	}
+
+	// Create assignments for mentors' assignedStudents stored before
+	// assignments existed, so their students stay assigned
+	if created, err := services.NewAssignmentService().Backfill(""); err != nil {
+		log.Printf("Warning: assignment backfill failed: %v", err)
+	} else if created > 0 {
+		log.Printf("Backfilled %d mentor assignments", created)
+	}
+
+	// Mark notifications stored before archiving existed as not archived, so
+	// the archived filter of lists and unread counts matches them
+	if updated, err := services.NewNotificationService().BackfillArchived(); err != nil {
+		log.Printf("Warning: notification backfill failed: %v", err)
+	} else if updated > 0 {
+		log.Printf("Backfilled archived on %d notifications", updated)
+	}
+
+	// Initialize text generation
+	llm.Init(cfg)
+
+	// Initialize notification delivery channels
+	delivery.Init(cfg)
+
+	// Initialize the real-time event bus
+	events.SetDefault(events.NewMemoryBus(cfg.EventHistorySize, cfg.EventBufferSize))
+
+	// Start background jobs
+	if cfg.SchedulerEnabled {
+		loc, err := time.LoadLocation(cfg.SchedulerTimezone)
+		if err != nil {
+			log.Fatalf("Invalid SCHEDULER_TIMEZONE: %v", err)
+		}
+		jobs := scheduler.New(services.NewJobStore(), loc)
+		if err := services.RegisterJobs(jobs, cfg); err != nil {
+			log.Fatalf("Failed to register jobs: %v", err)
+		}
+		jobs.Start()
+		scheduler.SetDefault(jobs)
+	}
+
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		AppName:      "MentorSphere API v1.0",
@@ -1,6 +1,9 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+)
 
 type Config struct {
 	Port                    string
@@ -8,6 +11,28 @@ type Config struct {
 	FirebaseCredentialsPath string
 	AllowedOrigins          string
 	Environment             string
+	PublicURL               string
+	CertificateSigningKey   string
+	LLMProvider             string
+	LLMBaseURL              string
+	LLMAPIKey               string
+	LLMModel                string
+	LLMMaxTokens            int
+	LLMTimeoutSeconds       int
+	LLMCacheTTLMinutes      int
+	SchedulerEnabled        bool
+	SchedulerTimezone       string
+	EventHistorySize        int
+	EventBufferSize         int
+	EventHeartbeatSeconds   int
+	NotificationTransport   string
+	SMTPHost                string
+	SMTPPort                string
+	SMTPUsername            string
+	SMTPPassword            string
+	SMTPFrom                string
+	VAPIDPrivateKey         string
+	VAPIDSubject            string
 }
 
 func Load() *Config {
@@ -17,6 +42,28 @@ func Load() *Config {
 		FirebaseCredentialsPath: getEnv("FIREBASE_CREDENTIALS_PATH", ""),
 		AllowedOrigins:          getEnv("ALLOWED_ORIGINS", "http://localhost:5173,http://localhost:3000"),
 		Environment:             getEnv("ENVIRONMENT", "development"),
+		PublicURL:               getEnv("PUBLIC_URL", "http://localhost:3001"),
+		CertificateSigningKey:   getEnv("CERTIFICATE_SIGNING_KEY", ""),
+		LLMProvider:             getEnv("LLM_PROVIDER", "none"),
+		LLMBaseURL:              getEnv("LLM_BASE_URL", "https://api.openai.com/v1"),
+		LLMAPIKey:               getEnv("LLM_API_KEY", ""),
+		LLMModel:                getEnv("LLM_MODEL", "gpt-4o-mini"),
+		LLMMaxTokens:            getEnvInt("LLM_MAX_TOKENS", 256),
+		LLMTimeoutSeconds:       getEnvInt("LLM_TIMEOUT_SECONDS", 10),
+		LLMCacheTTLMinutes:      getEnvInt("LLM_CACHE_TTL_MINUTES", 60),
+		SchedulerEnabled:        getEnv("SCHEDULER_ENABLED", "true") == "true",
+		SchedulerTimezone:       getEnv("SCHEDULER_TIMEZONE", "Asia/Jakarta"),
+		EventHistorySize:        getEnvInt("EVENT_HISTORY_SIZE", 200),
+		EventBufferSize:         getEnvInt("EVENT_BUFFER_SIZE", 64),
+		EventHeartbeatSeconds:   getEnvInt("EVENT_HEARTBEAT_SECONDS", 25),
+		NotificationTransport:   getEnv("NOTIFICATION_TRANSPORT", "auto"),
+		SMTPHost:                getEnv("SMTP_HOST", ""),
+		SMTPPort:                getEnv("SMTP_PORT", "587"),
+		SMTPUsername:            getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:            getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:                getEnv("SMTP_FROM", "MentorSphere <no-reply@mentorsphere.local>"),
+		VAPIDPrivateKey:         getEnv("VAPID_PRIVATE_KEY", ""),
+		VAPIDSubject:            getEnv("VAPID_SUBJECT", "mailto:admin@mentorsphere.com"),
 	}
 }
 
@@ -26,3 +73,10 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,123 @@
+// Package delivery sends notifications over outbound channels. Each channel
+// has a Transport; Init installs SMTP and Web Push transports when they are
+// configured and stub transports otherwise.
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"mentorsphere-api/internal/config"
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/pkg/mailer"
+	"mentorsphere-api/pkg/webpush"
+)
+
+// Delivery channels
+const (
+	ChannelInApp = "in_app"
+	ChannelEmail = "email"
+	ChannelPush  = "push"
+)
+
+// Recipient is the user a message goes to
+type Recipient struct {
+	UserID        string
+	Name          string
+	Email         string
+	Subscriptions []models.PushSubscription
+}
+
+// Message is the content of a notification on an outbound channel
+type Message struct {
+	NotificationID string
+	Category       string
+	Type           string
+	Title          string
+	Body           string
+	// URL opens the relevant page when the message is clicked
+	URL string
+	// HTML replaces the generated HTML body of e-mails when set
+	HTML string
+}
+
+// Transport sends messages over one channel
+type Transport interface {
+	Send(ctx context.Context, to Recipient, msg Message) error
+}
+
+// ExpiredSubscriptionsError reports push subscriptions the push service no
+// longer accepts. Delivered tells whether another subscription got the message.
+type ExpiredSubscriptionsError struct {
+	IDs       []string
+	Delivered bool
+}
+
+func (e *ExpiredSubscriptionsError) Error() string {
+	return fmt.Sprintf("%d push subscription(s) expired", len(e.IDs))
+}
+
+var (
+	mu         sync.RWMutex
+	transports = map[string]Transport{
+		ChannelEmail: NewStubTransport(ChannelEmail),
+		ChannelPush:  NewStubTransport(ChannelPush),
+	}
+	pushPublicKey string
+)
+
+// Init installs the transports selected by the configuration
+func Init(cfg *config.Config) {
+	stub := cfg.NotificationTransport == "stub"
+
+	if !stub && cfg.SMTPHost != "" {
+		m := mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+		SetTransport(ChannelEmail, NewEmailTransport(m))
+		log.Printf("✅ E-mail delivery via %s:%s", cfg.SMTPHost, cfg.SMTPPort)
+	} else {
+		SetTransport(ChannelEmail, NewStubTransport(ChannelEmail))
+		log.Println("E-mail delivery uses the stub transport")
+	}
+
+	if cfg.VAPIDPrivateKey != "" {
+		client, err := webpush.NewClient(cfg.VAPIDPrivateKey, cfg.VAPIDSubject)
+		if err != nil {
+			log.Printf("Warning: Web Push disabled: %v", err)
+		} else {
+			mu.Lock()
+			pushPublicKey = client.PublicKey()
+			mu.Unlock()
+			if !stub {
+				SetTransport(ChannelPush, NewPushTransport(client))
+				log.Println("✅ Web Push delivery enabled")
+				return
+			}
+		}
+	}
+	SetTransport(ChannelPush, NewStubTransport(ChannelPush))
+	log.Println("Web Push delivery uses the stub transport")
+}
+
+// SetTransport replaces the transport of a channel
+func SetTransport(channel string, transport Transport) {
+	mu.Lock()
+	defer mu.Unlock()
+	transports[channel] = transport
+}
+
+// Get returns the transport of a channel, or nil for unknown channels
+func Get(channel string) Transport {
+	mu.RLock()
+	defer mu.RUnlock()
+	return transports[channel]
+}
+
+// PushPublicKey returns the VAPID public key browsers subscribe with, or an
+// empty string when Web Push is not configured
+func PushPublicKey() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return pushPublicKey
+}
@@ -0,0 +1,54 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+
+	"mentorsphere-api/pkg/mailer"
+)
+
+var emailLayout = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #1f2937; max-width: 560px; margin: 0 auto; padding: 24px;">
+  <h2 style="margin: 0 0 16px;">{{.Title}}</h2>
+  <p style="line-height: 1.5;">{{.Body}}</p>
+  {{if .URL}}<p><a href="{{.URL}}" style="color: #4f46e5;">Buka MentorSphere</a></p>{{end}}
+  <hr style="border: none; border-top: 1px solid #e5e7eb; margin: 24px 0;">
+  <p style="font-size: 12px; color: #6b7280;">Ubah preferensi notifikasi di menu Pengaturan MentorSphere.</p>
+</body>
+</html>`))
+
+// EmailTransport sends messages as e-mails
+type EmailTransport struct {
+	mailer mailer.Mailer
+}
+
+// NewEmailTransport creates an e-mail transport
+func NewEmailTransport(m mailer.Mailer) *EmailTransport {
+	return &EmailTransport{mailer: m}
+}
+
+// Send e-mails the message to the recipient's address
+func (t *EmailTransport) Send(ctx context.Context, to Recipient, msg Message) error {
+	text := msg.Body
+	if msg.URL != "" {
+		text += "\n\n" + msg.URL
+	}
+
+	html := msg.HTML
+	if html == "" {
+		var buf bytes.Buffer
+		if err := emailLayout.Execute(&buf, msg); err != nil {
+			return err
+		}
+		html = buf.String()
+	}
+
+	return t.mailer.Send(mailer.Message{
+		To:      to.Email,
+		Subject: msg.Title,
+		Text:    text,
+		HTML:    html,
+	})
+}
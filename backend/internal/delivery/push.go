@@ -0,0 +1,77 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"mentorsphere-api/pkg/webpush"
+)
+
+// PushTransport sends messages to every push subscription of the recipient
+type PushTransport struct {
+	client *webpush.Client
+}
+
+// NewPushTransport creates a Web Push transport
+func NewPushTransport(client *webpush.Client) *PushTransport {
+	return &PushTransport{client: client}
+}
+
+// pushPayload is what the service worker receives
+type pushPayload struct {
+	NotificationID string `json:"notificationId"`
+	Category       string `json:"category"`
+	Type           string `json:"type"`
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	URL            string `json:"url,omitempty"`
+}
+
+// Send pushes the message; it succeeds when at least one subscription got it
+func (t *PushTransport) Send(ctx context.Context, to Recipient, msg Message) error {
+	payload, err := json.Marshal(pushPayload{
+		NotificationID: msg.NotificationID,
+		Category:       msg.Category,
+		Type:           msg.Type,
+		Title:          msg.Title,
+		Body:           msg.Body,
+		URL:            msg.URL,
+	})
+	if err != nil {
+		return err
+	}
+
+	opts := webpush.Options{TTL: 24 * time.Hour, Urgency: "normal", Topic: msg.Category}
+	if msg.Type == "danger" {
+		opts.Urgency = "high"
+	}
+
+	var expired []string
+	var lastErr error
+	delivered := false
+	for _, sub := range to.Subscriptions {
+		err := t.client.Send(webpush.Subscription{
+			Endpoint: sub.Endpoint,
+			P256DH:   sub.P256DH,
+			Auth:     sub.Auth,
+		}, payload, opts)
+		switch {
+		case err == nil:
+			delivered = true
+		case errors.Is(err, webpush.ErrGone):
+			expired = append(expired, sub.ID)
+		default:
+			lastErr = err
+		}
+	}
+
+	if len(expired) > 0 {
+		return &ExpiredSubscriptionsError{IDs: expired, Delivered: delivered}
+	}
+	if !delivered && lastErr != nil {
+		return lastErr
+	}
+	return nil
+}
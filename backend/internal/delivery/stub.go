@@ -0,0 +1,61 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+// ErrStubFailure is returned by a stub transport told to fail
+var ErrStubFailure = errors.New("stub transport failure")
+
+// SentMessage is a message recorded by a stub transport
+type SentMessage struct {
+	To      Recipient
+	Message Message
+}
+
+// StubTransport records messages in memory and logs them instead of sending.
+// It is meant for development and tests.
+type StubTransport struct {
+	channel string
+
+	mu       sync.Mutex
+	sent     []SentMessage
+	failures int
+}
+
+// NewStubTransport creates a stub transport for a channel
+func NewStubTransport(channel string) *StubTransport {
+	return &StubTransport{channel: channel}
+}
+
+// Send records the message, or fails while failures are pending
+func (t *StubTransport) Send(ctx context.Context, to Recipient, msg Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.failures > 0 {
+		t.failures--
+		return ErrStubFailure
+	}
+
+	t.sent = append(t.sent, SentMessage{To: to, Message: msg})
+	log.Printf("delivery[%s] stub: to user %s: %s", t.channel, to.UserID, msg.Title)
+	return nil
+}
+
+// FailNext makes the next n sends fail
+func (t *StubTransport) FailNext(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failures = n
+}
+
+// Sent returns the recorded messages
+func (t *StubTransport) Sent() []SentMessage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]SentMessage(nil), t.sent...)
+}
@@ -0,0 +1,240 @@
+// Package digest renders the weekly progress report e-mails of students and
+// mentors. Every digest has a plain-text and an HTML version; the wording comes
+// from per-language message tables so both versions say the same thing.
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"text/template"
+
+	"mentorsphere-api/internal/models"
+)
+
+// DefaultLanguage is used for users whose language has no messages
+const DefaultLanguage = "id"
+
+// messages holds the wording per language; values with verbs are printf formats
+var messages = map[string]map[string]string{
+	"id": {
+		"studentSubject":   "Laporan mingguan Anda: %s – %s",
+		"mentorSubject":    "Ringkasan mentee mingguan: %s – %s",
+		"greeting":         "Halo %s,",
+		"studentIntro":     "Berikut ringkasan belajar Anda minggu lalu.",
+		"mentorIntro":      "Berikut ringkasan perkembangan mentee Anda minggu lalu.",
+		"timeStudied":      "Waktu belajar",
+		"minutes":          "%d menit",
+		"changeUp":         "naik %d%% dari minggu sebelumnya",
+		"changeDown":       "turun %d%% dari minggu sebelumnya",
+		"changeFlat":       "sama seperti minggu sebelumnya",
+		"activeDays":       "Hari aktif",
+		"days":             "%d dari 7 hari",
+		"modulesCompleted": "Modul selesai",
+		"streak":           "Streak",
+		"streakDays":       "%d hari (terpanjang %d hari)",
+		"nextSteps":        "Langkah berikutnya",
+		"continueModule":   "Lanjutkan modul %s di %s",
+		"noNextSteps":      "Pilih course berikutnya untuk melanjutkan perjalanan belajar Anda.",
+		"cohort":           "Ringkasan mentee",
+		"activeStudents":   "Mentee aktif",
+		"ofStudents":       "%d dari %d",
+		"totalMinutes":     "Total waktu belajar",
+		"averageProgress":  "Rata-rata progres",
+		"percent":          "%d%%",
+		"newAtRisk":        "Mentee baru berisiko tinggi",
+		"riskScore":        "%s (skor risiko %d)",
+		"noNewAtRisk":      "Tidak ada mentee baru yang berisiko tinggi.",
+		"pending":          "Intervensi menunggu tanggapan",
+		"pendingItem":      "%s – %s (%s, %d hari)",
+		"noPending":        "Tidak ada intervensi yang menunggu tanggapan.",
+		"footer":           "Anda menerima e-mail ini karena laporan mingguan aktif. Ubah preferensi notifikasi di menu Pengaturan MentorSphere.",
+	},
+	"en": {
+		"studentSubject":   "Your weekly report: %s – %s",
+		"mentorSubject":    "Your weekly mentee summary: %s – %s",
+		"greeting":         "Hi %s,",
+		"studentIntro":     "Here is a summary of your learning last week.",
+		"mentorIntro":      "Here is how your mentees did last week.",
+		"timeStudied":      "Time studied",
+		"minutes":          "%d minutes",
+		"changeUp":         "up %d%% from the week before",
+		"changeDown":       "down %d%% from the week before",
+		"changeFlat":       "same as the week before",
+		"activeDays":       "Active days",
+		"days":             "%d of 7 days",
+		"modulesCompleted": "Modules completed",
+		"streak":           "Streak",
+		"streakDays":       "%d days (longest %d days)",
+		"nextSteps":        "Next steps",
+		"continueModule":   "Continue module %s in %s",
+		"noNextSteps":      "Pick your next course to keep learning.",
+		"cohort":           "Mentee summary",
+		"activeStudents":   "Active mentees",
+		"ofStudents":       "%d of %d",
+		"totalMinutes":     "Total time studied",
+		"averageProgress":  "Average progress",
+		"percent":          "%d%%",
+		"newAtRisk":        "Mentees newly at high risk",
+		"riskScore":        "%s (risk score %d)",
+		"noNewAtRisk":      "No mentees newly at high risk.",
+		"pending":          "Interventions awaiting a response",
+		"pendingItem":      "%s – %s (%s, %d days)",
+		"noPending":        "No interventions are awaiting a response.",
+		"footer":           "You receive this e-mail because weekly reports are on. Change your notification preferences in MentorSphere's Settings.",
+	},
+}
+
+// Language returns the supported language closest to the requested one
+func Language(language string) string {
+	if _, ok := messages[language]; ok {
+		return language
+	}
+	return DefaultLanguage
+}
+
+type templateData struct {
+	L map[string]string
+	D *models.WeeklyDigest
+}
+
+// Render fills in the subject, text and HTML of the digest in its language
+func Render(d *models.WeeklyDigest) error {
+	d.Language = Language(d.Language)
+	data := templateData{L: messages[d.Language], D: d}
+
+	subject, text, html := studentSubject, studentText, studentHTML
+	if d.Mentor != nil {
+		subject, text, html = mentorSubject, mentorText, mentorHTML
+	}
+
+	var buf bytes.Buffer
+	if err := subject.Execute(&buf, data); err != nil {
+		return err
+	}
+	d.Subject = buf.String()
+
+	buf.Reset()
+	if err := text.Execute(&buf, data); err != nil {
+		return err
+	}
+	d.Text = buf.String()
+
+	buf.Reset()
+	if err := html.Execute(&buf, data); err != nil {
+		return err
+	}
+	d.HTML = buf.String()
+
+	return nil
+}
+
+// change describes the week-over-week change of study time
+func change(l map[string]string, percent int) string {
+	switch {
+	case percent > 0:
+		return fmt.Sprintf(l["changeUp"], percent)
+	case percent < 0:
+		return fmt.Sprintf(l["changeDown"], -percent)
+	}
+	return l["changeFlat"]
+}
+
+var funcs = template.FuncMap{"change": change}
+
+var (
+	studentSubject = template.Must(template.New("studentSubject").Parse(
+		`{{printf .L.studentSubject .D.WeekStart .D.WeekEnd}}`))
+	mentorSubject = template.Must(template.New("mentorSubject").Parse(
+		`{{printf .L.mentorSubject .D.WeekStart .D.WeekEnd}}`))
+
+	studentText = template.Must(template.New("studentText").Funcs(funcs).Parse(
+		`{{$L := .L}}{{with .D}}{{printf $L.greeting .Name}}
+
+{{$L.studentIntro}}
+{{with .Student}}
+- {{$L.timeStudied}}: {{printf $L.minutes .MinutesStudied}}{{if .PreviousMinutes}}, {{change $L .ChangePercent}}{{end}}
+- {{$L.activeDays}}: {{printf $L.days .ActiveDays}}
+- {{$L.modulesCompleted}}: {{.ModulesCompleted}}
+- {{$L.streak}}: {{printf $L.streakDays .CurrentStreak .LongestStreak}}
+
+{{$L.nextSteps}}:
+{{range .NextSteps}}- {{printf $L.continueModule .Module .Course}}
+{{else}}{{$L.noNextSteps}}
+{{end}}{{end}}
+--
+{{$L.footer}}
+{{end}}`))
+
+	mentorText = template.Must(template.New("mentorText").Parse(
+		`{{$L := .L}}{{with .D}}{{printf $L.greeting .Name}}
+
+{{$L.mentorIntro}}
+{{with .Mentor}}
+{{$L.cohort}}:
+- {{$L.activeStudents}}: {{printf $L.ofStudents .ActiveStudents .Students}}
+- {{$L.totalMinutes}}: {{printf $L.minutes .TotalMinutes}}
+- {{$L.averageProgress}}: {{printf $L.percent .AverageProgress}}
+
+{{$L.newAtRisk}}:
+{{range .NewAtRisk}}- {{printf $L.riskScore .Name .RiskScore}}
+{{else}}{{$L.noNewAtRisk}}
+{{end}}
+{{$L.pending}}:
+{{range .PendingInterventions}}- {{printf $L.pendingItem .StudentName .Type .Status .DaysOpen}}
+{{else}}{{$L.noPending}}
+{{end}}{{end}}
+--
+{{$L.footer}}
+{{end}}`))
+)
+
+// The HTML versions share a layout; each defines the "content" block
+const htmlLayout = `<!DOCTYPE html>
+<html lang="{{.D.Language}}">
+<body style="font-family: Arial, sans-serif; color: #1f2937; max-width: 560px; margin: 0 auto; padding: 24px;">
+  <p>{{printf .L.greeting .D.Name}}</p>
+  {{template "content" .}}
+  <hr style="border: none; border-top: 1px solid #e5e7eb; margin: 24px 0;">
+  <p style="font-size: 12px; color: #6b7280;">{{.L.footer}}</p>
+</body>
+</html>`
+
+const rowStyle = `style="padding: 6px 0; border-bottom: 1px solid #f3f4f6;"`
+
+var (
+	studentHTML = htmltemplate.Must(htmltemplate.Must(htmltemplate.New("studentHTML").
+			Funcs(htmltemplate.FuncMap(funcs)).Parse(htmlLayout)).Parse(`{{define "content"}}{{$L := .L}}
+  <p>{{$L.studentIntro}}</p>
+  {{with .D.Student}}
+  <table style="width: 100%; border-collapse: collapse;">
+    <tr><td ` + rowStyle + `>{{$L.timeStudied}}</td><td ` + rowStyle + `><strong>{{printf $L.minutes .MinutesStudied}}</strong>{{if .PreviousMinutes}}<br><small>{{change $L .ChangePercent}}</small>{{end}}</td></tr>
+    <tr><td ` + rowStyle + `>{{$L.activeDays}}</td><td ` + rowStyle + `><strong>{{printf $L.days .ActiveDays}}</strong></td></tr>
+    <tr><td ` + rowStyle + `>{{$L.modulesCompleted}}</td><td ` + rowStyle + `><strong>{{.ModulesCompleted}}</strong></td></tr>
+    <tr><td ` + rowStyle + `>{{$L.streak}}</td><td ` + rowStyle + `><strong>{{printf $L.streakDays .CurrentStreak .LongestStreak}}</strong></td></tr>
+  </table>
+  <h3 style="margin: 24px 0 8px;">{{$L.nextSteps}}</h3>
+  {{if .NextSteps}}<ul>{{range .NextSteps}}<li>{{printf $L.continueModule .Module .Course}}</li>{{end}}</ul>
+  {{else}}<p>{{$L.noNextSteps}}</p>{{end}}
+  {{end}}
+{{end}}`))
+
+	mentorHTML = htmltemplate.Must(htmltemplate.Must(htmltemplate.New("mentorHTML").
+			Parse(htmlLayout)).Parse(`{{define "content"}}{{$L := .L}}
+  <p>{{$L.mentorIntro}}</p>
+  {{with .D.Mentor}}
+  <h3 style="margin: 24px 0 8px;">{{$L.cohort}}</h3>
+  <table style="width: 100%; border-collapse: collapse;">
+    <tr><td ` + rowStyle + `>{{$L.activeStudents}}</td><td ` + rowStyle + `><strong>{{printf $L.ofStudents .ActiveStudents .Students}}</strong></td></tr>
+    <tr><td ` + rowStyle + `>{{$L.totalMinutes}}</td><td ` + rowStyle + `><strong>{{printf $L.minutes .TotalMinutes}}</strong></td></tr>
+    <tr><td ` + rowStyle + `>{{$L.averageProgress}}</td><td ` + rowStyle + `><strong>{{printf $L.percent .AverageProgress}}</strong></td></tr>
+  </table>
+  <h3 style="margin: 24px 0 8px;">{{$L.newAtRisk}}</h3>
+  {{if .NewAtRisk}}<ul>{{range .NewAtRisk}}<li>{{printf $L.riskScore .Name .RiskScore}}</li>{{end}}</ul>
+  {{else}}<p>{{$L.noNewAtRisk}}</p>{{end}}
+  <h3 style="margin: 24px 0 8px;">{{$L.pending}}</h3>
+  {{if .PendingInterventions}}<ul>{{range .PendingInterventions}}<li>{{printf $L.pendingItem .StudentName .Type .Status .DaysOpen}}</li>{{end}}</ul>
+  {{else}}<p>{{$L.noPending}}</p>{{end}}
+  {{end}}
+{{end}}`))
+)
@@ -0,0 +1,89 @@
+// Package events carries real-time events to connected users over a pub/sub
+// Bus. The in-process MemoryBus serves a single instance; a Bus backed by a
+// shared broker (e.g. Redis streams) lets several instances fan out together.
+package events
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// Event types streamed to clients
+const (
+	TypeNotification     = "notification"
+	TypeNotificationRead = "notification.read"
+	TypeIntervention     = "intervention"
+	TypeMessage          = "message"
+	TypeMessageRead      = "message.read"
+	TypeProgress         = "progress"
+	// TypeResync tells the client that events were lost and it should reload
+	TypeResync = "resync"
+)
+
+// Event is one message for a user. ID is assigned by the bus and increases
+// with every published event.
+type Event struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	UserID string          `json:"-"`
+	Data   json.RawMessage `json:"data"`
+	At     time.Time       `json:"at"`
+}
+
+// Bus publishes events and hands them to the subscriptions of their user
+type Bus interface {
+	Publish(event Event) (Event, error)
+	// Subscribe starts a subscription for the user. When lastEventID is set,
+	// the user's retained events after it are delivered first, or a resync
+	// event when some of them are no longer retained.
+	Subscribe(userID, lastEventID string) (*Subscription, error)
+}
+
+// Subscription receives the events of one connection. Events is closed when
+// the subscription is closed or the bus drops it because the connection
+// could not keep up; the client then resumes from the last event it got.
+type Subscription struct {
+	Events <-chan Event
+
+	events chan Event
+	closed bool
+	cancel func()
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	s.cancel()
+}
+
+var defaultBus Bus = NewMemoryBus(defaultHistorySize, defaultBufferSize)
+
+// SetDefault installs the bus used by services and the stream endpoint
+func SetDefault(bus Bus) {
+	defaultBus = bus
+}
+
+// Default returns the installed bus
+func Default() Bus {
+	return defaultBus
+}
+
+// Publish sends payload as an event of the given type to each user. Failures
+// are logged: real-time delivery is best effort and never fails the caller.
+func Publish(eventType string, payload interface{}, userIDs ...string) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("events: failed to encode %s event: %v", eventType, err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if userID == "" {
+			continue
+		}
+		event := Event{Type: eventType, UserID: userID, Data: data, At: time.Now()}
+		if _, err := defaultBus.Publish(event); err != nil {
+			log.Printf("events: failed to publish %s event to user %s: %v", eventType, userID, err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package events
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHistorySize = 200
+	defaultBufferSize  = 64
+)
+
+// MemoryBus delivers events within the process. It keeps the last
+// historySize events of every user for resuming, and gives each subscription
+// a buffer of bufferSize events; a subscription whose buffer is full is
+// dropped instead of slowing down the publisher.
+//
+// Event IDs have the form "<epoch>-<sequence>". The epoch changes with every
+// process start, so IDs from before a restart trigger a resync.
+type MemoryBus struct {
+	historySize int
+	bufferSize  int
+	epoch       string
+
+	mu       sync.Mutex
+	sequence uint64
+	history  map[string]*userHistory
+	subs     map[string]map[*Subscription]struct{}
+}
+
+type userHistory struct {
+	events []Event
+	// evicted is the sequence of the newest event no longer retained
+	evicted uint64
+}
+
+// NewMemoryBus creates an in-process bus
+func NewMemoryBus(historySize, bufferSize int) *MemoryBus {
+	if historySize <= 0 {
+		historySize = defaultHistorySize
+	}
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &MemoryBus{
+		historySize: historySize,
+		bufferSize:  bufferSize,
+		epoch:       strconv.FormatInt(time.Now().Unix(), 36),
+		history:     make(map[string]*userHistory),
+		subs:        make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Publish stores the event in the user's history and hands it to their
+// subscriptions
+func (b *MemoryBus) Publish(event Event) (Event, error) {
+	if event.UserID == "" {
+		return event, fmt.Errorf("event has no user")
+	}
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	event.ID = b.formatID(b.sequence)
+
+	h := b.history[event.UserID]
+	if h == nil {
+		h = &userHistory{}
+		b.history[event.UserID] = h
+	}
+	h.events = append(h.events, event)
+	if len(h.events) > b.historySize {
+		_, h.evicted, _ = b.parseID(h.events[0].ID)
+		h.events = h.events[1:]
+	}
+
+	for sub := range b.subs[event.UserID] {
+		select {
+		case sub.events <- event:
+		default:
+			// The connection is not keeping up; drop it so it resumes later
+			b.remove(event.UserID, sub)
+		}
+	}
+
+	return event, nil
+}
+
+// Subscribe registers a subscription for the user, first filled with the
+// events after lastEventID
+func (b *MemoryBus) Subscribe(userID, lastEventID string) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Event
+	if lastEventID != "" {
+		replay = b.replay(userID, lastEventID)
+	}
+
+	events := make(chan Event, b.bufferSize+len(replay))
+	for _, event := range replay {
+		events <- event
+	}
+
+	sub := &Subscription{Events: events, events: events}
+	sub.cancel = func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(userID, sub)
+	}
+
+	if b.subs[userID] == nil {
+		b.subs[userID] = make(map[*Subscription]struct{})
+	}
+	b.subs[userID][sub] = struct{}{}
+
+	return sub, nil
+}
+
+// replay returns the retained events after lastEventID, or a single resync
+// event when the client may have missed events that are gone
+func (b *MemoryBus) replay(userID, lastEventID string) []Event {
+	epoch, last, err := b.parseID(lastEventID)
+	h := b.history[userID]
+	if err != nil || epoch != b.epoch || last > b.sequence || (h != nil && last < h.evicted) {
+		return []Event{{ID: b.formatID(b.sequence), Type: TypeResync, UserID: userID, Data: []byte("{}"), At: time.Now()}}
+	}
+	if h == nil {
+		return nil
+	}
+
+	var events []Event
+	for _, event := range h.events {
+		if _, sequence, _ := b.parseID(event.ID); sequence > last {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// remove unregisters a subscription; the caller holds b.mu
+func (b *MemoryBus) remove(userID string, sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.events)
+
+	delete(b.subs[userID], sub)
+	if len(b.subs[userID]) == 0 {
+		delete(b.subs, userID)
+	}
+}
+
+func (b *MemoryBus) formatID(sequence uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(sequence, 10)
+}
+
+func (b *MemoryBus) parseID(id string) (string, uint64, error) {
+	epoch, sequence, ok := strings.Cut(id, "-")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid event id %q", id)
+	}
+	n, err := strconv.ParseUint(sequence, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid event id %q", id)
+	}
+	return epoch, n, nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AchievementHandler struct {
+	achievementService *services.AchievementService
+}
+
+func NewAchievementHandler() *AchievementHandler {
+	return &AchievementHandler{
+		achievementService: services.NewAchievementService(),
+	}
+}
+
+func (h *AchievementHandler) GetBadges(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	badges := h.achievementService.GetUserBadges(userID)
+	return utils.SendSuccess(c, badges)
+}
+
+func (h *AchievementHandler) EvaluateBadges(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	newBadges := h.achievementService.Evaluate(userID)
+	return utils.SendSuccess(c, fiber.Map{
+		"newBadges": newBadges,
+	})
+}
+
+func (h *AchievementHandler) GetDefinitions(c *fiber.Ctx) error {
+	return utils.SendSuccess(c, fiber.Map{
+		"badges":  h.achievementService.GetDefinitions(),
+		"metrics": services.AchievementMetrics(),
+	})
+}
+
+func (h *AchievementHandler) SaveDefinition(c *fiber.Ctx) error {
+	var req models.BadgeDefinitionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	if id := c.Params("id"); id != "" {
+		// Params alias the request buffer; the ID is stored
+		req.ID = strings.Clone(id)
+	}
+
+	if req.ID == "" || req.Name == "" {
+		return utils.SendBadRequest(c, "Badge id and name are required")
+	}
+
+	badge, err := h.achievementService.SaveDefinition(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidBadgeRule) {
+			return utils.SendBadRequest(c, err.Error())
+		}
+		return utils.SendInternalError(c, "Failed to save badge")
+	}
+
+	return utils.SendSuccessWithMessage(c, "Badge saved", badge)
+}
+
+func (h *AchievementHandler) DeactivateDefinition(c *fiber.Ctx) error {
+	badgeID := c.Params("id")
+
+	if err := h.achievementService.DeactivateDefinition(badgeID); err != nil {
+		if errors.Is(err, services.ErrBadgeNotFound) {
+			return utils.SendNotFound(c, err.Error())
+		}
+		return utils.SendInternalError(c, "Failed to deactivate badge")
+	}
+
+	return utils.SendSuccess(c, fiber.Map{
+		"success": true,
+		"badgeId": badgeID,
+	})
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AlertHandler struct {
+	alertService *services.AlertService
+}
+
+func NewAlertHandler() *AlertHandler {
+	return &AlertHandler{
+		alertService: services.NewAlertService(),
+	}
+}
+
+func (h *AlertHandler) GetRules(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	rules := h.alertService.GetRules(mentorID)
+	return utils.SendSuccess(c, rules)
+}
+
+func (h *AlertHandler) UpdateRule(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	var req models.UpdateAlertRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	rule, err := h.alertService.UpdateRule(mentorID, c.Params("type"), req)
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
+
+	return utils.SendSuccessWithMessage(c, "Alert rule saved", rule)
+}
+
+func (h *AlertHandler) Evaluate(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	notifications := h.alertService.EvaluateMentor(mentorID)
+	return utils.SendSuccess(c, fiber.Map{
+		"alerts": notifications,
+	})
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AssignmentHandler struct {
+	assignmentService *services.AssignmentService
+}
+
+func NewAssignmentHandler() *AssignmentHandler {
+	return &AssignmentHandler{
+		assignmentService: services.NewAssignmentService(),
+	}
+}
+
+func (h *AssignmentHandler) GetAssignments(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	assignments, err := h.assignmentService.List(userID, models.AssignmentQuery{
+		MentorID:   c.Query("mentorId", ""),
+		StudentID:  c.Query("studentId", ""),
+		ActiveOnly: c.QueryBool("active", false),
+	})
+	if err != nil {
+		return sendAssignmentError(c, err)
+	}
+
+	return utils.SendSuccess(c, assignments)
+}
+
+func (h *AssignmentHandler) Assign(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.AssignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	assignment, err := h.assignmentService.Assign(userID, req)
+	if err != nil {
+		return sendAssignmentError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Student assigned", assignment)
+}
+
+func (h *AssignmentHandler) Reassign(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.ReassignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	assignment, err := h.assignmentService.Reassign(userID, req)
+	if err != nil {
+		return sendAssignmentError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Student reassigned", assignment)
+}
+
+func (h *AssignmentHandler) Unassign(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	assignment, err := h.assignmentService.Unassign(userID, c.Params("id"))
+	if err != nil {
+		return sendAssignmentError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Assignment ended", assignment)
+}
+
+func (h *AssignmentHandler) GetConfig(c *fiber.Ctx) error {
+	return utils.SendSuccess(c, h.assignmentService.GetConfig())
+}
+
+func (h *AssignmentHandler) UpdateConfig(c *fiber.Ctx) error {
+	var req models.AssignmentConfig
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	config, err := h.assignmentService.UpdateConfig(req)
+	if err != nil {
+		return sendAssignmentError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Assignment config updated", config)
+}
+
+func (h *AssignmentHandler) GetLoads(c *fiber.Ctx) error {
+	return utils.SendSuccess(c, h.assignmentService.Loads())
+}
+
+func (h *AssignmentHandler) Balance(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	result, err := h.assignmentService.Balance(userID, c.QueryBool("dryRun", false))
+	if err != nil {
+		return sendAssignmentError(c, err)
+	}
+
+	return utils.SendSuccess(c, result)
+}
+
+func (h *AssignmentHandler) Backfill(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	created, err := h.assignmentService.Backfill(userID)
+	if err != nil {
+		return sendAssignmentError(c, err)
+	}
+
+	return utils.SendSuccess(c, fiber.Map{"created": created})
+}
+
+func (h *AssignmentHandler) UpdateMentorProfile(c *fiber.Ctx) error {
+	var req models.MentorProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	mentor, err := h.assignmentService.UpdateMentorProfile(c.Params("id"), req)
+	if err != nil {
+		return sendAssignmentError(c, err)
+	}
+
+	return utils.SendSuccess(c, mentor)
+}
+
+func sendAssignmentError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrAssignmentNotFound):
+		return utils.SendNotFound(c, err.Error())
+	case errors.Is(err, services.ErrAssignmentForbidden):
+		return utils.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrAlreadyAssigned), errors.Is(err, services.ErrStudentHasMentor),
+		errors.Is(err, services.ErrAssignmentChanged):
+		return utils.SendError(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidAssignment):
+		return utils.SendBadRequest(c, err.Error())
+	default:
+		return utils.SendInternalError(c, err.Error())
+	}
+}
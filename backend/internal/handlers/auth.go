@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"log"
+
 	"mentorsphere-api/internal/config"
 	"mentorsphere-api/internal/models"
 	"mentorsphere-api/internal/services"
@@ -10,14 +12,16 @@ import (
 )
 
 type AuthHandler struct {
-	authService *services.AuthService
-	cfg         *config.Config
+	authService       *services.AuthService
+	assignmentService *services.AssignmentService
+	cfg               *config.Config
 }
 
 func NewAuthHandler(cfg *config.Config) *AuthHandler {
 	return &AuthHandler{
-		authService: services.NewAuthService(),
-		cfg:         cfg,
+		authService:       services.NewAuthService(),
+		assignmentService: services.NewAssignmentService(),
+		cfg:               cfg,
 	}
 }
 
@@ -60,12 +64,22 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	if req.Role == "" {
 		req.Role = "student"
 	}
+	// Admins are only created through seed data, never by signing up
+	if req.Role != "student" && req.Role != "mentor" {
+		return utils.SendBadRequest(c, "Role must be student or mentor")
+	}
 
 	user, err := h.authService.Register(req)
 	if err != nil {
 		return utils.SendBadRequest(c, err.Error())
 	}
 
+	if user.Role == "student" {
+		if _, err := h.assignmentService.AutoAssign(user.ID); err != nil {
+			log.Printf("Failed to auto-assign student %s: %v", user.ID, err)
+		}
+	}
+
 	token, err := utils.GenerateToken(user.ID, user.Email, user.Role, h.cfg)
 	if err != nil {
 		return utils.SendInternalError(c, "Failed to generate token")
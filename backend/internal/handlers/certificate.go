@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+
+	"mentorsphere-api/internal/config"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CertificateHandler struct {
+	certificateService *services.CertificateService
+}
+
+func NewCertificateHandler(cfg *config.Config) *CertificateHandler {
+	return &CertificateHandler{
+		certificateService: services.NewCertificateService(cfg),
+	}
+}
+
+func (h *CertificateHandler) GetCertificates(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	certificates := h.certificateService.GetUserCertificates(userID)
+	return utils.SendSuccess(c, certificates)
+}
+
+func (h *CertificateHandler) IssueCertificates(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	certificates, err := h.certificateService.IssueEligible(userID)
+	if err != nil {
+		return utils.SendInternalError(c, "Failed to issue certificates")
+	}
+
+	return utils.SendSuccess(c, certificates)
+}
+
+func (h *CertificateHandler) Download(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+	serial := c.Params("serial")
+
+	certificate, err := h.certificateService.GetUserCertificate(userID, serial)
+	if err != nil {
+		return utils.SendNotFound(c, "Sertifikat tidak ditemukan")
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.pdf"`, certificate.Serial))
+	return c.Send(h.certificateService.RenderPDF(certificate))
+}
+
+func (h *CertificateHandler) Verify(c *fiber.Ctx) error {
+	serial := c.Params("serial")
+
+	verification := h.certificateService.Verify(serial)
+	return utils.SendSuccess(c, verification)
+}
+
+func (h *CertificateHandler) GetPublicKey(c *fiber.Ctx) error {
+	return utils.SendSuccess(c, fiber.Map{
+		"algorithm": "Ed25519",
+		"publicKey": h.certificateService.PublicKey(),
+	})
+}
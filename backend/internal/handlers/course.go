@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"errors"
+
+	"mentorsphere-api/internal/models"
 	"mentorsphere-api/internal/services"
 	"mentorsphere-api/pkg/utils"
 
@@ -18,14 +21,17 @@ func NewCourseHandler() *CourseHandler {
 }
 
 func (h *CourseHandler) GetAll(c *fiber.Ctx) error {
-	courses := h.courseService.GetAll()
+	userID := c.Locals("userId").(string)
+
+	courses := h.courseService.GetAllForUser(userID)
 	return utils.SendSuccess(c, courses)
 }
 
 func (h *CourseHandler) GetByID(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
 	courseID := c.Params("id")
 
-	course := h.courseService.GetByID(courseID)
+	course := h.courseService.GetUserCourse(userID, courseID)
 	if course == nil {
 		return utils.SendNotFound(c, "Course tidak ditemukan")
 	}
@@ -34,9 +40,10 @@ func (h *CourseHandler) GetByID(c *fiber.Ctx) error {
 }
 
 func (h *CourseHandler) GetModules(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
 	courseID := c.Params("id")
 
-	modules := h.courseService.GetModules(courseID)
+	modules := h.courseService.GetModules(userID, courseID)
 	if modules == nil {
 		return utils.SendNotFound(c, "Course tidak ditemukan")
 	}
@@ -45,29 +52,38 @@ func (h *CourseHandler) GetModules(c *fiber.Ctx) error {
 }
 
 func (h *CourseHandler) UpdateModuleStatus(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
 	courseID := c.Params("id")
 	moduleID := c.Params("moduleId")
 
-	var req struct {
-		Status string `json:"status"`
-		Score  *int   `json:"score,omitempty"`
-	}
+	var req models.UpdateModuleStatusRequest
 	if err := c.BodyParser(&req); err != nil {
 		return utils.SendBadRequest(c, "Invalid request body")
 	}
 
-	return utils.SendSuccess(c, fiber.Map{
-		"success":  true,
-		"courseId": courseID,
-		"moduleId": moduleID,
-		"status":   req.Status,
-	})
+	update, err := h.courseService.UpdateModuleStatus(userID, courseID, moduleID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCourseNotFound), errors.Is(err, services.ErrModuleNotFound):
+			return utils.SendNotFound(c, err.Error())
+		case errors.Is(err, services.ErrCourseNotEnrolled):
+			return utils.SendError(c, fiber.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrModuleLocked):
+			return utils.SendError(c, fiber.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrInvalidModuleProgress):
+			return utils.SendBadRequest(c, err.Error())
+		}
+		return utils.SendInternalError(c, "Failed to update module status")
+	}
+
+	return utils.SendSuccess(c, update)
 }
 
 func (h *CourseHandler) GetQuizSummary(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
 	courseID := c.Params("id")
 
-	summary := h.courseService.GetQuizSummary(courseID)
+	summary := h.courseService.GetQuizSummary(userID, courseID)
 	if summary == nil {
 		return utils.SendNotFound(c, "Course tidak ditemukan")
 	}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DigestHandler struct {
+	digestService *services.DigestService
+}
+
+func NewDigestHandler() *DigestHandler {
+	return &DigestHandler{
+		digestService: services.NewDigestService(),
+	}
+}
+
+// Preview renders the user's weekly digest; ?format=html returns the e-mail
+// body as it would be sent, ?format=text the plain-text version
+func (h *DigestHandler) Preview(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	digest, err := h.digestService.Preview(userID, c.QueryInt("year", 0), c.QueryInt("week", 0))
+	if err != nil {
+		if errors.Is(err, services.ErrDigestUnavailable) {
+			return utils.SendError(c, fiber.StatusForbidden, err.Error())
+		}
+		return utils.SendBadRequest(c, err.Error())
+	}
+
+	switch c.Query("format", "") {
+	case "html":
+		c.Type("html", "utf-8")
+		return c.SendString(digest.HTML)
+	case "text":
+		c.Type("txt", "utf-8")
+		return c.SendString(digest.Text)
+	}
+
+	return utils.SendSuccess(c, digest)
+}
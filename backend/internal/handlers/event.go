@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"mentorsphere-api/internal/config"
+	"mentorsphere-api/internal/events"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// Delay the browser waits before reconnecting a dropped stream
+const streamRetryMillis = 3000
+
+type EventHandler struct {
+	heartbeat time.Duration
+}
+
+func NewEventHandler(cfg *config.Config) *EventHandler {
+	heartbeat := time.Duration(cfg.EventHeartbeatSeconds) * time.Second
+	if heartbeat <= 0 {
+		heartbeat = 25 * time.Second
+	}
+	return &EventHandler{heartbeat: heartbeat}
+}
+
+// Stream sends the user's events as Server-Sent Events until the client goes
+// away. Reconnecting clients send Last-Event-ID (or ?lastEventId=) to get the
+// events they missed.
+func (h *EventHandler) Stream(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+	lastEventID := c.Get("Last-Event-ID", c.Query("lastEventId", ""))
+
+	sub, err := events.Default().Subscribe(userID, lastEventID)
+	if err != nil {
+		return utils.SendInternalError(c, err.Error())
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	heartbeat := h.heartbeat
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		fmt.Fprintf(w, "retry: %d\n\n", streamRetryMillis)
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-sub.Events:
+				if !ok {
+					// Dropped by the bus; the client reconnects and resumes
+					return
+				}
+				if err := writeEvent(w, event); err != nil {
+					return
+				}
+			case <-ticker.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			// A failed flush means the client has disconnected
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}))
+
+	return nil
+}
+
+func writeEvent(w *bufio.Writer, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
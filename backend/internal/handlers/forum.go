@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"errors"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ForumHandler struct {
+	forumService *services.ForumService
+}
+
+func NewForumHandler() *ForumHandler {
+	return &ForumHandler{
+		forumService: services.NewForumService(),
+	}
+}
+
+func (h *ForumHandler) GetThreads(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	page, err := h.forumService.ListThreads(userID, models.ForumThreadQuery{
+		CourseID: c.Params("courseId"),
+		ModuleID: c.QueryInt("moduleId", 0),
+		Sort:     c.Query("sort", ""),
+		Limit:    c.QueryInt("limit", 0),
+		Offset:   c.QueryInt("offset", 0),
+	})
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccess(c, page)
+}
+
+func (h *ForumHandler) CreateThread(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.CreateForumThreadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	thread, err := h.forumService.CreateThread(userID, c.Params("courseId"), req)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Thread created", thread)
+}
+
+func (h *ForumHandler) GetThread(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	thread, err := h.forumService.GetThread(userID, c.Params("id"))
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccess(c, thread)
+}
+
+func (h *ForumHandler) Reply(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.CreateForumReplyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	reply, err := h.forumService.Reply(userID, c.Params("id"), req)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Reply posted", reply)
+}
+
+func (h *ForumHandler) UpvoteThread(c *fiber.Ctx) error {
+	return h.upvoteThread(c, true)
+}
+
+func (h *ForumHandler) RemoveThreadUpvote(c *fiber.Ctx) error {
+	return h.upvoteThread(c, false)
+}
+
+func (h *ForumHandler) upvoteThread(c *fiber.Ctx, upvote bool) error {
+	userID := c.Locals("userId").(string)
+
+	thread, err := h.forumService.UpvoteThread(userID, c.Params("id"), upvote)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccess(c, thread)
+}
+
+func (h *ForumHandler) UpvoteReply(c *fiber.Ctx) error {
+	return h.upvoteReply(c, true)
+}
+
+func (h *ForumHandler) RemoveReplyUpvote(c *fiber.Ctx) error {
+	return h.upvoteReply(c, false)
+}
+
+func (h *ForumHandler) upvoteReply(c *fiber.Ctx, upvote bool) error {
+	userID := c.Locals("userId").(string)
+
+	reply, err := h.forumService.UpvoteReply(userID, c.Params("id"), upvote)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccess(c, reply)
+}
+
+func (h *ForumHandler) EndorseReply(c *fiber.Ctx) error {
+	return h.endorseReply(c, true)
+}
+
+func (h *ForumHandler) RemoveEndorsement(c *fiber.Ctx) error {
+	return h.endorseReply(c, false)
+}
+
+func (h *ForumHandler) endorseReply(c *fiber.Ctx, endorse bool) error {
+	userID := c.Locals("userId").(string)
+
+	reply, err := h.forumService.EndorseReply(userID, c.Params("id"), endorse)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccess(c, reply)
+}
+
+func (h *ForumHandler) ModerateThread(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.ForumModerationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	thread, err := h.forumService.ModerateThread(userID, c.Params("id"), req)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccess(c, thread)
+}
+
+func (h *ForumHandler) ModerateReply(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.ForumModerationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	reply, err := h.forumService.ModerateReply(userID, c.Params("id"), req)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccess(c, reply)
+}
+
+func (h *ForumHandler) ReportThread(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.ForumReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	report, err := h.forumService.ReportThread(userID, c.Params("id"), req)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Report submitted", report)
+}
+
+func (h *ForumHandler) ReportReply(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.ForumReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	report, err := h.forumService.ReportReply(userID, c.Params("id"), req)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Report submitted", report)
+}
+
+func (h *ForumHandler) GetReports(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	reports, err := h.forumService.ListReports(userID, c.Params("courseId"), c.Query("status", ""))
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccess(c, reports)
+}
+
+func (h *ForumHandler) ResolveReport(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.ResolveForumReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	report, err := h.forumService.ResolveReport(userID, c.Params("id"), req)
+	if err != nil {
+		return sendForumError(c, err)
+	}
+
+	return utils.SendSuccess(c, report)
+}
+
+func sendForumError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrForumPostNotFound),
+		errors.Is(err, services.ErrForumReportNotFound),
+		errors.Is(err, services.ErrCourseNotFound),
+		errors.Is(err, services.ErrModuleNotFound):
+		return utils.SendNotFound(c, err.Error())
+	case errors.Is(err, services.ErrForumForbidden),
+		errors.Is(err, services.ErrForumModeratorOnly),
+		errors.Is(err, services.ErrForumLocked):
+		return utils.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvalidForumRequest):
+		return utils.SendBadRequest(c, err.Error())
+	default:
+		return utils.SendInternalError(c, err.Error())
+	}
+}
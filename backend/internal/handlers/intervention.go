@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type InterventionHandler struct {
+	interventionService *services.InterventionService
+}
+
+func NewInterventionHandler() *InterventionHandler {
+	return &InterventionHandler{
+		interventionService: services.NewInterventionService(),
+	}
+}
+
+func (h *InterventionHandler) GetMine(c *fiber.Ctx) error {
+	studentID := c.Locals("userId").(string)
+
+	interventions := h.interventionService.ListByStudent(studentID, false)
+	return utils.SendSuccess(c, interventions)
+}
+
+func (h *InterventionHandler) Acknowledge(c *fiber.Ctx) error {
+	studentID := c.Locals("userId").(string)
+
+	intervention, err := h.interventionService.Acknowledge(studentID, c.Params("id"))
+	if err != nil {
+		return sendInterventionError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Intervention acknowledged", intervention)
+}
+
+func (h *InterventionHandler) Respond(c *fiber.Ctx) error {
+	studentID := c.Locals("userId").(string)
+
+	var req models.InterventionResponseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	intervention, err := h.interventionService.Respond(studentID, c.Params("id"), req.Response)
+	if err != nil {
+		return sendInterventionError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Response sent", intervention)
+}
+
+func (h *InterventionHandler) GetEffectiveness(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	return h.sendEffectiveness(c, mentorID)
+}
+
+func (h *InterventionHandler) GetAllEffectiveness(c *fiber.Ctx) error {
+	return h.sendEffectiveness(c, c.Query("mentorId", ""))
+}
+
+func (h *InterventionHandler) sendEffectiveness(c *fiber.Ctx, mentorID string) error {
+	report, err := h.interventionService.GetEffectiveness(services.EffectivenessQuery{
+		MentorID:   mentorID,
+		WindowDays: c.QueryInt("windowDays", 0),
+		From:       c.Query("from", ""),
+		To:         c.Query("to", ""),
+	})
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
+
+	return utils.SendSuccess(c, report)
+}
+
+func (h *InterventionHandler) GetTemplates(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	templates := h.interventionService.GetTemplates(mentorID)
+	return utils.SendSuccess(c, templates)
+}
+
+func (h *InterventionHandler) CreateTemplate(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	var req models.SaveInterventionTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	template, err := h.interventionService.CreateTemplate(mentorID, req)
+	if err != nil {
+		return sendInterventionError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Template created", template)
+}
+
+func (h *InterventionHandler) UpdateTemplate(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	var req models.SaveInterventionTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	template, err := h.interventionService.UpdateTemplate(mentorID, c.Params("id"), req)
+	if err != nil {
+		return sendInterventionError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Template saved", template)
+}
+
+func (h *InterventionHandler) DeleteTemplate(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	if err := h.interventionService.DeleteTemplate(mentorID, c.Params("id")); err != nil {
+		return sendInterventionError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Template deleted", nil)
+}
+
+func (h *InterventionHandler) PreviewBulk(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	var req models.BulkInterventionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	preview, err := h.interventionService.PreviewBulk(mentorID, req)
+	if err != nil {
+		return sendInterventionError(c, err)
+	}
+
+	return utils.SendSuccess(c, preview)
+}
+
+func (h *InterventionHandler) CreateBulk(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	var req models.BulkInterventionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	result, err := h.interventionService.CreateBulk(mentorID, req)
+	if err != nil {
+		return sendInterventionError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Interventions created", result)
+}
+
+// sendInterventionError maps intervention service errors to HTTP responses
+func sendInterventionError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInterventionNotFound), errors.Is(err, services.ErrTemplateNotFound):
+		return utils.SendNotFound(c, err.Error())
+	case errors.Is(err, services.ErrInterventionForbidden), errors.Is(err, services.ErrTemplateReadOnly):
+		return utils.SendError(c, fiber.StatusForbidden, err.Error())
+	default:
+		return utils.SendBadRequest(c, err.Error())
+	}
+}
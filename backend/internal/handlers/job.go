@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+
+	"mentorsphere-api/internal/scheduler"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JobHandler struct{}
+
+func NewJobHandler() *JobHandler {
+	return &JobHandler{}
+}
+
+func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
+	s := scheduler.Default()
+	if s == nil {
+		return utils.SendError(c, fiber.StatusServiceUnavailable, "Scheduler is disabled")
+	}
+
+	return utils.SendSuccess(c, s.Jobs())
+}
+
+func (h *JobHandler) GetRuns(c *fiber.Ctx) error {
+	s := scheduler.Default()
+	if s == nil {
+		return utils.SendError(c, fiber.StatusServiceUnavailable, "Scheduler is disabled")
+	}
+
+	runs, err := s.Runs(c.Params("name"), c.QueryInt("limit", 20))
+	if err != nil {
+		return utils.SendNotFound(c, err.Error())
+	}
+
+	return utils.SendSuccess(c, runs)
+}
+
+func (h *JobHandler) TriggerJob(c *fiber.Ctx) error {
+	s := scheduler.Default()
+	if s == nil {
+		return utils.SendError(c, fiber.StatusServiceUnavailable, "Scheduler is disabled")
+	}
+
+	run, err := s.Trigger(c.Params("name"))
+	if errors.Is(err, scheduler.ErrJobNotFound) {
+		return utils.SendNotFound(c, err.Error())
+	}
+	if err != nil {
+		return utils.SendError(c, fiber.StatusConflict, err.Error())
+	}
+
+	return utils.SendSuccessWithMessage(c, "Job started", run)
+}
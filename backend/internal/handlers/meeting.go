@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"mentorsphere-api/internal/config"
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MeetingHandler struct {
+	meetingService *services.MeetingService
+	publicURL      string
+}
+
+func NewMeetingHandler(cfg *config.Config) *MeetingHandler {
+	return &MeetingHandler{
+		meetingService: services.NewMeetingService(),
+		publicURL:      strings.TrimRight(cfg.PublicURL, "/"),
+	}
+}
+
+func (h *MeetingHandler) GetSlots(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	slots := h.meetingService.ListSlots(mentorID)
+	return utils.SendSuccess(c, slots)
+}
+
+func (h *MeetingHandler) CreateSlot(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	var req models.CreateSlotRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	slot, err := h.meetingService.CreateSlot(mentorID, req)
+	if err != nil {
+		return sendMeetingError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Slot created", slot)
+}
+
+func (h *MeetingHandler) DeleteSlot(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+
+	if err := h.meetingService.DeleteSlot(mentorID, c.Params("id")); err != nil {
+		return sendMeetingError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Slot removed", nil)
+}
+
+func (h *MeetingHandler) GetOpenSlots(c *fiber.Ctx) error {
+	studentID := c.Locals("userId").(string)
+
+	slots := h.meetingService.ListOpenSlots(studentID)
+	return utils.SendSuccess(c, slots)
+}
+
+func (h *MeetingHandler) GetMeetings(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	meetings := h.meetingService.ListMeetings(userID)
+	return utils.SendSuccess(c, meetings)
+}
+
+func (h *MeetingHandler) Book(c *fiber.Ctx) error {
+	studentID := c.Locals("userId").(string)
+
+	var req models.BookMeetingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	meeting, err := h.meetingService.Book(studentID, req)
+	if err != nil {
+		return sendMeetingError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Meeting booked", meeting)
+}
+
+func (h *MeetingHandler) Reschedule(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.RescheduleMeetingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	meeting, err := h.meetingService.Reschedule(userID, c.Params("id"), req)
+	if err != nil {
+		return sendMeetingError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Meeting rescheduled", meeting)
+}
+
+func (h *MeetingHandler) Cancel(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.CancelMeetingRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.SendBadRequest(c, "Invalid request body")
+		}
+	}
+
+	meeting, err := h.meetingService.Cancel(userID, c.Params("id"), req.Reason)
+	if err != nil {
+		return sendMeetingError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Meeting cancelled", meeting)
+}
+
+func (h *MeetingHandler) GetCalendarFeed(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	token, err := h.meetingService.GetFeedToken(userID)
+	if err != nil {
+		return utils.SendInternalError(c, err.Error())
+	}
+
+	return utils.SendSuccess(c, fiber.Map{"url": h.feedURL(token)})
+}
+
+func (h *MeetingHandler) ResetCalendarFeed(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	token, err := h.meetingService.ResetFeedToken(userID)
+	if err != nil {
+		return utils.SendInternalError(c, err.Error())
+	}
+
+	return utils.SendSuccessWithMessage(c, "Calendar feed URL replaced", fiber.Map{"url": h.feedURL(token)})
+}
+
+// ServeCalendar serves the iCalendar feed; the secret token in the URL
+// authenticates calendar apps, which cannot send a bearer token
+func (h *MeetingHandler) ServeCalendar(c *fiber.Ctx) error {
+	token := strings.TrimSuffix(c.Params("token"), ".ics")
+
+	feed, err := h.meetingService.RenderFeed(token)
+	if err != nil {
+		return utils.SendNotFound(c, "Kalender tidak ditemukan")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="mentorsphere.ics"`)
+	return c.Send(feed)
+}
+
+func (h *MeetingHandler) feedURL(token string) string {
+	return h.publicURL + "/api/calendar/" + token + ".ics"
+}
+
+// sendMeetingError maps meeting service errors to HTTP responses
+func sendMeetingError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrSlotNotFound), errors.Is(err, services.ErrMeetingNotFound):
+		return utils.SendNotFound(c, err.Error())
+	case errors.Is(err, services.ErrMeetingConflict), errors.Is(err, services.ErrSlotUnavailable):
+		return utils.SendError(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrMeetingForbidden):
+		return utils.SendError(c, fiber.StatusForbidden, err.Error())
+	default:
+		return sendInterventionError(c, err)
+	}
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+
 	"mentorsphere-api/internal/models"
 	"mentorsphere-api/internal/services"
 	"mentorsphere-api/pkg/utils"
@@ -21,7 +23,19 @@ func NewMentorHandler() *MentorHandler {
 func (h *MentorHandler) GetDashboard(c *fiber.Ctx) error {
 	mentorID := c.Locals("userId").(string)
 
-	dashboard := h.mentorService.GetDashboard(mentorID)
+	dashboard, err := h.mentorService.GetDashboard(mentorID, services.MentorDashboardQuery{
+		From:     c.Query("from", ""),
+		To:       c.Query("to", ""),
+		Days:     c.QueryInt("days", 0),
+		CourseID: c.Query("courseId", ""),
+	})
+	if errors.Is(err, services.ErrCourseNotFound) {
+		return utils.SendNotFound(c, err.Error())
+	}
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
+
 	return utils.SendSuccess(c, dashboard)
 }
 
@@ -33,9 +47,12 @@ func (h *MentorHandler) GetStudents(c *fiber.Ctx) error {
 }
 
 func (h *MentorHandler) GetStudentDetail(c *fiber.Ctx) error {
-	studentID := c.Params("id")
+	mentorID := c.Locals("userId").(string)
 
-	detail, err := h.mentorService.GetStudentDetail(studentID)
+	detail, err := h.mentorService.GetStudentDetail(mentorID, c.Params("id"))
+	if errors.Is(err, services.ErrStudentNotAssigned) {
+		return utils.SendError(c, fiber.StatusForbidden, err.Error())
+	}
 	if err != nil {
 		return utils.SendNotFound(c, err.Error())
 	}
@@ -43,6 +60,25 @@ func (h *MentorHandler) GetStudentDetail(c *fiber.Ctx) error {
 	return utils.SendSuccess(c, detail)
 }
 
+func (h *MentorHandler) GetStudentRiskHistory(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
+	studentID := c.Params("id")
+	days := c.QueryInt("days", 30)
+	if days < 1 || days > 365 {
+		return utils.SendBadRequest(c, "days must be between 1 and 365")
+	}
+
+	history, err := h.mentorService.GetStudentRiskHistory(mentorID, studentID, days)
+	if errors.Is(err, services.ErrStudentNotAssigned) {
+		return utils.SendError(c, fiber.StatusForbidden, err.Error())
+	}
+	if err != nil {
+		return utils.SendNotFound(c, err.Error())
+	}
+
+	return utils.SendSuccess(c, history)
+}
+
 func (h *MentorHandler) CreateIntervention(c *fiber.Ctx) error {
 	mentorID := c.Locals("userId").(string)
 
@@ -53,7 +89,7 @@ func (h *MentorHandler) CreateIntervention(c *fiber.Ctx) error {
 
 	intervention, err := h.mentorService.CreateIntervention(mentorID, req)
 	if err != nil {
-		return utils.SendInternalError(c, err.Error())
+		return sendInterventionError(c, err)
 	}
 
 	return utils.SendSuccessWithMessage(c, "Intervention created", intervention)
@@ -66,41 +102,30 @@ func (h *MentorHandler) GetInterventions(c *fiber.Ctx) error {
 	return utils.SendSuccess(c, interventions)
 }
 
-func (h *MentorHandler) UpdateInterventionStatus(c *fiber.Ctx) error {
-	interventionID := c.Params("id")
-
-	var req models.UpdateInterventionRequest
-	if err := c.BodyParser(&req); err != nil {
-		return utils.SendBadRequest(c, "Invalid request body")
-	}
+func (h *MentorHandler) GetIntervention(c *fiber.Ctx) error {
+	mentorID := c.Locals("userId").(string)
 
-	if err := h.mentorService.UpdateInterventionStatus(interventionID, req); err != nil {
-		return utils.SendNotFound(c, err.Error())
+	intervention, err := h.mentorService.GetIntervention(mentorID, c.Params("id"))
+	if err != nil {
+		return sendInterventionError(c, err)
 	}
 
-	return utils.SendSuccess(c, fiber.Map{
-		"success":        true,
-		"interventionId": interventionID,
-		"status":         req.Status,
-	})
+	return utils.SendSuccess(c, intervention)
 }
 
-func (h *MentorHandler) GetNotifications(c *fiber.Ctx) error {
+func (h *MentorHandler) UpdateInterventionStatus(c *fiber.Ctx) error {
 	mentorID := c.Locals("userId").(string)
+	interventionID := c.Params("id")
 
-	notifications := h.mentorService.GetNotifications(mentorID)
-	return utils.SendSuccess(c, notifications)
-}
-
-func (h *MentorHandler) MarkNotificationRead(c *fiber.Ctx) error {
-	notificationID := c.Params("id")
+	var req models.UpdateInterventionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
 
-	if err := h.mentorService.MarkNotificationRead(notificationID); err != nil {
-		return utils.SendNotFound(c, err.Error())
+	intervention, err := h.mentorService.UpdateInterventionStatus(mentorID, interventionID, req)
+	if err != nil {
+		return sendInterventionError(c, err)
 	}
 
-	return utils.SendSuccess(c, fiber.Map{
-		"success":        true,
-		"notificationId": notificationID,
-	})
+	return utils.SendSuccess(c, intervention)
 }
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MessageHandler struct {
+	messageService *services.MessageService
+}
+
+func NewMessageHandler() *MessageHandler {
+	return &MessageHandler{
+		messageService: services.NewMessageService(),
+	}
+}
+
+func (h *MessageHandler) GetConversations(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	conversations, err := h.messageService.ListConversations(userID)
+	if err != nil {
+		return utils.SendNotFound(c, "User not found")
+	}
+
+	return utils.SendSuccess(c, conversations)
+}
+
+func (h *MessageHandler) GetMessages(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	page, err := h.messageService.List(userID, models.MessageQuery{
+		ConversationID: c.Params("id"),
+		Before:         c.Query("before", ""),
+		After:          c.Query("after", ""),
+		Limit:          c.QueryInt("limit", 0),
+	})
+	if err != nil {
+		return sendMessageError(c, err)
+	}
+
+	return utils.SendSuccess(c, page)
+}
+
+func (h *MessageHandler) Send(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.SendMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	message, err := h.messageService.Send(userID, c.Params("id"), req)
+	if err != nil {
+		return sendMessageError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Message sent", message)
+}
+
+func (h *MessageHandler) MarkRead(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	receipt, err := h.messageService.MarkRead(userID, c.Params("id"))
+	if err != nil {
+		return sendMessageError(c, err)
+	}
+
+	return utils.SendSuccess(c, receipt)
+}
+
+func (h *MessageHandler) Search(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	messages, err := h.messageService.Search(userID, c.Query("q", ""), c.QueryInt("limit", 0))
+	if err != nil {
+		return sendMessageError(c, err)
+	}
+
+	return utils.SendSuccess(c, messages)
+}
+
+func sendMessageError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrConversationNotFound):
+		return utils.SendNotFound(c, err.Error())
+	case errors.Is(err, services.ErrConversationForbidden):
+		return utils.SendError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvalidMessage):
+		return utils.SendBadRequest(c, err.Error())
+	default:
+		return utils.SendInternalError(c, err.Error())
+	}
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"mentorsphere-api/internal/delivery"
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+	deliveryService     *services.DeliveryService
+}
+
+func NewNotificationHandler() *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: services.NewNotificationService(),
+		deliveryService:     services.NewDeliveryService(),
+	}
+}
+
+func (h *NotificationHandler) GetNotifications(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	page, err := h.notificationService.List(models.NotificationQuery{
+		UserID:     userID,
+		Types:      splitTypes(c.Query("type", "")),
+		UnreadOnly: c.QueryBool("unread", false),
+		Archived:   c.QueryBool("archived", false),
+		Cursor:     c.Query("cursor", ""),
+		Limit:      c.QueryInt("limit", 0),
+	})
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
+
+	return utils.SendSuccess(c, page)
+}
+
+func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	return utils.SendSuccess(c, h.notificationService.UnreadCount(userID))
+}
+
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	notification, err := h.notificationService.MarkRead(userID, c.Params("id"))
+	if err != nil {
+		return sendNotificationError(c, err)
+	}
+
+	return utils.SendSuccess(c, notification)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	count, err := h.notificationService.MarkAllRead(userID, splitTypes(c.Query("type", "")))
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
+
+	return utils.SendSuccess(c, fiber.Map{
+		"updated": count,
+	})
+}
+
+func (h *NotificationHandler) Archive(c *fiber.Ctx) error {
+	return h.setArchived(c, true)
+}
+
+func (h *NotificationHandler) Unarchive(c *fiber.Ctx) error {
+	return h.setArchived(c, false)
+}
+
+func (h *NotificationHandler) setArchived(c *fiber.Ctx, archived bool) error {
+	userID := c.Locals("userId").(string)
+
+	notification, err := h.notificationService.SetArchived(userID, c.Params("id"), archived)
+	if err != nil {
+		return sendNotificationError(c, err)
+	}
+
+	return utils.SendSuccess(c, notification)
+}
+
+func (h *NotificationHandler) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	if err := h.notificationService.Delete(userID, c.Params("id")); err != nil {
+		return sendNotificationError(c, err)
+	}
+
+	return utils.SendSuccessWithMessage(c, "Notification deleted", nil)
+}
+
+func (h *NotificationHandler) GetDeliveries(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	deliveries, err := h.notificationService.Deliveries(userID, c.Params("id"))
+	if err != nil {
+		return sendNotificationError(c, err)
+	}
+
+	return utils.SendSuccess(c, deliveries)
+}
+
+func (h *NotificationHandler) GetAllDeliveries(c *fiber.Ctx) error {
+	deliveries, err := h.deliveryService.ListByStatus(c.Query("status", ""), c.QueryInt("limit", 50))
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
+
+	return utils.SendSuccess(c, deliveries)
+}
+
+func (h *NotificationHandler) GetPushPublicKey(c *fiber.Ctx) error {
+	key := delivery.PushPublicKey()
+	if key == "" {
+		return utils.SendNotFound(c, "Push notifications are not configured")
+	}
+
+	return utils.SendSuccess(c, fiber.Map{
+		"publicKey": key,
+	})
+}
+
+func (h *NotificationHandler) SubscribePush(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.PushSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	subscription, err := h.deliveryService.Subscribe(userID, c.Get("User-Agent"), req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPushSubscription) {
+			return utils.SendBadRequest(c, err.Error())
+		}
+		if errors.Is(err, services.ErrPushSubscriptionTaken) {
+			return utils.SendError(c, fiber.StatusConflict, err.Error())
+		}
+		return utils.SendInternalError(c, err.Error())
+	}
+
+	return utils.SendSuccessWithMessage(c, "Push subscription saved", subscription)
+}
+
+func (h *NotificationHandler) UnsubscribePush(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.PushSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	if err := h.deliveryService.Unsubscribe(userID, req.Endpoint); err != nil {
+		return utils.SendNotFound(c, "Push subscription not found")
+	}
+
+	return utils.SendSuccessWithMessage(c, "Push subscription removed", nil)
+}
+
+// splitTypes parses a comma-separated type filter
+func splitTypes(value string) []string {
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func sendNotificationError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrNotificationNotFound):
+		return utils.SendNotFound(c, err.Error())
+	case errors.Is(err, services.ErrNotificationForbidden):
+		return utils.SendError(c, fiber.StatusForbidden, err.Error())
+	default:
+		return utils.SendInternalError(c, err.Error())
+	}
+}
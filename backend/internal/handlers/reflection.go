@@ -35,18 +35,35 @@ func (h *ReflectionHandler) GetDailyReflection(c *fiber.Ctx) error {
 	userID := c.Locals("userId").(string)
 	date := c.Query("date", "")
 
-	daily := h.reflectionService.GetDailyReflection(userID, date)
+	daily, err := h.reflectionService.GetDailyReflection(userID, date)
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
+
 	return utils.SendSuccess(c, daily)
 }
 
 func (h *ReflectionHandler) GetWeeklyInsight(c *fiber.Ctx) error {
 	userID := c.Locals("userId").(string)
-	weekNumber := c.QueryInt("week", 1)
+	year := c.QueryInt("year", 0)
+	week := c.QueryInt("week", 0)
+
+	weekly, err := h.reflectionService.GetWeeklyInsight(userID, year, week)
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
 
-	weekly := h.reflectionService.GetWeeklyInsight(userID, weekNumber)
 	return utils.SendSuccess(c, weekly)
 }
 
+func (h *ReflectionHandler) GetWeeklyInsightHistory(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+	limit := c.QueryInt("limit", 12)
+
+	insights := h.reflectionService.ListWeeklyInsights(userID, limit)
+	return utils.SendSuccess(c, insights)
+}
+
 func (h *ReflectionHandler) GetLearningPath(c *fiber.Ctx) error {
 	userID := c.Locals("userId").(string)
 
@@ -57,6 +74,10 @@ func (h *ReflectionHandler) GetLearningPath(c *fiber.Ctx) error {
 func (h *ReflectionHandler) GetRiskAssessment(c *fiber.Ctx) error {
 	userID := c.Locals("userId").(string)
 
-	riskAssessment := h.reflectionService.GetRiskAssessment(userID)
+	riskAssessment, err := h.reflectionService.GetRiskAssessment(userID)
+	if err != nil {
+		return utils.SendNotFound(c, err.Error())
+	}
+
 	return utils.SendSuccess(c, riskAssessment)
 }
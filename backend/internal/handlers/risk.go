@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RiskHandler struct {
+	riskService *services.RiskService
+}
+
+func NewRiskHandler() *RiskHandler {
+	return &RiskHandler{
+		riskService: services.NewRiskService(),
+	}
+}
+
+func (h *RiskHandler) GetConfig(c *fiber.Ctx) error {
+	return utils.SendSuccess(c, h.riskService.GetConfig())
+}
+
+func (h *RiskHandler) UpdateConfig(c *fiber.Ctx) error {
+	var req models.RiskConfig
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	config, err := h.riskService.UpdateConfig(req)
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
+
+	return utils.SendSuccessWithMessage(c, "Risk configuration saved", config)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/services"
+	"mentorsphere-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StreakHandler struct {
+	streakService      *services.StreakService
+	achievementService *services.AchievementService
+}
+
+func NewStreakHandler() *StreakHandler {
+	return &StreakHandler{
+		streakService:      services.NewStreakService(),
+		achievementService: services.NewAchievementService(),
+	}
+}
+
+func (h *StreakHandler) GetStreak(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	summary := h.streakService.GetSummary(userID)
+	return utils.SendSuccess(c, summary)
+}
+
+func (h *StreakHandler) GetHistory(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+	days := c.QueryInt("days", 30)
+
+	if days < 1 || days > 365 {
+		return utils.SendBadRequest(c, "days must be between 1 and 365")
+	}
+
+	history := h.streakService.GetHistory(userID, days)
+	return utils.SendSuccess(c, history)
+}
+
+func (h *StreakHandler) UseFreeze(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req models.UseStreakFreezeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendBadRequest(c, "Invalid request body")
+	}
+
+	if req.Date == "" {
+		return utils.SendBadRequest(c, "Date is required")
+	}
+
+	summary, err := h.streakService.UseFreeze(userID, req.Date)
+	if err != nil {
+		return utils.SendBadRequest(c, err.Error())
+	}
+
+	// A freeze can extend the streak past a badge threshold
+	h.achievementService.Evaluate(userID)
+
+	return utils.SendSuccessWithMessage(c, "Streak freeze applied", summary)
+}
@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Oldest entries are evicted once the cache holds this many answers
+const maxCacheEntries = 1000
+
+type cacheEntry struct {
+	text      string
+	expiresAt time.Time
+}
+
+// CachingGenerator memoizes answers of another generator by request content
+type CachingGenerator struct {
+	next    TextGenerator
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	order   []string
+}
+
+// NewCachingGenerator wraps next with a cache whose entries live for ttl
+func NewCachingGenerator(next TextGenerator, ttl time.Duration) *CachingGenerator {
+	return &CachingGenerator{
+		next:    next,
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// Generate returns a cached answer or asks the wrapped generator
+func (g *CachingGenerator) Generate(ctx context.Context, req Request) (string, error) {
+	key, err := cacheKey(req)
+	if err != nil {
+		return g.next.Generate(ctx, req)
+	}
+
+	g.mu.Lock()
+	entry, ok := g.entries[key]
+	g.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.text, nil
+	}
+
+	text, err := g.next.Generate(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, exists := g.entries[key]; !exists {
+		g.order = append(g.order, key)
+	}
+	g.entries[key] = cacheEntry{text: text, expiresAt: time.Now().Add(g.ttl)}
+	for len(g.order) > maxCacheEntries {
+		delete(g.entries, g.order[0])
+		g.order = g.order[1:]
+	}
+
+	return text, nil
+}
+
+func cacheKey(req Request) (string, error) {
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,81 @@
+// Package llm generates short natural-language texts (reflection summaries,
+// insights) from structured facts through a pluggable TextGenerator.
+package llm
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+)
+
+// Request asks for one text rendered from a named prompt template
+type Request struct {
+	Template  string
+	Language  string
+	Facts     map[string]interface{}
+	MaxTokens int
+}
+
+// TextGenerator produces text for a request
+type TextGenerator interface {
+	Generate(ctx context.Context, req Request) (string, error)
+}
+
+// ErrEmptyResponse is returned when a provider answers without any text
+var ErrEmptyResponse = errors.New("llm: empty response")
+
+var (
+	defaultGenerator TextGenerator
+	defaultTimeout   = 10 * time.Second
+	maxOutputChars   = 1200
+)
+
+// SetDefault installs the generator used by GenerateOrFallback; nil disables generation
+func SetDefault(generator TextGenerator, timeout time.Duration) {
+	defaultGenerator = generator
+	if timeout > 0 {
+		defaultTimeout = timeout
+	}
+}
+
+// Default returns the installed generator, or nil when generation is disabled
+func Default() TextGenerator {
+	return defaultGenerator
+}
+
+// GenerateOrFallback generates text with the default generator and returns
+// fallback when generation is disabled or fails. The second result reports
+// whether the text came from the generator.
+func GenerateOrFallback(req Request, fallback string) (string, bool) {
+	if defaultGenerator == nil {
+		return fallback, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	text, err := defaultGenerator.Generate(ctx, req)
+	if err != nil {
+		log.Printf("llm: %s generation failed, using fallback: %v", req.Template, err)
+		return fallback, false
+	}
+
+	return limitOutput(text), true
+}
+
+// limitOutput trims whitespace and cuts overly long answers at a sentence boundary
+func limitOutput(text string) string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+	if len(runes) <= maxOutputChars {
+		return text
+	}
+
+	cut := string(runes[:maxOutputChars])
+	if i := strings.LastIndexAny(cut, ".!?"); i > 0 {
+		return cut[:i+1]
+	}
+	return cut
+}
@@ -0,0 +1,116 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenAIGenerator talks to any OpenAI-compatible chat completions API,
+// including local servers that implement the same endpoint.
+type OpenAIGenerator struct {
+	baseURL   string
+	apiKey    string
+	model     string
+	maxTokens int
+	client    *http.Client
+}
+
+// NewOpenAIGenerator creates a generator for the API at baseURL (e.g. https://api.openai.com/v1)
+func NewOpenAIGenerator(baseURL, apiKey, model string, maxTokens int, timeout time.Duration) *OpenAIGenerator {
+	return &OpenAIGenerator{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		apiKey:    apiKey,
+		model:     model,
+		maxTokens: maxTokens,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Generate sends the rendered prompt to the chat completions endpoint
+func (g *OpenAIGenerator) Generate(ctx context.Context, req Request) (string, error) {
+	system, user, err := renderPrompt(req)
+	if err != nil {
+		return "", err
+	}
+
+	maxTokens := g.maxTokens
+	if req.MaxTokens > 0 && (maxTokens == 0 || req.MaxTokens < maxTokens) {
+		maxTokens = req.MaxTokens
+	}
+
+	body, err := json.Marshal(chatRequest{
+		Model: g.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: system},
+			{Role: "user", Content: user},
+		},
+		MaxTokens:   maxTokens,
+		Temperature: 0.3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("llm: failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("llm: failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if g.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+g.apiKey)
+	}
+
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("llm: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("llm: failed to read response: %w", err)
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(payload, &parsed); err != nil {
+		return "", fmt.Errorf("llm: invalid response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if parsed.Error != nil {
+			return "", fmt.Errorf("llm: provider error (status %d): %s", resp.StatusCode, parsed.Error.Message)
+		}
+		return "", fmt.Errorf("llm: provider returned status %d", resp.StatusCode)
+	}
+	if len(parsed.Choices) == 0 || strings.TrimSpace(parsed.Choices[0].Message.Content) == "" {
+		return "", ErrEmptyResponse
+	}
+
+	return parsed.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,32 @@
+package llm
+
+import (
+	"log"
+	"time"
+
+	"mentorsphere-api/internal/config"
+)
+
+// Init installs the default generator selected by the configuration
+func Init(cfg *config.Config) {
+	timeout := time.Duration(cfg.LLMTimeoutSeconds) * time.Second
+
+	var generator TextGenerator
+	switch cfg.LLMProvider {
+	case "openai":
+		generator = NewOpenAIGenerator(cfg.LLMBaseURL, cfg.LLMAPIKey, cfg.LLMModel, cfg.LLMMaxTokens, timeout)
+	case "stub":
+		generator = NewStubGenerator()
+	default:
+		log.Println("LLM provider disabled, using rule-based texts")
+		SetDefault(nil, timeout)
+		return
+	}
+
+	if cfg.LLMCacheTTLMinutes > 0 {
+		generator = NewCachingGenerator(generator, time.Duration(cfg.LLMCacheTTLMinutes)*time.Minute)
+	}
+
+	log.Printf("✅ LLM provider %q initialized", cfg.LLMProvider)
+	SetDefault(generator, timeout)
+}
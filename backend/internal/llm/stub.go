@@ -0,0 +1,24 @@
+package llm
+
+import "context"
+
+// StubGenerator answers deterministically from the request facts without
+// any network access. It is meant for development and tests.
+type StubGenerator struct{}
+
+// NewStubGenerator creates a stub generator
+func NewStubGenerator() *StubGenerator {
+	return &StubGenerator{}
+}
+
+// Generate renders the template's stub answer
+func (g *StubGenerator) Generate(ctx context.Context, req Request) (string, error) {
+	text, err := renderStub(req)
+	if err != nil {
+		return "", err
+	}
+	if text == "" {
+		return "", ErrEmptyResponse
+	}
+	return text, nil
+}
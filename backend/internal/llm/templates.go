@@ -0,0 +1,162 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/template"
+)
+
+// Facts are trimmed to this encoded size before being sent to a provider
+const maxFactsBytes = 4000
+
+// Prompt template names
+const (
+	TemplateDailyReflection = "daily_reflection"
+	TemplateStudentInsight  = "student_insight"
+)
+
+// promptTemplate renders the system and user messages for a request. Stub
+// renders the deterministic offline answer from the same facts.
+type promptTemplate struct {
+	System string
+	User   string
+	Stub   string
+}
+
+var promptTemplates = map[string]promptTemplate{
+	TemplateDailyReflection: {
+		System: "You are a supportive learning coach for an online learning platform. " +
+			"Write in {{.Language}}. Use only the facts provided; never invent courses, numbers or events. " +
+			"Answer with 2-3 sentences of plain text and no lists.",
+		User: "Summarize this student's study day for them, mentioning time studied, " +
+			"the main course, the comparison with their recent average and any quiz results.\n\nFacts (JSON):\n{{.FactsJSON}}",
+		Stub: "{{with .Facts}}Ringkasan {{.date}}: {{.totalMinutes}} menit belajar dalam {{.activityCount}} aktivitas " +
+			"(rata-rata sebelumnya {{.recentAverage}} menit).{{end}}",
+	},
+	TemplateStudentInsight: {
+		System: "You are a supportive learning coach for an online learning platform. " +
+			"Write in {{.Language}}. Use only the facts provided; never invent courses, numbers or events. " +
+			"Answer with at most 2 short sentences of plain text.",
+		User: "Give the student one actionable insight for today based on their recent activity, " +
+			"their streak and the module they should continue.\n\nFacts (JSON):\n{{.FactsJSON}}",
+		Stub: "{{with .Facts}}Minggu ini {{.minutesThisWeek}} menit belajar (minggu lalu {{.minutesLastWeek}} menit)." +
+			"{{if .nextModule}} Lanjutkan modul {{.nextModule}}.{{end}}{{end}}",
+	},
+}
+
+type promptData struct {
+	Language  string
+	Facts     map[string]interface{}
+	FactsJSON string
+}
+
+// renderPrompt returns the system and user messages for a request
+func renderPrompt(req Request) (string, string, error) {
+	tmpl, ok := promptTemplates[req.Template]
+	if !ok {
+		return "", "", fmt.Errorf("llm: unknown template %q", req.Template)
+	}
+
+	data, err := newPromptData(req)
+	if err != nil {
+		return "", "", err
+	}
+
+	system, err := execute(tmpl.System, data)
+	if err != nil {
+		return "", "", err
+	}
+	user, err := execute(tmpl.User, data)
+	if err != nil {
+		return "", "", err
+	}
+	return system, user, nil
+}
+
+// renderStub returns the deterministic offline answer for a request
+func renderStub(req Request) (string, error) {
+	tmpl, ok := promptTemplates[req.Template]
+	if !ok {
+		return "", fmt.Errorf("llm: unknown template %q", req.Template)
+	}
+
+	data, err := newPromptData(req)
+	if err != nil {
+		return "", err
+	}
+	return execute(tmpl.Stub, data)
+}
+
+func newPromptData(req Request) (promptData, error) {
+	factsJSON, err := encodeFacts(req.Facts)
+	if err != nil {
+		return promptData{}, err
+	}
+
+	language := "Indonesian"
+	if req.Language == "en" {
+		language = "English"
+	}
+
+	return promptData{
+		Language:  language,
+		Facts:     req.Facts,
+		FactsJSON: string(factsJSON),
+	}, nil
+}
+
+// encodeFacts serializes the facts, dropping the largest entries until the
+// result fits in maxFactsBytes so the provider always receives valid JSON
+func encodeFacts(facts map[string]interface{}) ([]byte, error) {
+	factsJSON, err := json.Marshal(facts)
+	if err != nil {
+		return nil, fmt.Errorf("llm: failed to encode facts: %w", err)
+	}
+	if len(factsJSON) <= maxFactsBytes {
+		return factsJSON, nil
+	}
+
+	sizes := make(map[string]int, len(facts))
+	keys := make([]string, 0, len(facts))
+	for key, value := range facts {
+		encoded, _ := json.Marshal(value)
+		sizes[key] = len(key) + len(encoded)
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if sizes[keys[i]] != sizes[keys[j]] {
+			return sizes[keys[i]] > sizes[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	trimmed := make(map[string]interface{}, len(facts))
+	for key, value := range facts {
+		trimmed[key] = value
+	}
+	for _, key := range keys {
+		delete(trimmed, key)
+		if factsJSON, err = json.Marshal(trimmed); err != nil {
+			return nil, fmt.Errorf("llm: failed to encode facts: %w", err)
+		}
+		if len(factsJSON) <= maxFactsBytes {
+			break
+		}
+	}
+	return factsJSON, nil
+}
+
+func execute(text string, data promptData) (string, error) {
+	tmpl, err := template.New("prompt").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("llm: invalid template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("llm: failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
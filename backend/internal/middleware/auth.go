@@ -37,6 +37,17 @@ func AuthMiddleware(cfg *config.Config) fiber.Handler {
 	}
 }
 
+// QueryTokenMiddleware accepts the bearer token as ?token= for clients that
+// cannot set headers, like the browser EventSource
+func QueryTokenMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" && c.Query("token") != "" {
+			c.Request().Header.Set("Authorization", "Bearer "+c.Query("token"))
+		}
+		return c.Next()
+	}
+}
+
 func RoleMiddleware(allowedRoles ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role := c.Locals("role").(string)
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// BadgeDefinition describes a badge and the rule that awards it
+type BadgeDefinition struct {
+	ID          string    `json:"id" firestore:"id"`
+	Name        string    `json:"name" firestore:"name"`
+	Icon        string    `json:"icon" firestore:"icon"`
+	Description string    `json:"description" firestore:"description"`
+	Rule        BadgeRule `json:"rule" firestore:"rule"`
+	Active      bool      `json:"active" firestore:"active"`
+	CreatedAt   time.Time `json:"createdAt" firestore:"createdAt"`
+}
+
+// BadgeRule compares a named achievement metric against a threshold
+type BadgeRule struct {
+	Metric    string  `json:"metric" firestore:"metric"`
+	Operator  string  `json:"operator" firestore:"operator"`
+	Threshold float64 `json:"threshold" firestore:"threshold"`
+}
+
+type BadgeAward struct {
+	ID        string    `json:"id" firestore:"id"`
+	UserID    string    `json:"userId" firestore:"userId"`
+	BadgeID   string    `json:"badgeId" firestore:"badgeId"`
+	AwardedAt time.Time `json:"awardedAt" firestore:"awardedAt"`
+}
+
+type BadgeDefinitionRequest struct {
+	ID          string    `json:"id" validate:"required"`
+	Name        string    `json:"name" validate:"required"`
+	Icon        string    `json:"icon"`
+	Description string    `json:"description"`
+	Rule        BadgeRule `json:"rule" validate:"required"`
+	Active      *bool     `json:"active,omitempty"`
+}
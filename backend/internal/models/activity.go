@@ -11,6 +11,9 @@ type ActivityLog struct {
 	Date     time.Time `json:"date" firestore:"date"`
 	CourseID string    `json:"courseId" firestore:"courseId"`
 	Score    *int      `json:"score,omitempty" firestore:"score,omitempty"`
+
+	// Module of the course the activity belongs to, when known
+	ModuleID int `json:"moduleId,omitempty" firestore:"moduleId,omitempty"`
 }
 
 type WeeklyActivity struct {
@@ -31,3 +34,16 @@ type ActivityResponse struct {
 	Breakdown   ActivityBreakdown `json:"breakdown"`
 	TotalTime   int               `json:"totalTime"`
 }
+
+// ActivityRollup is the activity total of one user on one local day
+type ActivityRollup struct {
+	UserID          string         `json:"userId" firestore:"userId"`
+	Date            string         `json:"date" firestore:"date"`
+	Minutes         int            `json:"minutes" firestore:"minutes"`
+	Activities      int            `json:"activities" firestore:"activities"`
+	MinutesByType   map[string]int `json:"minutesByType" firestore:"minutesByType"`
+	MinutesByCourse map[string]int `json:"minutesByCourse" firestore:"minutesByCourse"`
+	QuizCount       int            `json:"quizCount" firestore:"quizCount"`
+	QuizScoreSum    int            `json:"quizScoreSum" firestore:"quizScoreSum"`
+	UpdatedAt       time.Time      `json:"updatedAt" firestore:"updatedAt"`
+}
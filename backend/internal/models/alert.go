@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// AlertRule is a mentor's early-warning condition. Threshold is interpreted
+// per type: days (inactivity), score (risk_threshold), failed attempts in a
+// row (failed_quizzes), overdue modules (missed_deadline) or risk points per
+// day (falling_trend).
+type AlertRule struct {
+	MentorID      string    `json:"mentorId" firestore:"mentorId"`
+	Type          string    `json:"type" firestore:"type"`
+	Threshold     float64   `json:"threshold" firestore:"threshold"`
+	Severity      string    `json:"severity" firestore:"severity"` // warning, danger
+	CooldownHours int       `json:"cooldownHours" firestore:"cooldownHours"`
+	Enabled       bool      `json:"enabled" firestore:"enabled"`
+	UpdatedAt     time.Time `json:"updatedAt" firestore:"updatedAt"`
+}
+
+type UpdateAlertRuleRequest struct {
+	Threshold     *float64 `json:"threshold"`
+	Severity      *string  `json:"severity"`
+	CooldownHours *int     `json:"cooldownHours"`
+	Enabled       *bool    `json:"enabled"`
+}
+
+// AlertEvent remembers when a rule last fired for a student, for cool-downs
+type AlertEvent struct {
+	MentorID    string    `json:"mentorId" firestore:"mentorId"`
+	StudentID   string    `json:"studentId" firestore:"studentId"`
+	RuleType    string    `json:"ruleType" firestore:"ruleType"`
+	Message     string    `json:"message" firestore:"message"`
+	LastFiredAt time.Time `json:"lastFiredAt" firestore:"lastFiredAt"`
+	FireCount   int       `json:"fireCount" firestore:"fireCount"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// MentorAssignment links a student to a mentor for a period of time. Ended
+// assignments are kept as the assignment history.
+type MentorAssignment struct {
+	ID        string `json:"id" firestore:"id"`
+	MentorID  string `json:"mentorId" firestore:"mentorId"`
+	StudentID string `json:"studentId" firestore:"studentId"`
+	// Source is manual, reassign, auto or backfill
+	Source     string     `json:"source" firestore:"source"`
+	AssignedBy string     `json:"assignedBy,omitempty" firestore:"assignedBy,omitempty"`
+	Note       string     `json:"note,omitempty" firestore:"note,omitempty"`
+	Active     bool       `json:"active" firestore:"active"`
+	StartedAt  time.Time  `json:"startedAt" firestore:"startedAt"`
+	EndedAt    *time.Time `json:"endedAt,omitempty" firestore:"endedAt,omitempty"`
+	EndedBy    string     `json:"endedBy,omitempty" firestore:"endedBy,omitempty"`
+}
+
+// AssignmentConfig controls auto-balancing of students across mentors
+type AssignmentConfig struct {
+	// AutoAssign assigns newly registered students to a mentor right away
+	AutoAssign bool `json:"autoAssign" firestore:"autoAssign"`
+	// DefaultCapacity applies to mentors without their own MentorCapacity
+	DefaultCapacity int       `json:"defaultCapacity" firestore:"defaultCapacity"`
+	UpdatedAt       time.Time `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// MentorLoad is a mentor's current assignment load
+type MentorLoad struct {
+	MentorID         string   `json:"mentorId"`
+	Name             string   `json:"name"`
+	Students         int      `json:"students"`
+	Capacity         int      `json:"capacity"`
+	AtRisk           int      `json:"atRisk"`
+	ExpertiseCourses []string `json:"expertiseCourses"`
+}
+
+// BalanceResult lists the assignments auto-balancing made, or would make on
+// a dry run, and the students no mentor had room for
+type BalanceResult struct {
+	DryRun      bool               `json:"dryRun"`
+	Assignments []MentorAssignment `json:"assignments"`
+	Unassigned  []string           `json:"unassigned"`
+	Loads       []MentorLoad       `json:"loads"`
+}
+
+type AssignmentQuery struct {
+	MentorID  string
+	StudentID string
+	// ActiveOnly leaves out ended assignments
+	ActiveOnly bool
+}
+
+type AssignRequest struct {
+	MentorID  string `json:"mentorId"`
+	StudentID string `json:"studentId"`
+	Note      string `json:"note"`
+}
+
+type ReassignRequest struct {
+	StudentID    string `json:"studentId"`
+	FromMentorID string `json:"fromMentorId"`
+	ToMentorID   string `json:"toMentorId"`
+	Note         string `json:"note"`
+}
+
+// MentorProfileRequest sets a mentor's auto-balancing profile; nil fields
+// stay as they are
+type MentorProfileRequest struct {
+	Capacity         *int     `json:"capacity,omitempty"`
+	ExpertiseCourses []string `json:"expertiseCourses,omitempty"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type Certificate struct {
+	ID          string    `json:"id" firestore:"id"`
+	Serial      string    `json:"serial" firestore:"serial"`
+	UserID      string    `json:"userId" firestore:"userId"`
+	UserName    string    `json:"userName" firestore:"userName"`
+	CourseID    string    `json:"courseId" firestore:"courseId"`
+	CourseTitle string    `json:"courseTitle" firestore:"courseTitle"`
+	IssuedAt    time.Time `json:"issuedAt" firestore:"issuedAt"`
+	Signature   string    `json:"signature" firestore:"signature"`
+}
+
+type CertificateVerification struct {
+	Valid       bool       `json:"valid"`
+	Serial      string     `json:"serial"`
+	UserName    string     `json:"userName,omitempty"`
+	CourseTitle string     `json:"courseTitle,omitempty"`
+	IssuedAt    *time.Time `json:"issuedAt,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+}
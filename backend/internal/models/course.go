@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Course struct {
 	ID               string   `json:"id" firestore:"id"`
 	Title            string   `json:"title" firestore:"title"`
@@ -13,6 +15,8 @@ type Course struct {
 	Category         string   `json:"category" firestore:"category"`
 	Level            string   `json:"level" firestore:"level"`
 	Modules          []Module `json:"modules" firestore:"modules"`
+	// IDs of courses to complete before this one
+	Prerequisites []string `json:"prerequisites,omitempty" firestore:"prerequisites,omitempty"`
 }
 
 type Module struct {
@@ -22,6 +26,11 @@ type Module struct {
 	Type     string `json:"type" firestore:"type"`
 	Status   string `json:"status" firestore:"status"`
 	Score    *int   `json:"score,omitempty" firestore:"score,omitempty"`
+	// IDs of modules in the same course to complete first; when empty the
+	// module before it in the course is the prerequisite
+	Prerequisites []int `json:"prerequisites,omitempty" firestore:"prerequisites,omitempty"`
+	// Optional deadline for completing the module
+	DueDate *time.Time `json:"dueDate,omitempty" firestore:"dueDate,omitempty"`
 }
 
 type QuizSummary struct {
@@ -42,6 +51,29 @@ type QuizDTO struct {
 }
 
 type UpdateModuleStatusRequest struct {
-	Status string `json:"status" validate:"required,oneof=locked in-progress completed"`
+	Status string `json:"status" validate:"required,oneof=in-progress completed"`
 	Score  *int   `json:"score,omitempty"`
 }
+
+// ModuleProgress is one student's status and score on a module. Its ID is the
+// user, course and module ID, so each student has one record per module.
+type ModuleProgress struct {
+	ID          string     `json:"id" firestore:"id"`
+	UserID      string     `json:"userId" firestore:"userId"`
+	CourseID    string     `json:"courseId" firestore:"courseId"`
+	ModuleID    int        `json:"moduleId" firestore:"moduleId"`
+	Status      string     `json:"status" firestore:"status"`
+	Score       *int       `json:"score,omitempty" firestore:"score,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty" firestore:"completedAt,omitempty"`
+	UpdatedAt   time.Time  `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// ModuleProgressUpdate describes a student's module status change and their
+// course progress after it; it is also streamed to the student and their
+// mentors
+type ModuleProgressUpdate struct {
+	ModuleProgress
+	Progress         int `json:"progress"`
+	CompletedModules int `json:"completedModules"`
+	TotalModules     int `json:"totalModules"`
+}
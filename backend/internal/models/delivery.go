@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// NotificationDelivery records how a notification went out on one channel
+type NotificationDelivery struct {
+	ID             string `json:"id" firestore:"id"`
+	NotificationID string `json:"notificationId" firestore:"notificationId"`
+	UserID         string `json:"userId" firestore:"userId"`
+	// Channel is in_app, email or push
+	Channel string `json:"channel" firestore:"channel"`
+	// Status is sent, retrying, failed or skipped
+	Status   string `json:"status" firestore:"status"`
+	Attempts int    `json:"attempts" firestore:"attempts"`
+	// Reason explains a skipped delivery or holds the last error
+	Reason        string     `json:"reason,omitempty" firestore:"reason,omitempty"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty" firestore:"nextAttemptAt,omitempty"`
+	SentAt        *time.Time `json:"sentAt,omitempty" firestore:"sentAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// PushSubscription is a browser's Web Push subscription
+type PushSubscription struct {
+	// ID is derived from the endpoint so re-subscribing replaces the entry
+	ID        string    `json:"id" firestore:"id"`
+	UserID    string    `json:"userId" firestore:"userId"`
+	Endpoint  string    `json:"endpoint" firestore:"endpoint"`
+	P256DH    string    `json:"p256dh" firestore:"p256dh"`
+	Auth      string    `json:"auth" firestore:"auth"`
+	UserAgent string    `json:"userAgent,omitempty" firestore:"userAgent,omitempty"`
+	CreatedAt time.Time `json:"createdAt" firestore:"createdAt"`
+}
+
+// PushSubscriptionRequest is the JSON of a browser PushSubscription
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256DH string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
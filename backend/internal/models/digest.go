@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// WeeklyDigest is the weekly progress report of a student or mentor, rendered
+// for e-mail. Exactly one of Student and Mentor is set.
+type WeeklyDigest struct {
+	UserID      string         `json:"userId"`
+	Name        string         `json:"name"`
+	Role        string         `json:"role"`
+	Language    string         `json:"language"`
+	Year        int            `json:"year"`
+	WeekNumber  int            `json:"weekNumber"`
+	WeekStart   string         `json:"weekStart"`
+	WeekEnd     string         `json:"weekEnd"`
+	Student     *StudentDigest `json:"student,omitempty"`
+	Mentor      *MentorDigest  `json:"mentor,omitempty"`
+	Subject     string         `json:"subject"`
+	Text        string         `json:"text"`
+	HTML        string         `json:"html"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+}
+
+type StudentDigest struct {
+	MinutesStudied   int `json:"minutesStudied"`
+	PreviousMinutes  int `json:"previousMinutes"`
+	ChangePercent    int `json:"changePercent"`
+	ActiveDays       int `json:"activeDays"`
+	ModulesCompleted int `json:"modulesCompleted"`
+	CurrentStreak    int `json:"currentStreak"`
+	LongestStreak    int `json:"longestStreak"`
+	// NextSteps are the in-progress modules to continue
+	NextSteps []DigestModule `json:"nextSteps"`
+}
+
+type DigestModule struct {
+	Module string `json:"module"`
+	Course string `json:"course"`
+}
+
+type MentorDigest struct {
+	Students        int `json:"students"`
+	ActiveStudents  int `json:"activeStudents"`
+	TotalMinutes    int `json:"totalMinutes"`
+	AverageProgress int `json:"averageProgress"`
+	// NewAtRisk are students who reached high risk during the week
+	NewAtRisk            []DigestStudent      `json:"newAtRisk"`
+	PendingInterventions []DigestIntervention `json:"pendingInterventions"`
+}
+
+type DigestStudent struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	RiskScore int    `json:"riskScore"`
+}
+
+type DigestIntervention struct {
+	ID          string `json:"id"`
+	StudentName string `json:"studentName"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	// DaysOpen counts the days since the intervention was sent
+	DaysOpen int `json:"daysOpen"`
+}
+
+// DigestSend records that a user's digest for a week was sent. Its ID is the
+// user ID and ISO week, so a week's digest can only be claimed once.
+type DigestSend struct {
+	ID     string    `json:"id" firestore:"id"`
+	UserID string    `json:"userId" firestore:"userId"`
+	Week   string    `json:"week" firestore:"week"`
+	SentAt time.Time `json:"sentAt" firestore:"sentAt"`
+}
@@ -0,0 +1,122 @@
+package models
+
+import "time"
+
+// ForumThread is a discussion thread of a course, optionally about one of its
+// modules
+type ForumThread struct {
+	ID       string `json:"id" firestore:"id"`
+	CourseID string `json:"courseId" firestore:"courseId"`
+	// ModuleID is 0 for course-wide threads
+	ModuleID   int      `json:"moduleId,omitempty" firestore:"moduleId"`
+	AuthorID   string   `json:"authorId" firestore:"authorId"`
+	AuthorName string   `json:"authorName" firestore:"authorName"`
+	AuthorRole string   `json:"authorRole" firestore:"authorRole"`
+	Title      string   `json:"title" firestore:"title"`
+	Body       string   `json:"body" firestore:"body"`
+	Mentions   []string `json:"mentions,omitempty" firestore:"mentions,omitempty"`
+	Upvoters   []string `json:"-" firestore:"upvoters"`
+	// UpvoteCount mirrors len(Upvoters) so threads can be ordered by it
+	UpvoteCount int `json:"upvoteCount" firestore:"upvoteCount"`
+	// Upvoted tells whether the requesting user upvoted the thread
+	Upvoted    bool `json:"upvoted" firestore:"-"`
+	ReplyCount int  `json:"replyCount" firestore:"replyCount"`
+	// EndorsedReplyID is the reply a mentor marked as the answer
+	EndorsedReplyID string    `json:"endorsedReplyId,omitempty" firestore:"endorsedReplyId,omitempty"`
+	Pinned          bool      `json:"pinned" firestore:"pinned"`
+	Locked          bool      `json:"locked" firestore:"locked"`
+	Hidden          bool      `json:"hidden" firestore:"hidden"`
+	LastActivityAt  time.Time `json:"lastActivityAt" firestore:"lastActivityAt"`
+	CreatedAt       time.Time `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// ForumReply is a reply to a forum thread
+type ForumReply struct {
+	ID          string   `json:"id" firestore:"id"`
+	ThreadID    string   `json:"threadId" firestore:"threadId"`
+	CourseID    string   `json:"courseId" firestore:"courseId"`
+	AuthorID    string   `json:"authorId" firestore:"authorId"`
+	AuthorName  string   `json:"authorName" firestore:"authorName"`
+	AuthorRole  string   `json:"authorRole" firestore:"authorRole"`
+	Body        string   `json:"body" firestore:"body"`
+	Mentions    []string `json:"mentions,omitempty" firestore:"mentions,omitempty"`
+	Upvoters    []string `json:"-" firestore:"upvoters"`
+	UpvoteCount int      `json:"upvoteCount" firestore:"upvoteCount"`
+	Upvoted     bool     `json:"upvoted" firestore:"-"`
+	// Endorsed replies are marked by a mentor as the answer to the thread
+	Endorsed   bool      `json:"endorsed" firestore:"endorsed"`
+	EndorsedBy string    `json:"endorsedBy,omitempty" firestore:"endorsedBy,omitempty"`
+	Hidden     bool      `json:"hidden" firestore:"hidden"`
+	CreatedAt  time.Time `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// ForumThreadDetail is a thread with its replies, oldest first
+type ForumThreadDetail struct {
+	ForumThread
+	Replies []ForumReply `json:"replies"`
+}
+
+// ForumReport flags a thread or reply for mentors to review
+type ForumReport struct {
+	ID       string `json:"id" firestore:"id"`
+	CourseID string `json:"courseId" firestore:"courseId"`
+	ThreadID string `json:"threadId" firestore:"threadId"`
+	// ReplyID is empty when the thread itself is reported
+	ReplyID    string `json:"replyId,omitempty" firestore:"replyId,omitempty"`
+	ReporterID string `json:"reporterId" firestore:"reporterId"`
+	Reason     string `json:"reason" firestore:"reason"`
+	// Excerpt is the start of the reported text, for reviewing at a glance
+	Excerpt string `json:"excerpt" firestore:"excerpt"`
+	// Status is open, resolved (content hidden) or dismissed
+	Status     string     `json:"status" firestore:"status"`
+	ResolvedBy string     `json:"resolvedBy,omitempty" firestore:"resolvedBy,omitempty"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty" firestore:"resolvedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt" firestore:"createdAt"`
+}
+
+// ForumThreadQuery selects the threads of a course. Pinned threads come first,
+// the rest is ordered by Sort: activity (default), new or top.
+type ForumThreadQuery struct {
+	CourseID string
+	// ModuleID limits the threads to one module when set
+	ModuleID int
+	Sort     string
+	Limit    int
+	Offset   int
+}
+
+type ForumThreadPage struct {
+	Threads []ForumThread `json:"threads"`
+	Total   int           `json:"total"`
+}
+
+type CreateForumThreadRequest struct {
+	ModuleID int    `json:"moduleId"`
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+	// Mentions are the IDs of users to notify
+	Mentions []string `json:"mentions"`
+}
+
+type CreateForumReplyRequest struct {
+	Body     string   `json:"body"`
+	Mentions []string `json:"mentions"`
+}
+
+type ForumReportRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ForumModerationRequest changes the given flags; nil fields stay as they are
+type ForumModerationRequest struct {
+	Pinned *bool `json:"pinned,omitempty"`
+	Locked *bool `json:"locked,omitempty"`
+	Hidden *bool `json:"hidden,omitempty"`
+}
+
+type ResolveForumReportRequest struct {
+	// Status is resolved, which hides the reported content, or dismissed
+	Status string `json:"status"`
+}
@@ -14,18 +14,170 @@ type Intervention struct {
 	Response      *string    `json:"response,omitempty" firestore:"response,omitempty"`
 	ScheduledDate *time.Time `json:"scheduledDate,omitempty" firestore:"scheduledDate,omitempty"`
 	Attachments   []string   `json:"attachments,omitempty" firestore:"attachments,omitempty"`
+	MeetingID     string     `json:"meetingId,omitempty" firestore:"meetingId,omitempty"`
+
+	// Lifecycle timestamps, set when the intervention enters each state
+	SentAt         *time.Time `json:"sentAt,omitempty" firestore:"sentAt,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty" firestore:"acknowledgedAt,omitempty"`
+	RespondedAt    *time.Time `json:"respondedAt,omitempty" firestore:"respondedAt,omitempty"`
+	CompletedAt    *time.Time `json:"completedAt,omitempty" firestore:"completedAt,omitempty"`
+	CancelledAt    *time.Time `json:"cancelledAt,omitempty" firestore:"cancelledAt,omitempty"`
+	UpdatedAt      time.Time  `json:"updatedAt" firestore:"updatedAt"`
+
+	Transitions []InterventionTransition `json:"transitions" firestore:"transitions"`
+
+	// Baseline captured when the intervention reaches the student, and the
+	// outcome measured OutcomeWindowDays later
+	RiskScoreAtSend   *int                 `json:"riskScoreAtSend,omitempty" firestore:"riskScoreAtSend,omitempty"`
+	ModulesAtSend     *int                 `json:"modulesAtSend,omitempty" firestore:"modulesAtSend,omitempty"`
+	OutcomeWindowDays int                  `json:"outcomeWindowDays" firestore:"outcomeWindowDays"`
+	Outcome           *InterventionOutcome `json:"outcome,omitempty" firestore:"outcome,omitempty"`
+}
+
+// InterventionTransition records one status change
+type InterventionTransition struct {
+	From   string    `json:"from" firestore:"from"`
+	To     string    `json:"to" firestore:"to"`
+	At     time.Time `json:"at" firestore:"at"`
+	UserID string    `json:"userId" firestore:"userId"`
+}
+
+// InterventionOutcome compares the student before and after an intervention
+type InterventionOutcome struct {
+	EvaluatedAt   time.Time `json:"evaluatedAt" firestore:"evaluatedAt"`
+	WindowDays    int       `json:"windowDays" firestore:"windowDays"`
+	ReEngaged     bool      `json:"reEngaged" firestore:"reEngaged"`
+	MinutesBefore int       `json:"minutesBefore" firestore:"minutesBefore"`
+	MinutesAfter  int       `json:"minutesAfter" firestore:"minutesAfter"`
+	RiskBefore    int       `json:"riskBefore" firestore:"riskBefore"`
+	RiskAfter     int       `json:"riskAfter" firestore:"riskAfter"`
+	RiskDelta     int       `json:"riskDelta" firestore:"riskDelta"`
+	ModulesBefore int       `json:"modulesBefore" firestore:"modulesBefore"`
+	ModulesAfter  int       `json:"modulesAfter" firestore:"modulesAfter"`
 }
 
 type CreateInterventionRequest struct {
-	StudentID     string     `json:"studentId" validate:"required"`
-	StudentName   string     `json:"studentName" validate:"required"`
-	Type          string     `json:"type" validate:"required,oneof=reminder meeting resource"`
-	Message       string     `json:"message" validate:"required"`
+	StudentID   string `json:"studentId" validate:"required"`
+	StudentName string `json:"studentName"`
+	Type        string `json:"type" validate:"required,oneof=reminder meeting resource"`
+	Message     string `json:"message" validate:"required"`
+	// TemplateID renders the message from a template when Message is empty
+	TemplateID    string     `json:"templateId,omitempty"`
 	ScheduledDate *time.Time `json:"scheduledDate,omitempty"`
 	Attachments   []string   `json:"attachments,omitempty"`
+	// Draft keeps the intervention unsent until it is moved to sent or scheduled
+	Draft             bool `json:"draft,omitempty"`
+	OutcomeWindowDays int  `json:"outcomeWindowDays,omitempty"`
 }
 
 type UpdateInterventionRequest struct {
-	Status   string  `json:"status" validate:"required,oneof=sent scheduled completed cancelled"`
+	Status   string  `json:"status" validate:"required,oneof=sent scheduled acknowledged completed cancelled"`
 	Response *string `json:"response,omitempty"`
 }
+
+type InterventionResponseRequest struct {
+	Response string `json:"response"`
+}
+
+// EffectivenessStats aggregates the outcomes of a group of interventions.
+// Averages are per evaluated intervention; a negative AvgRiskDelta means the
+// students' risk went down.
+type EffectivenessStats struct {
+	Type             string  `json:"type,omitempty"`
+	MentorID         string  `json:"mentorId,omitempty"`
+	MentorName       string  `json:"mentorName,omitempty"`
+	RiskLevel        string  `json:"riskLevel,omitempty"`
+	Interventions    int     `json:"interventions"`
+	ReEngaged        int     `json:"reEngaged"`
+	ReEngagementRate float64 `json:"reEngagementRate"`
+	AvgMinutesBefore float64 `json:"avgMinutesBefore"`
+	AvgMinutesAfter  float64 `json:"avgMinutesAfter"`
+	AvgMinutesChange float64 `json:"avgMinutesChange"`
+	AvgRiskDelta     float64 `json:"avgRiskDelta"`
+	AvgModulesGained float64 `json:"avgModulesGained"`
+}
+
+// EffectivenessReport compares interventions sent between From and To,
+// measured over WindowDays before and after sending. RiskLevel groups use the
+// student's risk level when the intervention was sent.
+type EffectivenessReport struct {
+	MentorID           string               `json:"mentorId,omitempty"`
+	WindowDays         int                  `json:"windowDays"`
+	From               string               `json:"from"`
+	To                 string               `json:"to"`
+	Overall            EffectivenessStats   `json:"overall"`
+	ByType             []EffectivenessStats `json:"byType"`
+	ByRiskLevel        []EffectivenessStats `json:"byRiskLevel"`
+	ByTypeAndRiskLevel []EffectivenessStats `json:"byTypeAndRiskLevel"`
+	ByMentor           []EffectivenessStats `json:"byMentor"`
+	BestTypeByRisk     map[string]string    `json:"bestTypeByRiskLevel"`
+	Pending            int                  `json:"pending"`
+	GeneratedAt        time.Time            `json:"generatedAt"`
+}
+
+// InterventionTemplate is a reusable intervention message. Variants holds the
+// message per language code ("id", "en"); placeholders such as
+// {{studentName}} are filled in per student. Templates without MentorID are
+// built in and shared by every mentor.
+type InterventionTemplate struct {
+	ID              string            `json:"id" firestore:"id"`
+	MentorID        string            `json:"mentorId,omitempty" firestore:"mentorId,omitempty"`
+	Name            string            `json:"name" firestore:"name"`
+	Type            string            `json:"type" firestore:"type"`
+	Variants        map[string]string `json:"variants" firestore:"variants"`
+	DefaultLanguage string            `json:"defaultLanguage" firestore:"defaultLanguage"`
+	BuiltIn         bool              `json:"builtIn" firestore:"-"`
+	CreatedAt       time.Time         `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt       time.Time         `json:"updatedAt" firestore:"updatedAt"`
+}
+
+type SaveInterventionTemplateRequest struct {
+	Name            string            `json:"name"`
+	Type            string            `json:"type"`
+	Variants        map[string]string `json:"variants"`
+	DefaultLanguage string            `json:"defaultLanguage"`
+}
+
+// StudentFilter selects students among a mentor's assigned students. Empty
+// fields do not filter.
+type StudentFilter struct {
+	StudentIDs      []string `json:"studentIds,omitempty"`
+	CourseID        string   `json:"courseId,omitempty"`
+	RiskLevel       string   `json:"riskLevel,omitempty"`
+	MinRiskScore    int      `json:"minRiskScore,omitempty"`
+	MinDaysInactive int      `json:"minDaysInactive,omitempty"`
+}
+
+// BulkInterventionRequest creates one intervention per filtered student from
+// a template, or from Message when no template is given
+type BulkInterventionRequest struct {
+	TemplateID        string        `json:"templateId,omitempty"`
+	Type              string        `json:"type,omitempty"`
+	Message           string        `json:"message,omitempty"`
+	Filter            StudentFilter `json:"filter"`
+	ScheduledDate     *time.Time    `json:"scheduledDate,omitempty"`
+	Draft             bool          `json:"draft,omitempty"`
+	OutcomeWindowDays int           `json:"outcomeWindowDays,omitempty"`
+}
+
+// RenderedIntervention is the message one student would receive
+type RenderedIntervention struct {
+	StudentID    string `json:"studentId"`
+	StudentName  string `json:"studentName"`
+	Language     string `json:"language"`
+	Type         string `json:"type"`
+	Message      string `json:"message"`
+	CourseName   string `json:"courseName,omitempty"`
+	RiskScore    int    `json:"riskScore"`
+	DaysInactive int    `json:"daysInactive"`
+}
+
+type BulkInterventionResult struct {
+	Created []Intervention `json:"created"`
+	Failed  []BulkFailure  `json:"failed"`
+}
+
+type BulkFailure struct {
+	StudentID string `json:"studentId"`
+	Error     string `json:"error"`
+}
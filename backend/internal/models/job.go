@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// JobRun records one execution of a background job
+type JobRun struct {
+	ID           string     `json:"id" firestore:"id"`
+	Job          string     `json:"job" firestore:"job"`
+	Trigger      string     `json:"trigger" firestore:"trigger"` // schedule, manual
+	Holder       string     `json:"holder" firestore:"holder"`
+	Status       string     `json:"status" firestore:"status"` // running, succeeded, failed
+	Attempts     int        `json:"attempts" firestore:"attempts"`
+	Error        string     `json:"error,omitempty" firestore:"error,omitempty"`
+	ScheduledFor time.Time  `json:"scheduledFor" firestore:"scheduledFor"`
+	StartedAt    time.Time  `json:"startedAt" firestore:"startedAt"`
+	FinishedAt   *time.Time `json:"finishedAt,omitempty" firestore:"finishedAt,omitempty"`
+}
+
+// JobLease marks which scheduler instance owns a job. LastSlot is the latest
+// scheduled time that was claimed, so one slot never runs twice.
+type JobLease struct {
+	Job       string    `json:"job" firestore:"job"`
+	Holder    string    `json:"holder" firestore:"holder"`
+	ExpiresAt time.Time `json:"expiresAt" firestore:"expiresAt"`
+	LastSlot  time.Time `json:"lastSlot" firestore:"lastSlot"`
+}
+
+// JobInfo describes a registered job for the admin API
+type JobInfo struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Schedule    string    `json:"schedule"`
+	NextRun     time.Time `json:"nextRun"`
+	Running     bool      `json:"running"`
+	LastRun     *JobRun   `json:"lastRun,omitempty"`
+}
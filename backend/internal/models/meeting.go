@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// AvailabilitySlot is a time range a mentor offers for meetings. Start and End
+// are stored in UTC; Timezone is the zone the mentor entered them in.
+type AvailabilitySlot struct {
+	ID        string    `json:"id" firestore:"id"`
+	MentorID  string    `json:"mentorId" firestore:"mentorId"`
+	Start     time.Time `json:"start" firestore:"start"`
+	End       time.Time `json:"end" firestore:"end"`
+	Timezone  string    `json:"timezone" firestore:"timezone"`
+	Status    string    `json:"status" firestore:"status"`
+	MeetingID string    `json:"meetingId,omitempty" firestore:"meetingId,omitempty"`
+	CreatedAt time.Time `json:"createdAt" firestore:"createdAt"`
+}
+
+// Meeting is a booked slot between a mentor and a student
+type Meeting struct {
+	ID             string     `json:"id" firestore:"id"`
+	SlotID         string     `json:"slotId" firestore:"slotId"`
+	MentorID       string     `json:"mentorId" firestore:"mentorId"`
+	MentorName     string     `json:"mentorName" firestore:"mentorName"`
+	StudentID      string     `json:"studentId" firestore:"studentId"`
+	StudentName    string     `json:"studentName" firestore:"studentName"`
+	InterventionID string     `json:"interventionId,omitempty" firestore:"interventionId,omitempty"`
+	Topic          string     `json:"topic" firestore:"topic"`
+	Start          time.Time  `json:"start" firestore:"start"`
+	End            time.Time  `json:"end" firestore:"end"`
+	Status         string     `json:"status" firestore:"status"`
+	Sequence       int        `json:"sequence" firestore:"sequence"`
+	CreatedAt      time.Time  `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt" firestore:"updatedAt"`
+	CancelledAt    *time.Time `json:"cancelledAt,omitempty" firestore:"cancelledAt,omitempty"`
+	CancelledBy    string     `json:"cancelledBy,omitempty" firestore:"cancelledBy,omitempty"`
+	CancelReason   string     `json:"cancelReason,omitempty" firestore:"cancelReason,omitempty"`
+	CompletedAt    *time.Time `json:"completedAt,omitempty" firestore:"completedAt,omitempty"`
+}
+
+// CalendarFeed holds the secret token of a user's iCalendar feed URL
+type CalendarFeed struct {
+	UserID    string    `json:"userId" firestore:"userId"`
+	Token     string    `json:"token" firestore:"token"`
+	CreatedAt time.Time `json:"createdAt" firestore:"createdAt"`
+}
+
+// CreateSlotRequest uses local times ("2006-01-02T15:04") in Timezone, which
+// defaults to the mentor's timezone setting
+type CreateSlotRequest struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+type BookMeetingRequest struct {
+	SlotID         string `json:"slotId"`
+	Topic          string `json:"topic"`
+	InterventionID string `json:"interventionId,omitempty"`
+}
+
+type RescheduleMeetingRequest struct {
+	SlotID string `json:"slotId"`
+}
+
+type CancelMeetingRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
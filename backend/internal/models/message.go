@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// Message kinds
+const (
+	MessageText                 = "text"
+	MessageIntervention         = "intervention"
+	MessageInterventionResponse = "intervention_response"
+)
+
+// Message is one entry of the conversation between a mentor and a student.
+// Interventions and the student's responses to them are posted to the thread
+// as messages of their own kinds.
+type Message struct {
+	ID             string `json:"id" firestore:"id"`
+	ConversationID string `json:"conversationId" firestore:"conversationId"`
+	// Participants holds the mentor and student IDs for per-user queries
+	Participants   []string            `json:"-" firestore:"participants"`
+	SenderID       string              `json:"senderId" firestore:"senderId"`
+	RecipientID    string              `json:"recipientId" firestore:"recipientId"`
+	Kind           string              `json:"kind" firestore:"kind"`
+	Body           string              `json:"body" firestore:"body"`
+	Attachments    []MessageAttachment `json:"attachments,omitempty" firestore:"attachments,omitempty"`
+	InterventionID string              `json:"interventionId,omitempty" firestore:"interventionId,omitempty"`
+	Read           bool                `json:"read" firestore:"read"`
+	ReadAt         *time.Time          `json:"readAt,omitempty" firestore:"readAt,omitempty"`
+	CreatedAt      time.Time           `json:"createdAt" firestore:"createdAt"`
+}
+
+// MessageAttachment references an uploaded file
+type MessageAttachment struct {
+	Name        string `json:"name" firestore:"name"`
+	URL         string `json:"url,omitempty" firestore:"url,omitempty"`
+	ContentType string `json:"contentType,omitempty" firestore:"contentType,omitempty"`
+	Size        int64  `json:"size,omitempty" firestore:"size,omitempty"`
+}
+
+// Conversation is the thread of an assigned mentor–student pair; its ID is
+// "<mentorId>_<studentId>"
+type Conversation struct {
+	ID          string           `json:"id"`
+	Mentor      ConversationUser `json:"mentor"`
+	Student     ConversationUser `json:"student"`
+	LastMessage *Message         `json:"lastMessage"`
+	UnreadCount int              `json:"unreadCount"`
+}
+
+type ConversationUser struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Avatar string `json:"avatar"`
+}
+
+// MessageQuery selects a page of a conversation. Before pages back through
+// older messages; After returns the messages newer than a message, for polling.
+type MessageQuery struct {
+	ConversationID string
+	Before         string
+	After          string
+	Limit          int
+}
+
+type MessagePage struct {
+	Messages []Message `json:"messages"`
+	// NextCursor is passed as before to get older messages; empty on the last page
+	NextCursor string `json:"nextCursor"`
+}
+
+// MessageReadEvent tells the sender that the recipient read the conversation
+type MessageReadEvent struct {
+	ConversationID string    `json:"conversationId"`
+	ReaderID       string    `json:"readerId"`
+	MessageIDs     []string  `json:"messageIds"`
+	ReadAt         time.Time `json:"readAt"`
+}
+
+type SendMessageRequest struct {
+	Body        string              `json:"body"`
+	Attachments []MessageAttachment `json:"attachments"`
+}
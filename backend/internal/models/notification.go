@@ -1,13 +1,52 @@
 package models
 
+import "time"
+
 type Notification struct {
-	ID      string `json:"id" firestore:"id"`
-	UserID  string `json:"userId" firestore:"userId"`
-	Type    string `json:"type" firestore:"type"`
-	Title   string `json:"title" firestore:"title"`
-	Message string `json:"message" firestore:"message"`
-	Time    string `json:"time" firestore:"time"`
-	Read    bool   `json:"read" firestore:"read"`
+	ID         string     `json:"id" firestore:"id"`
+	UserID     string     `json:"userId" firestore:"userId"`
+	Type       string     `json:"type" firestore:"type"`
+	Category   string     `json:"category" firestore:"category"`
+	Title      string     `json:"title" firestore:"title"`
+	Message    string     `json:"message" firestore:"message"`
+	Read       bool       `json:"read" firestore:"read"`
+	ReadAt     *time.Time `json:"readAt,omitempty" firestore:"readAt,omitempty"`
+	Archived   bool       `json:"archived" firestore:"archived"`
+	ArchivedAt *time.Time `json:"archivedAt,omitempty" firestore:"archivedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt" firestore:"createdAt"`
+}
+
+// NotificationQuery selects one page of a user's notifications
+type NotificationQuery struct {
+	UserID string
+	// Types keeps only these notification types when not empty
+	Types      []string
+	UnreadOnly bool
+	Archived   bool
+	// Cursor is the ID of the last notification of the previous page
+	Cursor string
+	Limit  int
+}
+
+// NotificationPage is one page of notifications, newest first
+type NotificationPage struct {
+	Notifications []Notification `json:"notifications"`
+	// NextCursor is empty on the last page
+	NextCursor  string `json:"nextCursor"`
+	UnreadCount int    `json:"unreadCount"`
+}
+
+// NotificationReadEvent is streamed when notifications are marked read; ID is
+// empty when all of them were
+type NotificationReadEvent struct {
+	ID          string `json:"id,omitempty"`
+	UnreadCount int    `json:"unreadCount"`
+}
+
+// UnreadCount is the number of unread, unarchived notifications
+type UnreadCount struct {
+	Total  int            `json:"total"`
+	ByType map[string]int `json:"byType"`
 }
 
 type MentorStats struct {
@@ -41,6 +80,10 @@ type MentorDashboard struct {
 	Notifications      []Notification     `json:"notifications"`
 	GlobalActivity     []WeeklyActivity   `json:"globalActivity"`
 	RiskDistribution   []RiskDistribution `json:"riskDistribution"`
+	// Date range (mentor's local dates) and course the dashboard covers
+	From     string `json:"from"`
+	To       string `json:"to"`
+	CourseID string `json:"courseId,omitempty"`
 }
 
 type StudentDetail struct {
@@ -1,33 +1,88 @@
 package models
 
+import "time"
+
 type Reflection struct {
-	Daily          DailyReflection  `json:"daily" firestore:"daily"`
-	Weekly         WeeklyInsight    `json:"weekly" firestore:"weekly"`
-	LearningPath   LearningPath     `json:"learningPath" firestore:"learningPath"`
-	RiskAssessment RiskAssessment   `json:"riskAssessment" firestore:"riskAssessment"`
+	Daily          DailyReflection `json:"daily" firestore:"daily"`
+	Weekly         WeeklyInsight   `json:"weekly" firestore:"weekly"`
+	LearningPath   LearningPath    `json:"learningPath" firestore:"learningPath"`
+	RiskAssessment RiskAssessment  `json:"riskAssessment" firestore:"riskAssessment"`
 }
 
 type DailyReflection struct {
-	Date         string   `json:"date" firestore:"date"`
-	Summary      string   `json:"summary" firestore:"summary"`
-	Strengths    []string `json:"strengths" firestore:"strengths"`
-	Improvements []string `json:"improvements" firestore:"improvements"`
-	Mood         string   `json:"mood" firestore:"mood"`
+	UserID          string          `json:"userId,omitempty" firestore:"userId"`
+	Date            string          `json:"date" firestore:"date"`
+	Summary         string          `json:"summary" firestore:"summary"`
+	SummarySource   string          `json:"summarySource" firestore:"summarySource"`
+	Strengths       []string        `json:"strengths" firestore:"strengths"`
+	Improvements    []string        `json:"improvements" firestore:"improvements"`
+	Mood            string          `json:"mood" firestore:"mood"`
+	TotalMinutes    int             `json:"totalMinutes" firestore:"totalMinutes"`
+	ActivityCount   int             `json:"activityCount" firestore:"activityCount"`
+	MinutesByCourse []CourseMinutes `json:"minutesByCourse" firestore:"minutesByCourse"`
+	MinutesByType   map[string]int  `json:"minutesByType" firestore:"minutesByType"`
+	QuizResults     []QuizResult    `json:"quizResults" firestore:"quizResults"`
+	RecentAverage   int             `json:"recentAverage" firestore:"recentAverage"`
+	ChangePercent   int             `json:"changePercent" firestore:"changePercent"`
+	GeneratedAt     time.Time       `json:"generatedAt" firestore:"generatedAt"`
+}
+
+type CourseMinutes struct {
+	CourseID    string `json:"courseId" firestore:"courseId"`
+	CourseTitle string `json:"courseTitle" firestore:"courseTitle"`
+	Minutes     int    `json:"minutes" firestore:"minutes"`
+}
+
+type QuizResult struct {
+	Title    string `json:"title" firestore:"title"`
+	CourseID string `json:"courseId" firestore:"courseId"`
+	Score    int    `json:"score" firestore:"score"`
+	Passed   bool   `json:"passed" firestore:"passed"`
 }
 
+// WeeklyInsight summarizes one ISO week (Monday to Sunday in the user's timezone)
 type WeeklyInsight struct {
-	WeekNumber     int      `json:"weekNumber" firestore:"weekNumber"`
-	TotalStudyTime int      `json:"totalStudyTime" firestore:"totalStudyTime"`
-	AverageDaily   int      `json:"averageDaily" firestore:"averageDaily"`
-	TopSubjects    []string `json:"topSubjects" firestore:"topSubjects"`
-	Insights       []string `json:"insights" firestore:"insights"`
-	Recommendation string   `json:"recommendation" firestore:"recommendation"`
+	UserID             string           `json:"userId,omitempty" firestore:"userId"`
+	Year               int              `json:"year" firestore:"year"`
+	WeekNumber         int              `json:"weekNumber" firestore:"weekNumber"`
+	WeekStart          string           `json:"weekStart" firestore:"weekStart"`
+	WeekEnd            string           `json:"weekEnd" firestore:"weekEnd"`
+	TotalStudyTime     int              `json:"totalStudyTime" firestore:"totalStudyTime"`
+	AverageDaily       int              `json:"averageDaily" firestore:"averageDaily"`
+	ActiveDays         int              `json:"activeDays" firestore:"activeDays"`
+	TopSubjects        []string         `json:"topSubjects" firestore:"topSubjects"`
+	SubjectMinutes     []SubjectMinutes `json:"subjectMinutes" firestore:"subjectMinutes"`
+	DailyMinutes       []DayMinutes     `json:"dailyMinutes" firestore:"dailyMinutes"`
+	BestDay            string           `json:"bestDay" firestore:"bestDay"`
+	BestDayMinutes     int              `json:"bestDayMinutes" firestore:"bestDayMinutes"`
+	TimeOfDay          string           `json:"timeOfDay" firestore:"timeOfDay"`
+	MinutesByTimeOfDay map[string]int   `json:"minutesByTimeOfDay" firestore:"minutesByTimeOfDay"`
+	PreviousWeekTotal  int              `json:"previousWeekTotal" firestore:"previousWeekTotal"`
+	ChangePercent      int              `json:"changePercent" firestore:"changePercent"`
+	Insights           []string         `json:"insights" firestore:"insights"`
+	Recommendation     string           `json:"recommendation" firestore:"recommendation"`
+	GeneratedAt        time.Time        `json:"generatedAt" firestore:"generatedAt"`
+}
+
+type SubjectMinutes struct {
+	Subject string `json:"subject" firestore:"subject"`
+	Minutes int    `json:"minutes" firestore:"minutes"`
+}
+
+type DayMinutes struct {
+	Date    string `json:"date" firestore:"date"`
+	Day     string `json:"day" firestore:"day"`
+	Minutes int    `json:"minutes" firestore:"minutes"`
 }
 
 type LearningPath struct {
 	CurrentPhase        string           `json:"currentPhase" firestore:"currentPhase"`
 	Progress            int              `json:"progress" firestore:"progress"`
+	CompletedModules    int              `json:"completedModules" firestore:"completedModules"`
+	TotalModules        int              `json:"totalModules" firestore:"totalModules"`
 	NextMilestone       string           `json:"nextMilestone" firestore:"nextMilestone"`
+	RemainingMinutes    int              `json:"remainingMinutes" firestore:"remainingMinutes"`
+	WeeklyPace          int              `json:"weeklyPace" firestore:"weeklyPace"`
 	EstimatedCompletion string           `json:"estimatedCompletion" firestore:"estimatedCompletion"`
 	SuggestedTopics     []SuggestedTopic `json:"suggestedTopics" firestore:"suggestedTopics"`
 }
@@ -36,18 +91,40 @@ type SuggestedTopic struct {
 	Title    string `json:"title" firestore:"title"`
 	Priority string `json:"priority" firestore:"priority"`
 	Reason   string `json:"reason" firestore:"reason"`
+	Kind     string `json:"kind" firestore:"kind"` // review, module, course
+	CourseID string `json:"courseId,omitempty" firestore:"courseId,omitempty"`
+	ModuleID int    `json:"moduleId,omitempty" firestore:"moduleId,omitempty"`
 }
 
 type RiskAssessment struct {
-	Score           int              `json:"score" firestore:"score"`
-	Level           string           `json:"level" firestore:"level"`
-	Factors         []RiskFactor     `json:"factors" firestore:"factors"`
-	Explanation     string           `json:"explanation" firestore:"explanation"`
-	Recommendations []string         `json:"recommendations" firestore:"recommendations"`
+	Score           int          `json:"score" firestore:"score"`
+	Level           string       `json:"level" firestore:"level"`
+	Factors         []RiskFactor `json:"factors" firestore:"factors"`
+	Explanation     string       `json:"explanation" firestore:"explanation"`
+	Recommendations []string     `json:"recommendations" firestore:"recommendations"`
+	ComputedAt      time.Time    `json:"computedAt" firestore:"computedAt"`
 }
 
+// RiskFactor is one input of the risk score. Value is a 0-100 health value
+// (higher is better); Contribution is the number of risk points it adds.
 type RiskFactor struct {
-	Name   string `json:"name" firestore:"name"`
-	Value  int    `json:"value" firestore:"value"`
-	Status string `json:"status" firestore:"status"`
+	Key          string  `json:"key" firestore:"key"`
+	Name         string  `json:"name" firestore:"name"`
+	Value        int     `json:"value" firestore:"value"`
+	Status       string  `json:"status" firestore:"status"`
+	Weight       float64 `json:"weight" firestore:"weight"`
+	Contribution float64 `json:"contribution" firestore:"contribution"`
+	Detail       string  `json:"detail" firestore:"detail"`
+}
+
+// RiskConfig holds the tunable parameters of the risk model
+type RiskConfig struct {
+	Weights         map[string]float64 `json:"weights" firestore:"weights"`
+	MediumThreshold int                `json:"mediumThreshold" firestore:"mediumThreshold"`
+	HighThreshold   int                `json:"highThreshold" firestore:"highThreshold"`
+	WindowDays      int                `json:"windowDays" firestore:"windowDays"`
+	MaxInactiveDays int                `json:"maxInactiveDays" firestore:"maxInactiveDays"`
+	TrendWindowDays int                `json:"trendWindowDays" firestore:"trendWindowDays"`
+	TrendThreshold  float64            `json:"trendThreshold" firestore:"trendThreshold"`
+	UpdatedAt       time.Time          `json:"updatedAt" firestore:"updatedAt"`
 }
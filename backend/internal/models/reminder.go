@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// StudyReminder records the reminder sent to a user on a local calendar day.
+// Its ID is the user ID and date, so a day's reminder can only be claimed once.
+type StudyReminder struct {
+	ID           string    `json:"id" firestore:"id"`
+	UserID       string    `json:"userId" firestore:"userId"`
+	Date         string    `json:"date" firestore:"date"`
+	ModuleTitle  string    `json:"moduleTitle,omitempty" firestore:"moduleTitle,omitempty"`
+	CourseTitle  string    `json:"courseTitle,omitempty" firestore:"courseTitle,omitempty"`
+	TodayMinutes int       `json:"todayMinutes" firestore:"todayMinutes"`
+	DailyGoal    int       `json:"dailyGoal" firestore:"dailyGoal"`
+	SentAt       time.Time `json:"sentAt" firestore:"sentAt"`
+}
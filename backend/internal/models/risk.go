@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// RiskSnapshot is the risk score a student had at the end of a day
+type RiskSnapshot struct {
+	UserID           string         `json:"userId" firestore:"userId"`
+	Date             string         `json:"date" firestore:"date"`
+	Score            int            `json:"score" firestore:"score"`
+	Level            string         `json:"level" firestore:"level"`
+	Factors          map[string]int `json:"factors" firestore:"factors"`
+	CompletedModules int            `json:"completedModules" firestore:"completedModules"`
+	WeeklyMinutes    int            `json:"weeklyMinutes" firestore:"weeklyMinutes"`
+	ForumPosts       int            `json:"forumPosts" firestore:"forumPosts"`
+	CreatedAt        time.Time      `json:"createdAt" firestore:"createdAt"`
+}
+
+// RiskTrend summarizes how a risk score moved over the trend window. Slope is
+// in risk points per day; a rising score means the student is declining.
+type RiskTrend struct {
+	Direction     string  `json:"direction"`
+	Slope         float64 `json:"slope"`
+	MovingAverage float64 `json:"movingAverage"`
+	WindowDays    int     `json:"windowDays"`
+	Samples       int     `json:"samples"`
+}
+
+// RiskHistory is a student's risk time series
+type RiskHistory struct {
+	UserID    string         `json:"userId"`
+	Snapshots []RiskSnapshot `json:"snapshots"`
+	Trend     RiskTrend      `json:"trend"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type StreakSummary struct {
+	CurrentStreak  int    `json:"currentStreak"`
+	LongestStreak  int    `json:"longestStreak"`
+	FreezeTokens   int    `json:"freezeTokens"`
+	DailyGoal      int    `json:"dailyGoal"`
+	TodayMinutes   int    `json:"todayMinutes"`
+	GoalProgress   int    `json:"goalProgress"`
+	GoalMet        bool   `json:"goalMet"`
+	LastActiveDate string `json:"lastActiveDate,omitempty"`
+	Timezone       string `json:"timezone"`
+}
+
+type StreakDay struct {
+	Date    string `json:"date"`
+	Minutes int    `json:"minutes"`
+	Active  bool   `json:"active"`
+	GoalMet bool   `json:"goalMet"`
+	Frozen  bool   `json:"frozen"`
+}
+
+type StreakHistory struct {
+	Summary StreakSummary `json:"summary"`
+	Days    []StreakDay   `json:"days"`
+}
+
+// StreakState holds the persisted part of a user's streak; everything else
+// is derived from activity logs.
+type StreakState struct {
+	UserID      string    `json:"userId" firestore:"userId"`
+	FrozenDates []string  `json:"frozenDates" firestore:"frozenDates"`
+	UpdatedAt   time.Time `json:"updatedAt" firestore:"updatedAt"`
+	// Local date each frozen date's token was spent on, by frozen date
+	FrozenOn map[string]string `json:"frozenOn,omitempty" firestore:"frozenOn,omitempty"`
+}
+
+type UseStreakFreezeRequest struct {
+	Date string `json:"date" validate:"required"`
+}
@@ -19,22 +19,32 @@ type User struct {
 	TotalStudyTime   int       `json:"totalStudyTime" firestore:"totalStudyTime"`
 	CompletedModules int       `json:"completedModules" firestore:"completedModules"`
 	RiskScore        int       `json:"riskScore" firestore:"riskScore"`
+	// Mentors only: the most students auto-balancing assigns (0 means the
+	// configured default) and the IDs of the courses they are expert in
+	MentorCapacity   int      `json:"mentorCapacity,omitempty" firestore:"mentorCapacity,omitempty"`
+	ExpertiseCourses []string `json:"expertiseCourses,omitempty" firestore:"expertiseCourses,omitempty"`
 }
 
 type UserStats struct {
-	TotalStudyTime   int `json:"totalStudyTime"`
-	ModulesCompleted int `json:"modulesCompleted"`
-	CoursesEnrolled  int `json:"coursesEnrolled"`
-	Certificates     int `json:"certificates"`
-	Badges           int `json:"badges"`
-	Streak           int `json:"streak"`
+	TotalStudyTime   int  `json:"totalStudyTime"`
+	ModulesCompleted int  `json:"modulesCompleted"`
+	CoursesEnrolled  int  `json:"coursesEnrolled"`
+	Certificates     int  `json:"certificates"`
+	Badges           int  `json:"badges"`
+	Streak           int  `json:"streak"`
+	LongestStreak    int  `json:"longestStreak"`
+	FreezeTokens     int  `json:"freezeTokens"`
+	DailyGoal        int  `json:"dailyGoal"`
+	TodayMinutes     int  `json:"todayMinutes"`
+	DailyGoalMet     bool `json:"dailyGoalMet"`
 }
 
 type Badge struct {
-	ID          int    `json:"id" firestore:"id"`
-	Name        string `json:"name" firestore:"name"`
-	Icon        string `json:"icon" firestore:"icon"`
-	Description string `json:"description" firestore:"description"`
+	ID          string     `json:"id" firestore:"id"`
+	Name        string     `json:"name" firestore:"name"`
+	Icon        string     `json:"icon" firestore:"icon"`
+	Description string     `json:"description" firestore:"description"`
+	AwardedAt   *time.Time `json:"awardedAt,omitempty" firestore:"awardedAt,omitempty"`
 }
 
 type UserSettings struct {
@@ -53,6 +63,10 @@ type NotificationSettings struct {
 	MentorMessages bool `json:"mentorMessages" firestore:"mentorMessages"`
 	CourseUpdates  bool `json:"courseUpdates" firestore:"courseUpdates"`
 	Promotions     bool `json:"promotions" firestore:"promotions"`
+	// Quiet hours ("22:00" to "07:00", may wrap past midnight) hold back
+	// study reminders; empty disables them
+	QuietHoursStart string `json:"quietHoursStart" firestore:"quietHoursStart"`
+	QuietHoursEnd   string `json:"quietHoursEnd" firestore:"quietHoursEnd"`
 }
 
 type AppearanceSettings struct {
@@ -73,6 +87,7 @@ type LearningSettings struct {
 	ReminderTime   string `json:"reminderTime" firestore:"reminderTime"`
 	AutoplayVideos bool   `json:"autoplayVideos" firestore:"autoplayVideos"`
 	Subtitles      bool   `json:"subtitles" firestore:"subtitles"`
+	Timezone       string `json:"timezone" firestore:"timezone"`
 }
 
 type LoginRequest struct {
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"mentorsphere-api/internal/models"
+)
+
+// ActivityRollupRepository handles per-day activity totals
+type ActivityRollupRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewActivityRollupRepository creates a new activity rollup repository
+func NewActivityRollupRepository() *ActivityRollupRepository {
+	return &ActivityRollupRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "activity_rollups",
+	}
+}
+
+// FindByUserID finds a user's rollups between two dates (YYYY-MM-DD, inclusive), oldest first
+func (r *ActivityRollupRepository) FindByUserID(userID, fromDate, toDate string) ([]models.ActivityRollup, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).
+		Where("userId", "==", userID).
+		Where("date", ">=", fromDate).
+		Where("date", "<=", toDate).
+		OrderBy("date", firestore.Asc).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var rollups []models.ActivityRollup
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var rollup models.ActivityRollup
+		if err := doc.DataTo(&rollup); err != nil {
+			continue
+		}
+		rollups = append(rollups, rollup)
+	}
+
+	return rollups, nil
+}
+
+// Save stores the rollup of a user's day
+func (r *ActivityRollupRepository) Save(rollup *models.ActivityRollup) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(rollup.UserID+"_"+rollup.Date).Set(r.GetContext(), rollup)
+	if err != nil {
+		return fmt.Errorf("failed to save activity rollup: %w", err)
+	}
+
+	return nil
+}
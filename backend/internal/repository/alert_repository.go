@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mentorsphere-api/internal/models"
+)
+
+// AlertRepository handles mentor alert rules and fired alert events
+type AlertRepository struct {
+	*BaseRepository
+	ruleCollection  string
+	eventCollection string
+}
+
+// NewAlertRepository creates a new alert repository
+func NewAlertRepository() *AlertRepository {
+	return &AlertRepository{
+		BaseRepository:  NewBaseRepository(),
+		ruleCollection:  "alert_rules",
+		eventCollection: "alert_events",
+	}
+}
+
+// FindRulesByMentorID finds the rules a mentor has configured
+func (r *AlertRepository) FindRulesByMentorID(mentorID string) ([]models.AlertRule, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.ruleCollection).Where("mentorId", "==", mentorID).Documents(r.GetContext())
+	defer iter.Stop()
+
+	var rules []models.AlertRule
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var rule models.AlertRule
+		if err := doc.DataTo(&rule); err != nil {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// SaveRule stores a mentor's rule of one type
+func (r *AlertRepository) SaveRule(rule *models.AlertRule) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.ruleCollection).Doc(rule.MentorID+"_"+rule.Type).Set(r.GetContext(), rule)
+	if err != nil {
+		return fmt.Errorf("failed to save alert rule: %w", err)
+	}
+
+	return nil
+}
+
+// FindEvent finds when a rule last fired for a student, or nil when it never did
+func (r *AlertRepository) FindEvent(mentorID, studentID, ruleType string) (*models.AlertEvent, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.eventCollection).Doc(mentorID + "_" + studentID + "_" + ruleType).Get(r.GetContext())
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find alert event: %w", err)
+	}
+
+	var event models.AlertEvent
+	if err := doc.DataTo(&event); err != nil {
+		return nil, fmt.Errorf("failed to parse alert event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// SaveEvent stores the latest firing of a rule for a student
+func (r *AlertRepository) SaveEvent(event *models.AlertEvent) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.eventCollection).Doc(event.MentorID+"_"+event.StudentID+"_"+event.RuleType).Set(r.GetContext(), event)
+	if err != nil {
+		return fmt.Errorf("failed to save alert event: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEvent forgets the firing of a rule for a student
+func (r *AlertRepository) DeleteEvent(mentorID, studentID, ruleType string) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.eventCollection).Doc(mentorID + "_" + studentID + "_" + ruleType).Delete(r.GetContext())
+	if err != nil {
+		return fmt.Errorf("failed to delete alert event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mentorsphere-api/internal/models"
+)
+
+// Collection of mentor assignments, also read by UserRepository
+const assignmentCollection = "mentor_assignments"
+
+// AssignmentRepository handles mentor–student assignments and their history
+type AssignmentRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewAssignmentRepository creates a new assignment repository
+func NewAssignmentRepository() *AssignmentRepository {
+	return &AssignmentRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: assignmentCollection,
+	}
+}
+
+// Create stores an assignment under its ID. It reports false, storing
+// nothing, when an assignment with that ID already exists.
+func (r *AssignmentRepository) Create(assignment *models.MentorAssignment) (bool, error) {
+	if !r.IsFirestoreAvailable() {
+		return false, fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(assignment.ID).Create(r.GetContext(), assignment)
+	if status.Code(err) == codes.AlreadyExists {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create assignment: %w", err)
+	}
+
+	return true, nil
+}
+
+// Start creates an active assignment after checking, in the same
+// transaction, that the student has no active assignment, so two concurrent
+// requests cannot give a student two mentors. ending, when set, is the
+// student's active assignment being replaced (a reassignment): it must still
+// be active, the new mentor must not be assigned already, and it is saved
+// ended in the same transaction. It reports false, writing nothing, when
+// those checks fail.
+func (r *AssignmentRepository) Start(assignment, ending *models.MentorAssignment) (bool, error) {
+	if !r.IsFirestoreAvailable() {
+		return false, fmt.Errorf("firestore not available")
+	}
+
+	collection := r.GetCollection(r.collectionName)
+	docRef := collection.NewDoc()
+	active := collection.
+		Where("studentId", "==", assignment.StudentID).
+		Where("active", "==", true)
+
+	started := false
+	err := r.client.RunTransaction(r.GetContext(), func(ctx context.Context, tx *firestore.Transaction) error {
+		started = false
+
+		docs, err := tx.Documents(active).GetAll()
+		if err != nil {
+			return err
+		}
+		endingActive := false
+		for _, doc := range docs {
+			switch {
+			case ending == nil, doc.Data()["mentorId"] == assignment.MentorID:
+				return nil
+			case doc.Ref.ID == ending.ID:
+				endingActive = true
+			}
+		}
+		if ending != nil {
+			if !endingActive {
+				return nil
+			}
+			if err := tx.Set(collection.Doc(ending.ID), ending); err != nil {
+				return err
+			}
+		}
+
+		assignment.ID = docRef.ID
+		started = true
+		return tx.Create(docRef, assignment)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to start assignment: %w", err)
+	}
+
+	return started, nil
+}
+
+// FindByID finds an assignment by ID
+func (r *AssignmentRepository) FindByID(id string) (*models.MentorAssignment, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.collectionName).Doc(id).Get(r.GetContext())
+	if err != nil {
+		return nil, err
+	}
+
+	var assignment models.MentorAssignment
+	if err := doc.DataTo(&assignment); err != nil {
+		return nil, err
+	}
+	assignment.ID = doc.Ref.ID
+
+	return &assignment, nil
+}
+
+// Find finds the assignments matching the query, newest first
+func (r *AssignmentRepository) Find(query models.AssignmentQuery) ([]models.MentorAssignment, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	q := r.GetCollection(r.collectionName).Query
+	if query.MentorID != "" {
+		q = q.Where("mentorId", "==", query.MentorID)
+	}
+	if query.StudentID != "" {
+		q = q.Where("studentId", "==", query.StudentID)
+	}
+	if query.ActiveOnly {
+		q = q.Where("active", "==", true)
+	}
+
+	iter := q.OrderBy("startedAt", firestore.Desc).Documents(r.GetContext())
+	defer iter.Stop()
+
+	var assignments []models.MentorAssignment
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var assignment models.MentorAssignment
+		if err := doc.DataTo(&assignment); err != nil {
+			continue
+		}
+		assignment.ID = doc.Ref.ID
+		assignments = append(assignments, assignment)
+	}
+
+	return assignments, nil
+}
+
+// Save replaces an existing assignment
+func (r *AssignmentRepository) Save(assignment *models.MentorAssignment) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	if _, err := r.GetCollection(r.collectionName).Doc(assignment.ID).Set(r.GetContext(), assignment); err != nil {
+		return fmt.Errorf("failed to save assignment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"mentorsphere-api/internal/models"
+)
+
+// BadgeAwardRepository handles badge award data access
+type BadgeAwardRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewBadgeAwardRepository creates a new badge award repository
+func NewBadgeAwardRepository() *BadgeAwardRepository {
+	return &BadgeAwardRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "badge_awards",
+	}
+}
+
+// FindByUserID finds badge awards for a user
+func (r *BadgeAwardRepository) FindByUserID(userID string) ([]models.BadgeAward, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).
+		Where("userId", "==", userID).
+		OrderBy("awardedAt", firestore.Asc).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var awards []models.BadgeAward
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var award models.BadgeAward
+		if err := doc.DataTo(&award); err != nil {
+			continue
+		}
+		award.ID = doc.Ref.ID
+		awards = append(awards, award)
+	}
+
+	return awards, nil
+}
+
+// Create stores an award keyed by user and badge. It fails if the badge
+// was already awarded, which keeps awarding idempotent.
+func (r *BadgeAwardRepository) Create(award *models.BadgeAward) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	award.ID = award.UserID + "_" + award.BadgeID
+
+	_, err := r.GetCollection(r.collectionName).Doc(award.ID).Create(r.GetContext(), award)
+	if err != nil {
+		return fmt.Errorf("failed to create badge award: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"fmt"
+
+	"mentorsphere-api/internal/models"
+)
+
+// BadgeRepository handles badge definition data access
+type BadgeRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewBadgeRepository creates a new badge repository
+func NewBadgeRepository() *BadgeRepository {
+	return &BadgeRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "badge_definitions",
+	}
+}
+
+// FindAll returns all badge definitions
+func (r *BadgeRepository) FindAll() ([]models.BadgeDefinition, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).Documents(r.GetContext())
+	defer iter.Stop()
+
+	var badges []models.BadgeDefinition
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var badge models.BadgeDefinition
+		if err := doc.DataTo(&badge); err != nil {
+			continue
+		}
+		badge.ID = doc.Ref.ID
+		badges = append(badges, badge)
+	}
+
+	return badges, nil
+}
+
+// Save creates or replaces a badge definition
+func (r *BadgeRepository) Save(badge *models.BadgeDefinition) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(badge.ID).Set(r.GetContext(), badge)
+	if err != nil {
+		return fmt.Errorf("failed to save badge definition: %w", err)
+	}
+
+	return nil
+}
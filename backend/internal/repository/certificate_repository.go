@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mentorsphere-api/internal/models"
+)
+
+// CertificateRepository handles certificate data access
+type CertificateRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewCertificateRepository creates a new certificate repository
+func NewCertificateRepository() *CertificateRepository {
+	return &CertificateRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "certificates",
+	}
+}
+
+// FindBySerial finds a certificate by its serial number
+func (r *CertificateRepository) FindBySerial(serial string) (*models.Certificate, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).
+		Where("serial", "==", serial).
+		Limit(1).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("certificate not found: %w", err)
+	}
+
+	var certificate models.Certificate
+	if err := doc.DataTo(&certificate); err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	certificate.ID = doc.Ref.ID
+
+	return &certificate, nil
+}
+
+// FindByUserID finds certificates issued to a user
+func (r *CertificateRepository) FindByUserID(userID string) ([]models.Certificate, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).
+		Where("userId", "==", userID).
+		OrderBy("issuedAt", firestore.Desc).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var certificates []models.Certificate
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var certificate models.Certificate
+		if err := doc.DataTo(&certificate); err != nil {
+			continue
+		}
+		certificate.ID = doc.Ref.ID
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+// Create stores a certificate keyed by user and course. It reports false,
+// storing nothing, when the user already has the course's certificate, so
+// each completed course is certified once.
+func (r *CertificateRepository) Create(certificate *models.Certificate) (bool, error) {
+	if !r.IsFirestoreAvailable() {
+		return false, fmt.Errorf("firestore not available")
+	}
+
+	certificate.ID = certificate.UserID + "_" + certificate.CourseID
+
+	_, err := r.GetCollection(r.collectionName).Doc(certificate.ID).Create(r.GetContext(), certificate)
+	if status.Code(err) == codes.AlreadyExists {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	return true, nil
+}
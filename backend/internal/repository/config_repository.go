@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"fmt"
+)
+
+// ConfigRepository handles application-wide configuration documents
+type ConfigRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewConfigRepository creates a new config repository
+func NewConfigRepository() *ConfigRepository {
+	return &ConfigRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "app_config",
+	}
+}
+
+// Find loads the configuration document with the given key into out
+func (r *ConfigRepository) Find(key string, out interface{}) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.collectionName).Doc(key).Get(r.GetContext())
+	if err != nil {
+		return fmt.Errorf("config not found: %w", err)
+	}
+
+	if err := doc.DataTo(out); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	return nil
+}
+
+// Save stores the configuration document with the given key
+func (r *ConfigRepository) Save(key string, data interface{}) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(key).Set(r.GetContext(), data)
+	if err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"fmt"
+
+	"mentorsphere-api/internal/models"
+)
+
+// DailyReflectionRepository handles per-date reflection data access
+type DailyReflectionRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewDailyReflectionRepository creates a new daily reflection repository
+func NewDailyReflectionRepository() *DailyReflectionRepository {
+	return &DailyReflectionRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "daily_reflections",
+	}
+}
+
+// FindByUserAndDate finds the reflection a user has for a date (YYYY-MM-DD)
+func (r *DailyReflectionRepository) FindByUserAndDate(userID, date string) (*models.DailyReflection, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.collectionName).Doc(userID + "_" + date).Get(r.GetContext())
+	if err != nil {
+		return nil, fmt.Errorf("daily reflection not found: %w", err)
+	}
+
+	var daily models.DailyReflection
+	if err := doc.DataTo(&daily); err != nil {
+		return nil, fmt.Errorf("failed to parse daily reflection: %w", err)
+	}
+
+	return &daily, nil
+}
+
+// Save saves a user's reflection for its date
+func (r *DailyReflectionRepository) Save(daily *models.DailyReflection) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(daily.UserID+"_"+daily.Date).Set(r.GetContext(), daily)
+	if err != nil {
+		return fmt.Errorf("failed to save daily reflection: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mentorsphere-api/internal/models"
+)
+
+// DeliveryRepository handles notification delivery records and push subscriptions
+type DeliveryRepository struct {
+	*BaseRepository
+	deliveryCollection     string
+	subscriptionCollection string
+}
+
+// NewDeliveryRepository creates a new delivery repository
+func NewDeliveryRepository() *DeliveryRepository {
+	return &DeliveryRepository{
+		BaseRepository:         NewBaseRepository(),
+		deliveryCollection:     "notification_deliveries",
+		subscriptionCollection: "push_subscriptions",
+	}
+}
+
+// CreateDelivery creates a new delivery record
+func (r *DeliveryRepository) CreateDelivery(delivery *models.NotificationDelivery) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	docRef := r.GetCollection(r.deliveryCollection).NewDoc()
+	delivery.ID = docRef.ID
+
+	if _, err := docRef.Set(r.GetContext(), delivery); err != nil {
+		return fmt.Errorf("failed to create delivery: %w", err)
+	}
+
+	return nil
+}
+
+// SaveDelivery overwrites a delivery record
+func (r *DeliveryRepository) SaveDelivery(delivery *models.NotificationDelivery) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	if _, err := r.GetCollection(r.deliveryCollection).Doc(delivery.ID).Set(r.GetContext(), delivery); err != nil {
+		return fmt.Errorf("failed to save delivery: %w", err)
+	}
+
+	return nil
+}
+
+// FindDeliveriesByNotification finds the delivery records of a notification
+func (r *DeliveryRepository) FindDeliveriesByNotification(notificationID string) ([]models.NotificationDelivery, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	return r.collectDeliveries(r.GetCollection(r.deliveryCollection).
+		Where("notificationId", "==", notificationID))
+}
+
+// FindDueDeliveries finds pending or retrying deliveries due at or before the given time
+func (r *DeliveryRepository) FindDueDeliveries(before time.Time) ([]models.NotificationDelivery, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	return r.collectDeliveries(r.GetCollection(r.deliveryCollection).
+		Where("status", "in", []string{"pending", "retrying"}).
+		Where("nextAttemptAt", "<=", before))
+}
+
+// FindDeliveriesByStatus finds the newest deliveries with a status, or of
+// any status when it is empty
+func (r *DeliveryRepository) FindDeliveriesByStatus(status string, limit int) ([]models.NotificationDelivery, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	query := r.GetCollection(r.deliveryCollection).Query
+	if status != "" {
+		query = query.Where("status", "==", status)
+	}
+	return r.collectDeliveries(query.OrderBy("updatedAt", firestore.Desc).Limit(limit))
+}
+
+func (r *DeliveryRepository) collectDeliveries(query firestore.Query) ([]models.NotificationDelivery, error) {
+	iter := query.Documents(r.GetContext())
+	defer iter.Stop()
+
+	var deliveries []models.NotificationDelivery
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var delivery models.NotificationDelivery
+		if err := doc.DataTo(&delivery); err != nil {
+			continue
+		}
+		delivery.ID = doc.Ref.ID
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// FindSubscriptionsByUserID finds a user's push subscriptions
+func (r *DeliveryRepository) FindSubscriptionsByUserID(userID string) ([]models.PushSubscription, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.subscriptionCollection).
+		Where("userId", "==", userID).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var subscriptions []models.PushSubscription
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var subscription models.PushSubscription
+		if err := doc.DataTo(&subscription); err != nil {
+			continue
+		}
+		subscription.ID = doc.Ref.ID
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+// SaveSubscription creates or replaces a push subscription under its ID inside
+// a transaction. It reports false, saving nothing, when the ID belongs to
+// another user's subscription.
+func (r *DeliveryRepository) SaveSubscription(subscription *models.PushSubscription) (bool, error) {
+	if !r.IsFirestoreAvailable() {
+		return false, fmt.Errorf("firestore not available")
+	}
+
+	ref := r.GetCollection(r.subscriptionCollection).Doc(subscription.ID)
+	saved := false
+	err := r.client.RunTransaction(r.GetContext(), func(ctx context.Context, tx *firestore.Transaction) error {
+		saved = false
+
+		doc, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var existing models.PushSubscription
+			if err := doc.DataTo(&existing); err != nil {
+				return err
+			}
+			if existing.UserID != subscription.UserID {
+				return nil
+			}
+		}
+
+		saved = true
+		return tx.Set(ref, subscription)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to save push subscription: %w", err)
+	}
+
+	return saved, nil
+}
+
+// DeleteSubscription deletes a push subscription
+func (r *DeliveryRepository) DeleteSubscription(id string) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	if _, err := r.GetCollection(r.subscriptionCollection).Doc(id).Delete(r.GetContext()); err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mentorsphere-api/internal/models"
+)
+
+// DigestRepository handles weekly digest send records
+type DigestRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewDigestRepository creates a new digest repository
+func NewDigestRepository() *DigestRepository {
+	return &DigestRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "digest_sends",
+	}
+}
+
+// Create stores a send record keyed by user and week. It reports false,
+// storing nothing, when the week's digest was already claimed, so each digest
+// is sent at most once.
+func (r *DigestRepository) Create(send *models.DigestSend) (bool, error) {
+	if !r.IsFirestoreAvailable() {
+		return false, fmt.Errorf("firestore not available")
+	}
+
+	send.ID = send.UserID + "_" + send.Week
+
+	_, err := r.GetCollection(r.collectionName).Doc(send.ID).Create(r.GetContext(), send)
+	if status.Code(err) == codes.AlreadyExists {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create digest send: %w", err)
+	}
+
+	return true, nil
+}
+
+// Delete removes a send record so a failed send can be retried
+func (r *DigestRepository) Delete(id string) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	if _, err := r.GetCollection(r.collectionName).Doc(id).Delete(r.GetContext()); err != nil {
+		return fmt.Errorf("failed to delete digest send: %w", err)
+	}
+
+	return nil
+}
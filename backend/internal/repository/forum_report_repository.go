@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"mentorsphere-api/internal/models"
+)
+
+// ForumReportRepository handles reports of forum posts
+type ForumReportRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewForumReportRepository creates a new forum report repository
+func NewForumReportRepository() *ForumReportRepository {
+	return &ForumReportRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "forum_reports",
+	}
+}
+
+// Create creates a new report
+func (r *ForumReportRepository) Create(report *models.ForumReport) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	docRef := r.GetCollection(r.collectionName).NewDoc()
+	report.ID = docRef.ID
+
+	if _, err := docRef.Set(r.GetContext(), report); err != nil {
+		return fmt.Errorf("failed to create forum report: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a report by ID
+func (r *ForumReportRepository) FindByID(id string) (*models.ForumReport, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.collectionName).Doc(id).Get(r.GetContext())
+	if err != nil {
+		return nil, err
+	}
+
+	var report models.ForumReport
+	if err := doc.DataTo(&report); err != nil {
+		return nil, err
+	}
+	report.ID = doc.Ref.ID
+
+	return &report, nil
+}
+
+// FindByCourse finds the reports of a course, newest first, optionally only
+// those with the given status
+func (r *ForumReportRepository) FindByCourse(courseID, status string) ([]models.ForumReport, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	q := r.GetCollection(r.collectionName).Where("courseId", "==", courseID)
+	if status != "" {
+		q = q.Where("status", "==", status)
+	}
+
+	iter := q.OrderBy("createdAt", firestore.Desc).Documents(r.GetContext())
+	defer iter.Stop()
+
+	var reports []models.ForumReport
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var report models.ForumReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		report.ID = doc.Ref.ID
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// Save replaces an existing report
+func (r *ForumReportRepository) Save(report *models.ForumReport) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	if _, err := r.GetCollection(r.collectionName).Doc(report.ID).Set(r.GetContext(), report); err != nil {
+		return fmt.Errorf("failed to save forum report: %w", err)
+	}
+
+	return nil
+}
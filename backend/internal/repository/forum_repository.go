@@ -0,0 +1,336 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"mentorsphere-api/internal/models"
+)
+
+// ForumRepository handles course forum threads and replies
+type ForumRepository struct {
+	*BaseRepository
+	threadCollection string
+	replyCollection  string
+}
+
+// NewForumRepository creates a new forum repository
+func NewForumRepository() *ForumRepository {
+	return &ForumRepository{
+		BaseRepository:   NewBaseRepository(),
+		threadCollection: "forum_threads",
+		replyCollection:  "forum_replies",
+	}
+}
+
+// CreateThread creates a new thread
+func (r *ForumRepository) CreateThread(thread *models.ForumThread) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	docRef := r.GetCollection(r.threadCollection).NewDoc()
+	thread.ID = docRef.ID
+
+	if _, err := docRef.Set(r.GetContext(), thread); err != nil {
+		return fmt.Errorf("failed to create forum thread: %w", err)
+	}
+
+	return nil
+}
+
+// FindThreadByID finds a thread by ID
+func (r *ForumRepository) FindThreadByID(id string) (*models.ForumThread, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.threadCollection).Doc(id).Get(r.GetContext())
+	if err != nil {
+		return nil, err
+	}
+
+	var thread models.ForumThread
+	if err := doc.DataTo(&thread); err != nil {
+		return nil, err
+	}
+	thread.ID = doc.Ref.ID
+
+	return &thread, nil
+}
+
+// FindThreadsByCourse finds all threads of a course
+func (r *ForumRepository) FindThreadsByCourse(courseID string) ([]models.ForumThread, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.threadCollection).
+		Where("courseId", "==", courseID).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var threads []models.ForumThread
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var thread models.ForumThread
+		if err := doc.DataTo(&thread); err != nil {
+			continue
+		}
+		thread.ID = doc.Ref.ID
+		threads = append(threads, thread)
+	}
+
+	return threads, nil
+}
+
+// UpdateThreadFields updates only the given fields of a thread
+func (r *ForumRepository) UpdateThreadFields(id string, fields map[string]interface{}) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	if _, err := r.GetCollection(r.threadCollection).Doc(id).Update(r.GetContext(), fieldUpdates(fields)); err != nil {
+		return fmt.Errorf("failed to update forum thread: %w", err)
+	}
+
+	return nil
+}
+
+// SetThreadUpvote adds or removes a user's upvote of a thread in a
+// transaction, so concurrent votes are all counted. It returns the upvoters.
+func (r *ForumRepository) SetThreadUpvote(id, userID string, upvote bool) ([]string, error) {
+	return r.setUpvote(r.threadCollection, id, userID, upvote)
+}
+
+// CreateReply creates a new reply and, in the same transaction, counts it on
+// its thread and moves the thread's last activity to the reply
+func (r *ForumRepository) CreateReply(reply *models.ForumReply) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	docRef := r.GetCollection(r.replyCollection).NewDoc()
+	threadRef := r.GetCollection(r.threadCollection).Doc(reply.ThreadID)
+	reply.ID = docRef.ID
+
+	err := r.client.RunTransaction(r.GetContext(), func(ctx context.Context, tx *firestore.Transaction) error {
+		if err := tx.Create(docRef, reply); err != nil {
+			return err
+		}
+		return tx.Update(threadRef, []firestore.Update{
+			{Path: "replyCount", Value: firestore.Increment(1)},
+			{Path: "lastActivityAt", Value: reply.CreatedAt},
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create forum reply: %w", err)
+	}
+
+	return nil
+}
+
+// FindReplyByID finds a reply by ID
+func (r *ForumRepository) FindReplyByID(id string) (*models.ForumReply, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.replyCollection).Doc(id).Get(r.GetContext())
+	if err != nil {
+		return nil, err
+	}
+
+	var reply models.ForumReply
+	if err := doc.DataTo(&reply); err != nil {
+		return nil, err
+	}
+	reply.ID = doc.Ref.ID
+
+	return &reply, nil
+}
+
+// FindReplies finds the replies of a thread, oldest first
+func (r *ForumRepository) FindReplies(threadID string) ([]models.ForumReply, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.replyCollection).
+		Where("threadId", "==", threadID).
+		OrderBy("createdAt", firestore.Asc).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var replies []models.ForumReply
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var reply models.ForumReply
+		if err := doc.DataTo(&reply); err != nil {
+			continue
+		}
+		reply.ID = doc.Ref.ID
+		replies = append(replies, reply)
+	}
+
+	return replies, nil
+}
+
+// UpdateReplyFields updates only the given fields of a reply
+func (r *ForumRepository) UpdateReplyFields(id string, fields map[string]interface{}) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	if _, err := r.GetCollection(r.replyCollection).Doc(id).Update(r.GetContext(), fieldUpdates(fields)); err != nil {
+		return fmt.Errorf("failed to update forum reply: %w", err)
+	}
+
+	return nil
+}
+
+// SetReplyUpvote adds or removes a user's upvote of a reply in a
+// transaction, so concurrent votes are all counted. It returns the upvoters.
+func (r *ForumRepository) SetReplyUpvote(id, userID string, upvote bool) ([]string, error) {
+	return r.setUpvote(r.replyCollection, id, userID, upvote)
+}
+
+// EndorseReply marks a reply as the answer to its thread, unmarking the
+// thread's earlier answer, or removes the mark, all in one transaction so
+// the thread never ends up with two answers
+func (r *ForumRepository) EndorseReply(threadID, replyID, endorsedBy string, endorse bool, at time.Time) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	threadRef := r.GetCollection(r.threadCollection).Doc(threadID)
+	replyRef := r.GetCollection(r.replyCollection).Doc(replyID)
+
+	err := r.client.RunTransaction(r.GetContext(), func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(threadRef)
+		if err != nil {
+			return err
+		}
+		current, _ := doc.Data()["endorsedReplyId"].(string)
+
+		if !endorse {
+			if current == replyID {
+				if err := tx.Update(threadRef, []firestore.Update{
+					{Path: "endorsedReplyId", Value: firestore.Delete},
+					{Path: "updatedAt", Value: at},
+				}); err != nil {
+					return err
+				}
+			}
+			return tx.Update(replyRef, []firestore.Update{
+				{Path: "endorsed", Value: false},
+				{Path: "endorsedBy", Value: firestore.Delete},
+				{Path: "updatedAt", Value: at},
+			})
+		}
+
+		if current != "" && current != replyID {
+			if err := tx.Update(r.GetCollection(r.replyCollection).Doc(current), []firestore.Update{
+				{Path: "endorsed", Value: false},
+				{Path: "endorsedBy", Value: firestore.Delete},
+			}); err != nil {
+				return err
+			}
+		}
+		if err := tx.Update(threadRef, []firestore.Update{
+			{Path: "endorsedReplyId", Value: replyID},
+			{Path: "updatedAt", Value: at},
+		}); err != nil {
+			return err
+		}
+		return tx.Update(replyRef, []firestore.Update{
+			{Path: "endorsed", Value: true},
+			{Path: "endorsedBy", Value: endorsedBy},
+			{Path: "updatedAt", Value: at},
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to endorse forum reply: %w", err)
+	}
+
+	return nil
+}
+
+// setUpvote toggles userID in a post's upvoters and updates its upvote count
+// in one transaction
+func (r *ForumRepository) setUpvote(collection, id, userID string, upvote bool) ([]string, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	docRef := r.GetCollection(collection).Doc(id)
+
+	var upvoters []string
+	err := r.client.RunTransaction(r.GetContext(), func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(docRef)
+		if err != nil {
+			return err
+		}
+		var post struct {
+			Upvoters []string `firestore:"upvoters"`
+		}
+		if err := doc.DataTo(&post); err != nil {
+			return err
+		}
+
+		upvoters = slices.DeleteFunc(append([]string{}, post.Upvoters...), func(id string) bool { return id == userID })
+		if upvote {
+			upvoters = append(upvoters, userID)
+		}
+		return tx.Update(docRef, []firestore.Update{
+			{Path: "upvoters", Value: upvoters},
+			{Path: "upvoteCount", Value: len(upvoters)},
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update forum upvote: %w", err)
+	}
+
+	return upvoters, nil
+}
+
+func fieldUpdates(fields map[string]interface{}) []firestore.Update {
+	var updates []firestore.Update
+	for key, value := range fields {
+		updates = append(updates, firestore.Update{Path: key, Value: value})
+	}
+	return updates
+}
+
+// CountPostsSince counts the threads and replies a user wrote since the given
+// time
+func (r *ForumRepository) CountPostsSince(authorID string, since time.Time) (int, error) {
+	if !r.IsFirestoreAvailable() {
+		return 0, fmt.Errorf("firestore not available")
+	}
+
+	count := 0
+	for _, collection := range []string{r.threadCollection, r.replyCollection} {
+		docs, err := r.GetCollection(collection).
+			Where("authorId", "==", authorID).
+			Where("createdAt", ">=", since).
+			Documents(r.GetContext()).GetAll()
+		if err != nil {
+			return 0, fmt.Errorf("failed to count forum posts: %w", err)
+		}
+		count += len(docs)
+	}
+
+	return count, nil
+}
@@ -118,22 +118,44 @@ func (r *InterventionRepository) Create(intervention *models.Intervention) error
 	return nil
 }
 
-// UpdateStatus updates the status of an intervention
-func (r *InterventionRepository) UpdateStatus(id string, status string, response *string) error {
+// FindByStatuses finds interventions in any of the given statuses
+func (r *InterventionRepository) FindByStatuses(statuses []string) ([]models.Intervention, error) {
 	if !r.IsFirestoreAvailable() {
-		return fmt.Errorf("firestore not available")
+		return nil, fmt.Errorf("firestore not available")
 	}
 
-	updates := []firestore.Update{
-		{Path: "status", Value: status},
+	iter := r.GetCollection(r.collectionName).
+		Where("status", "in", statuses).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var interventions []models.Intervention
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var intervention models.Intervention
+		if err := doc.DataTo(&intervention); err != nil {
+			continue
+		}
+		intervention.ID = doc.Ref.ID
+		interventions = append(interventions, intervention)
 	}
-	if response != nil {
-		updates = append(updates, firestore.Update{Path: "response", Value: *response})
+
+	return interventions, nil
+}
+
+// Save replaces an existing intervention
+func (r *InterventionRepository) Save(intervention *models.Intervention) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
 	}
 
-	_, err := r.GetCollection(r.collectionName).Doc(id).Update(r.GetContext(), updates)
+	_, err := r.GetCollection(r.collectionName).Doc(intervention.ID).Set(r.GetContext(), intervention)
 	if err != nil {
-		return fmt.Errorf("failed to update intervention status: %w", err)
+		return fmt.Errorf("failed to save intervention: %w", err)
 	}
 
 	return nil
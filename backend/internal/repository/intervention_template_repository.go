@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"fmt"
+
+	"mentorsphere-api/internal/models"
+)
+
+// InterventionTemplateRepository handles mentors' intervention message templates
+type InterventionTemplateRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewInterventionTemplateRepository creates a new intervention template repository
+func NewInterventionTemplateRepository() *InterventionTemplateRepository {
+	return &InterventionTemplateRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "intervention_templates",
+	}
+}
+
+// FindByID finds a template by ID
+func (r *InterventionTemplateRepository) FindByID(id string) (*models.InterventionTemplate, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.collectionName).Doc(id).Get(r.GetContext())
+	if err != nil {
+		return nil, fmt.Errorf("template not found: %w", err)
+	}
+
+	var template models.InterventionTemplate
+	if err := doc.DataTo(&template); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	template.ID = doc.Ref.ID
+
+	return &template, nil
+}
+
+// FindByMentorID finds the templates a mentor has created
+func (r *InterventionTemplateRepository) FindByMentorID(mentorID string) ([]models.InterventionTemplate, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).Where("mentorId", "==", mentorID).Documents(r.GetContext())
+	defer iter.Stop()
+
+	var templates []models.InterventionTemplate
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var template models.InterventionTemplate
+		if err := doc.DataTo(&template); err != nil {
+			continue
+		}
+		template.ID = doc.Ref.ID
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// Create creates a new template
+func (r *InterventionTemplateRepository) Create(template *models.InterventionTemplate) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	docRef, _, err := r.GetCollection(r.collectionName).Add(r.GetContext(), template)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+	template.ID = docRef.ID
+
+	return nil
+}
+
+// Save replaces an existing template
+func (r *InterventionTemplateRepository) Save(template *models.InterventionTemplate) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(template.ID).Set(r.GetContext(), template)
+	if err != nil {
+		return fmt.Errorf("failed to save template: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a template
+func (r *InterventionTemplateRepository) Delete(id string) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(id).Delete(r.GetContext())
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return nil
+}
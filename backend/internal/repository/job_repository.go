@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mentorsphere-api/internal/models"
+)
+
+// JobRepository stores scheduler leases and job run history
+type JobRepository struct {
+	*BaseRepository
+	leaseCollection string
+	runCollection   string
+}
+
+// NewJobRepository creates a new job repository
+func NewJobRepository() *JobRepository {
+	return &JobRepository{
+		BaseRepository:  NewBaseRepository(),
+		leaseCollection: "job_leases",
+		runCollection:   "job_runs",
+	}
+}
+
+// AcquireLease claims a job for holder inside a transaction, so only one
+// instance wins a slot even when several race for it
+func (r *JobRepository) AcquireLease(job, holder string, slot time.Time, ttl time.Duration) (bool, error) {
+	if !r.IsFirestoreAvailable() {
+		return false, fmt.Errorf("firestore not available")
+	}
+
+	ref := r.GetCollection(r.leaseCollection).Doc(job)
+	acquired := false
+	err := r.client.RunTransaction(r.GetContext(), func(ctx context.Context, tx *firestore.Transaction) error {
+		acquired = false
+		now := time.Now()
+
+		var lease models.JobLease
+		doc, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			if err := doc.DataTo(&lease); err != nil {
+				return err
+			}
+		}
+
+		if !slot.After(lease.LastSlot) {
+			return nil
+		}
+		if lease.Holder != "" && lease.Holder != holder && lease.ExpiresAt.After(now) {
+			return nil
+		}
+
+		acquired = true
+		return tx.Set(ref, models.JobLease{Job: job, Holder: holder, ExpiresAt: now.Add(ttl), LastSlot: slot})
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease: %w", err)
+	}
+
+	return acquired, nil
+}
+
+// ReleaseLease ends holder's lease early; the claimed slot is kept
+func (r *JobRepository) ReleaseLease(job, holder string) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	ref := r.GetCollection(r.leaseCollection).Doc(job)
+	return r.client.RunTransaction(r.GetContext(), func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var lease models.JobLease
+		if err := doc.DataTo(&lease); err != nil {
+			return err
+		}
+		if lease.Holder != holder {
+			return fmt.Errorf("lease not held")
+		}
+		return tx.Update(ref, []firestore.Update{{Path: "expiresAt", Value: time.Now()}})
+	})
+}
+
+// SaveRun creates or updates a job run
+func (r *JobRepository) SaveRun(run *models.JobRun) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	ref := r.GetCollection(r.runCollection).NewDoc()
+	if run.ID != "" {
+		ref = r.GetCollection(r.runCollection).Doc(run.ID)
+	}
+	run.ID = ref.ID
+
+	if _, err := ref.Set(r.GetContext(), run); err != nil {
+		return fmt.Errorf("failed to save job run: %w", err)
+	}
+
+	return nil
+}
+
+// FindRuns returns the latest runs of a job, newest first
+func (r *JobRepository) FindRuns(job string, limit int) ([]models.JobRun, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	query := r.GetCollection(r.runCollection).
+		Where("job", "==", job).
+		OrderBy("startedAt", firestore.Desc)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	iter := query.Documents(r.GetContext())
+	defer iter.Stop()
+
+	var runs []models.JobRun
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var run models.JobRun
+		if err := doc.DataTo(&run); err != nil {
+			continue
+		}
+		run.ID = doc.Ref.ID
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
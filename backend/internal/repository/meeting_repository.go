@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"mentorsphere-api/internal/models"
+)
+
+// MeetingRepository handles mentor availability slots, booked meetings and calendar feeds
+type MeetingRepository struct {
+	*BaseRepository
+	slotCollection    string
+	meetingCollection string
+	feedCollection    string
+}
+
+// NewMeetingRepository creates a new meeting repository
+func NewMeetingRepository() *MeetingRepository {
+	return &MeetingRepository{
+		BaseRepository:    NewBaseRepository(),
+		slotCollection:    "availability_slots",
+		meetingCollection: "meetings",
+		feedCollection:    "calendar_feeds",
+	}
+}
+
+// FindSlotByID finds an availability slot by ID
+func (r *MeetingRepository) FindSlotByID(id string) (*models.AvailabilitySlot, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.slotCollection).Doc(id).Get(r.GetContext())
+	if err != nil {
+		return nil, fmt.Errorf("slot not found: %w", err)
+	}
+
+	var slot models.AvailabilitySlot
+	if err := doc.DataTo(&slot); err != nil {
+		return nil, fmt.Errorf("failed to parse slot: %w", err)
+	}
+	slot.ID = doc.Ref.ID
+
+	return &slot, nil
+}
+
+// FindSlotsByMentorID finds a mentor's slots that end after the given time
+func (r *MeetingRepository) FindSlotsByMentorID(mentorID string, endsAfter time.Time) ([]models.AvailabilitySlot, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.slotCollection).
+		Where("mentorId", "==", mentorID).
+		Where("end", ">", endsAfter).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var slots []models.AvailabilitySlot
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var slot models.AvailabilitySlot
+		if err := doc.DataTo(&slot); err != nil {
+			continue
+		}
+		slot.ID = doc.Ref.ID
+		slots = append(slots, slot)
+	}
+
+	return slots, nil
+}
+
+// CreateSlot creates a new availability slot
+func (r *MeetingRepository) CreateSlot(slot *models.AvailabilitySlot) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	docRef, _, err := r.GetCollection(r.slotCollection).Add(r.GetContext(), slot)
+	if err != nil {
+		return fmt.Errorf("failed to create slot: %w", err)
+	}
+	slot.ID = docRef.ID
+
+	return nil
+}
+
+// SaveSlot replaces an existing availability slot
+func (r *MeetingRepository) SaveSlot(slot *models.AvailabilitySlot) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.slotCollection).Doc(slot.ID).Set(r.GetContext(), slot)
+	if err != nil {
+		return fmt.Errorf("failed to save slot: %w", err)
+	}
+
+	return nil
+}
+
+// BookSlot marks an open slot as booked for the meeting and stores the meeting
+// in one transaction, so two bookings cannot take the same slot. A meeting
+// without ID is created. releaseSlotID, when set, is reopened in the same
+// transaction (rescheduling). It reports false when the slot is no longer open.
+func (r *MeetingRepository) BookSlot(slotID, releaseSlotID string, meeting *models.Meeting) (bool, error) {
+	if !r.IsFirestoreAvailable() {
+		return false, fmt.Errorf("firestore not available")
+	}
+
+	slotRef := r.GetCollection(r.slotCollection).Doc(slotID)
+	meetingRef := r.GetCollection(r.meetingCollection).NewDoc()
+	if meeting.ID != "" {
+		meetingRef = r.GetCollection(r.meetingCollection).Doc(meeting.ID)
+	}
+
+	booked := false
+	err := r.client.RunTransaction(r.GetContext(), func(ctx context.Context, tx *firestore.Transaction) error {
+		booked = false
+
+		doc, err := tx.Get(slotRef)
+		if err != nil {
+			return err
+		}
+		var slot models.AvailabilitySlot
+		if err := doc.DataTo(&slot); err != nil {
+			return err
+		}
+		if slot.Status != "open" {
+			return nil
+		}
+
+		if releaseSlotID != "" {
+			if err := tx.Update(r.GetCollection(r.slotCollection).Doc(releaseSlotID), []firestore.Update{
+				{Path: "status", Value: "open"},
+				{Path: "meetingId", Value: firestore.Delete},
+			}); err != nil {
+				return err
+			}
+		}
+
+		meeting.ID = meetingRef.ID
+		booked = true
+		if err := tx.Update(slotRef, []firestore.Update{
+			{Path: "status", Value: "booked"},
+			{Path: "meetingId", Value: meeting.ID},
+		}); err != nil {
+			return err
+		}
+		return tx.Set(meetingRef, meeting)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to book slot: %w", err)
+	}
+
+	return booked, nil
+}
+
+// FindMeetingByID finds a meeting by ID
+func (r *MeetingRepository) FindMeetingByID(id string) (*models.Meeting, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.meetingCollection).Doc(id).Get(r.GetContext())
+	if err != nil {
+		return nil, fmt.Errorf("meeting not found: %w", err)
+	}
+
+	var meeting models.Meeting
+	if err := doc.DataTo(&meeting); err != nil {
+		return nil, fmt.Errorf("failed to parse meeting: %w", err)
+	}
+	meeting.ID = doc.Ref.ID
+
+	return &meeting, nil
+}
+
+// FindMeetings finds the meetings where the given field (mentorId or studentId) matches userID
+func (r *MeetingRepository) FindMeetings(field, userID string) ([]models.Meeting, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	return r.queryMeetings(r.GetCollection(r.meetingCollection).Where(field, "==", userID).Documents(r.GetContext()))
+}
+
+// FindMeetingsByStatus finds every meeting in a status
+func (r *MeetingRepository) FindMeetingsByStatus(status string) ([]models.Meeting, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	return r.queryMeetings(r.GetCollection(r.meetingCollection).Where("status", "==", status).Documents(r.GetContext()))
+}
+
+// CreateMeeting creates a new meeting
+func (r *MeetingRepository) CreateMeeting(meeting *models.Meeting) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	docRef, _, err := r.GetCollection(r.meetingCollection).Add(r.GetContext(), meeting)
+	if err != nil {
+		return fmt.Errorf("failed to create meeting: %w", err)
+	}
+	meeting.ID = docRef.ID
+
+	return nil
+}
+
+// SaveMeeting replaces an existing meeting
+func (r *MeetingRepository) SaveMeeting(meeting *models.Meeting) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.meetingCollection).Doc(meeting.ID).Set(r.GetContext(), meeting)
+	if err != nil {
+		return fmt.Errorf("failed to save meeting: %w", err)
+	}
+
+	return nil
+}
+
+// FindFeedByUserID finds a user's calendar feed
+func (r *MeetingRepository) FindFeedByUserID(userID string) (*models.CalendarFeed, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.feedCollection).Doc(userID).Get(r.GetContext())
+	if err != nil {
+		return nil, fmt.Errorf("calendar feed not found: %w", err)
+	}
+
+	var feed models.CalendarFeed
+	if err := doc.DataTo(&feed); err != nil {
+		return nil, fmt.Errorf("failed to parse calendar feed: %w", err)
+	}
+
+	return &feed, nil
+}
+
+// FindFeedByToken finds the calendar feed with the given token
+func (r *MeetingRepository) FindFeedByToken(token string) (*models.CalendarFeed, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.feedCollection).Where("token", "==", token).Limit(1).Documents(r.GetContext())
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("calendar feed not found: %w", err)
+	}
+
+	var feed models.CalendarFeed
+	if err := doc.DataTo(&feed); err != nil {
+		return nil, fmt.Errorf("failed to parse calendar feed: %w", err)
+	}
+
+	return &feed, nil
+}
+
+// SaveFeed stores a user's calendar feed
+func (r *MeetingRepository) SaveFeed(feed *models.CalendarFeed) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.feedCollection).Doc(feed.UserID).Set(r.GetContext(), feed)
+	if err != nil {
+		return fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MeetingRepository) queryMeetings(iter *firestore.DocumentIterator) ([]models.Meeting, error) {
+	defer iter.Stop()
+
+	var meetings []models.Meeting
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var meeting models.Meeting
+		if err := doc.DataTo(&meeting); err != nil {
+			continue
+		}
+		meeting.ID = doc.Ref.ID
+		meetings = append(meetings, meeting)
+	}
+
+	return meetings, nil
+}
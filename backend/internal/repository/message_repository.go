@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"mentorsphere-api/internal/models"
+)
+
+// MessageRepository handles mentor–student message data access
+type MessageRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewMessageRepository creates a new message repository
+func NewMessageRepository() *MessageRepository {
+	return &MessageRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "messages",
+	}
+}
+
+// Create creates a new message
+func (r *MessageRepository) Create(message *models.Message) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	docRef := r.GetCollection(r.collectionName).NewDoc()
+	message.ID = docRef.ID
+
+	if _, err := docRef.Set(r.GetContext(), message); err != nil {
+		return fmt.Errorf("failed to create message: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a message by ID
+func (r *MessageRepository) FindByID(id string) (*models.Message, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.collectionName).Doc(id).Get(r.GetContext())
+	if err != nil {
+		return nil, err
+	}
+
+	var message models.Message
+	if err := doc.DataTo(&message); err != nil {
+		return nil, err
+	}
+	message.ID = doc.Ref.ID
+
+	return &message, nil
+}
+
+// FindBefore finds up to limit messages of a conversation created before the
+// given time (all when zero), newest first
+func (r *MessageRepository) FindBefore(conversationID string, before time.Time, limit int) ([]models.Message, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	q := r.GetCollection(r.collectionName).
+		Where("conversationId", "==", conversationID)
+	if !before.IsZero() {
+		q = q.Where("createdAt", "<", before)
+	}
+
+	return r.collect(q.OrderBy("createdAt", firestore.Desc).Limit(limit))
+}
+
+// FindAfter finds up to limit messages of a conversation created after the
+// given time, oldest first
+func (r *MessageRepository) FindAfter(conversationID string, after time.Time, limit int) ([]models.Message, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	return r.collect(r.GetCollection(r.collectionName).
+		Where("conversationId", "==", conversationID).
+		Where("createdAt", ">", after).
+		OrderBy("createdAt", firestore.Asc).
+		Limit(limit))
+}
+
+// FindByParticipant finds a user's most recent messages across conversations
+func (r *MessageRepository) FindByParticipant(userID string, limit int) ([]models.Message, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	return r.collect(r.GetCollection(r.collectionName).
+		Where("participants", "array-contains", userID).
+		OrderBy("createdAt", firestore.Desc).
+		Limit(limit))
+}
+
+// FindUnread finds the unread messages of a conversation sent to a user
+func (r *MessageRepository) FindUnread(conversationID, recipientID string) ([]models.Message, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	return r.collect(r.GetCollection(r.collectionName).
+		Where("conversationId", "==", conversationID).
+		Where("recipientId", "==", recipientID).
+		Where("read", "==", false))
+}
+
+// MarkAsRead marks a message as read at the given time
+func (r *MessageRepository) MarkAsRead(id string, at time.Time) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(id).Update(r.GetContext(), []firestore.Update{
+		{Path: "read", Value: true},
+		{Path: "readAt", Value: at},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark message as read: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepository) collect(query firestore.Query) ([]models.Message, error) {
+	iter := query.Documents(r.GetContext())
+	defer iter.Stop()
+
+	var messages []models.Message
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var message models.Message
+		if err := doc.DataTo(&message); err != nil {
+			continue
+		}
+		message.ID = doc.Ref.ID
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
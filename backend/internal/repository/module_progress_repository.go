@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"fmt"
+
+	"mentorsphere-api/internal/models"
+)
+
+// ModuleProgressRepository handles students' per-module progress records
+type ModuleProgressRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewModuleProgressRepository creates a new module progress repository
+func NewModuleProgressRepository() *ModuleProgressRepository {
+	return &ModuleProgressRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "module_progress",
+	}
+}
+
+// FindByUserID finds a student's module progress across all courses
+func (r *ModuleProgressRepository) FindByUserID(userID string) ([]models.ModuleProgress, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).
+		Where("userId", "==", userID).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var records []models.ModuleProgress
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var record models.ModuleProgress
+		if err := doc.DataTo(&record); err != nil {
+			continue
+		}
+		record.ID = doc.Ref.ID
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// Save creates or replaces a progress record keyed by user, course and module
+func (r *ModuleProgressRepository) Save(record *models.ModuleProgress) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	record.ID = fmt.Sprintf("%s_%s_%d", record.UserID, record.CourseID, record.ModuleID)
+
+	if _, err := r.GetCollection(r.collectionName).Doc(record.ID).Set(r.GetContext(), record); err != nil {
+		return fmt.Errorf("failed to save module progress: %w", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"mentorsphere-api/internal/models"
@@ -99,6 +101,101 @@ func (r *NotificationRepository) Create(notification *models.Notification) error
 	return nil
 }
 
+// FindByID finds a notification by ID
+func (r *NotificationRepository) FindByID(id string) (*models.Notification, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.collectionName).Doc(id).Get(r.GetContext())
+	if err != nil {
+		return nil, err
+	}
+
+	var notification models.Notification
+	if err := doc.DataTo(&notification); err != nil {
+		return nil, err
+	}
+	notification.ID = doc.Ref.ID
+
+	return &notification, nil
+}
+
+// FindPage finds up to query.Limit+1 notifications after the cursor, newest
+// first, so the caller can tell whether another page follows
+func (r *NotificationRepository) FindPage(query models.NotificationQuery) ([]models.Notification, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	q := r.GetCollection(r.collectionName).
+		Where("userId", "==", query.UserID).
+		Where("archived", "==", query.Archived)
+	if len(query.Types) > 0 {
+		q = q.Where("type", "in", query.Types)
+	}
+	if query.UnreadOnly {
+		q = q.Where("read", "==", false)
+	}
+	q = q.OrderBy("createdAt", firestore.Desc)
+
+	if query.Cursor != "" {
+		cursor, err := r.GetCollection(r.collectionName).Doc(query.Cursor).Get(r.GetContext())
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		q = q.StartAfter(cursor)
+	}
+
+	iter := q.Limit(query.Limit + 1).Documents(r.GetContext())
+	defer iter.Stop()
+
+	var notifications []models.Notification
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var notification models.Notification
+		if err := doc.DataTo(&notification); err != nil {
+			continue
+		}
+		notification.ID = doc.Ref.ID
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+// CountUnread counts unread, unarchived notifications per type
+func (r *NotificationRepository) CountUnread(userID string) (map[string]int, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).
+		Where("userId", "==", userID).
+		Where("read", "==", false).
+		Where("archived", "==", false).
+		Select("type").
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	counts := make(map[string]int)
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		notificationType, _ := doc.Data()["type"].(string)
+		counts[notificationType]++
+	}
+
+	return counts, nil
+}
+
 // MarkAsRead marks a notification as read
 func (r *NotificationRepository) MarkAsRead(id string) error {
 	if !r.IsFirestoreAvailable() {
@@ -107,6 +204,7 @@ func (r *NotificationRepository) MarkAsRead(id string) error {
 
 	_, err := r.GetCollection(r.collectionName).Doc(id).Update(r.GetContext(), []firestore.Update{
 		{Path: "read", Value: true},
+		{Path: "readAt", Value: time.Now()},
 	})
 	if err != nil {
 		return fmt.Errorf("failed to mark notification as read: %w", err)
@@ -115,23 +213,99 @@ func (r *NotificationRepository) MarkAsRead(id string) error {
 	return nil
 }
 
-// MarkAllAsRead marks all notifications as read for a user
-func (r *NotificationRepository) MarkAllAsRead(userID string) error {
+// MarkAllAsRead marks all notifications as read for a user, or only those of
+// the given types
+func (r *NotificationRepository) MarkAllAsRead(userID string, types ...string) (int, error) {
 	if !r.IsFirestoreAvailable() {
-		return fmt.Errorf("firestore not available")
+		return 0, fmt.Errorf("firestore not available")
 	}
 
 	// Get all unread notifications
 	unread, err := r.FindUnread(userID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	// Mark each as read
+	// Mark matching ones as read
+	count := 0
 	for _, notification := range unread {
+		if len(types) > 0 && !slices.Contains(types, notification.Type) {
+			continue
+		}
 		if err := r.MarkAsRead(notification.ID); err != nil {
-			return err
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// SetArchived archives or restores a notification
+func (r *NotificationRepository) SetArchived(id string, archived bool) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	var archivedAt interface{}
+	if archived {
+		archivedAt = time.Now()
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(id).Update(r.GetContext(), []firestore.Update{
+		{Path: "archived", Value: archived},
+		{Path: "archivedAt", Value: archivedAt},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive notification: %w", err)
+	}
+
+	return nil
+}
+
+// BackfillArchived sets archived to false on notifications stored before
+// archiving existed, which the archived filter of FindPage and CountUnread
+// would otherwise never match
+func (r *NotificationRepository) BackfillArchived() (int, error) {
+	if !r.IsFirestoreAvailable() {
+		return 0, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).
+		Select("archived").
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
 		}
+		if _, ok := doc.Data()["archived"]; ok {
+			continue
+		}
+
+		if _, err := doc.Ref.Update(r.GetContext(), []firestore.Update{
+			{Path: "archived", Value: false},
+		}); err != nil {
+			return count, fmt.Errorf("failed to backfill notification: %w", err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Delete deletes a notification
+func (r *NotificationRepository) Delete(id string) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(id).Delete(r.GetContext())
+	if err != nil {
+		return fmt.Errorf("failed to delete notification: %w", err)
 	}
 
 	return nil
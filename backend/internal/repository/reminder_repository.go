@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mentorsphere-api/internal/models"
+)
+
+// ReminderRepository handles study reminder records
+type ReminderRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewReminderRepository creates a new reminder repository
+func NewReminderRepository() *ReminderRepository {
+	return &ReminderRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "study_reminders",
+	}
+}
+
+// Create stores a reminder keyed by user and date. It reports false, storing
+// nothing, when the day's reminder already exists, so each day's reminder is
+// sent at most once.
+func (r *ReminderRepository) Create(reminder *models.StudyReminder) (bool, error) {
+	if !r.IsFirestoreAvailable() {
+		return false, fmt.Errorf("firestore not available")
+	}
+
+	reminder.ID = reminder.UserID + "_" + reminder.Date
+
+	_, err := r.GetCollection(r.collectionName).Doc(reminder.ID).Create(r.GetContext(), reminder)
+	if status.Code(err) == codes.AlreadyExists {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create study reminder: %w", err)
+	}
+
+	return true, nil
+}
+
+// Delete removes a reminder record so a failed reminder can be sent again
+func (r *ReminderRepository) Delete(id string) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	if _, err := r.GetCollection(r.collectionName).Doc(id).Delete(r.GetContext()); err != nil {
+		return fmt.Errorf("failed to delete study reminder: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"mentorsphere-api/internal/models"
+)
+
+// RiskSnapshotRepository handles daily risk score snapshots
+type RiskSnapshotRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewRiskSnapshotRepository creates a new risk snapshot repository
+func NewRiskSnapshotRepository() *RiskSnapshotRepository {
+	return &RiskSnapshotRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "risk_snapshots",
+	}
+}
+
+// FindByUserID finds a user's snapshots from the given date (YYYY-MM-DD) onwards, oldest first
+func (r *RiskSnapshotRepository) FindByUserID(userID, fromDate string) ([]models.RiskSnapshot, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).
+		Where("userId", "==", userID).
+		Where("date", ">=", fromDate).
+		OrderBy("date", firestore.Asc).
+		Documents(r.GetContext())
+	defer iter.Stop()
+
+	var snapshots []models.RiskSnapshot
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var snapshot models.RiskSnapshot
+		if err := doc.DataTo(&snapshot); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// Save stores the snapshot for its user and date, replacing an earlier one of the same day
+func (r *RiskSnapshotRepository) Save(snapshot *models.RiskSnapshot) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(snapshot.UserID+"_"+snapshot.Date).Set(r.GetContext(), snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to save risk snapshot: %w", err)
+	}
+
+	return nil
+}
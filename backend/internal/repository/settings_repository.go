@@ -90,13 +90,15 @@ func (r *SettingsRepository) CreateDefault(userID string) (*models.UserSettings,
 	settings := &models.UserSettings{
 		UserID: userID,
 		Notifications: models.NotificationSettings{
-			Email:          true,
-			Push:           true,
-			StudyReminder:  true,
-			WeeklyReport:   true,
-			MentorMessages: true,
-			CourseUpdates:  false,
-			Promotions:     false,
+			Email:           true,
+			Push:            true,
+			StudyReminder:   true,
+			WeeklyReport:    true,
+			MentorMessages:  true,
+			CourseUpdates:   false,
+			Promotions:      false,
+			QuietHoursStart: "22:00",
+			QuietHoursEnd:   "07:00",
 		},
 		Appearance: models.AppearanceSettings{
 			Theme:    "system",
@@ -114,6 +116,7 @@ func (r *SettingsRepository) CreateDefault(userID string) (*models.UserSettings,
 			ReminderTime:   "08:00",
 			AutoplayVideos: true,
 			Subtitles:      true,
+			Timezone:       "Asia/Jakarta",
 		},
 	}
 
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"fmt"
+
+	"mentorsphere-api/internal/models"
+)
+
+// StreakRepository handles streak state data access
+type StreakRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewStreakRepository creates a new streak repository
+func NewStreakRepository() *StreakRepository {
+	return &StreakRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "streaks",
+	}
+}
+
+// FindByUserID finds the streak state for a user
+func (r *StreakRepository) FindByUserID(userID string) (*models.StreakState, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.collectionName).Doc(userID).Get(r.GetContext())
+	if err != nil {
+		return nil, fmt.Errorf("streak state not found: %w", err)
+	}
+
+	var state models.StreakState
+	if err := doc.DataTo(&state); err != nil {
+		return nil, fmt.Errorf("failed to parse streak state: %w", err)
+	}
+	state.UserID = userID
+
+	return &state, nil
+}
+
+// Save saves the streak state for a user
+func (r *StreakRepository) Save(state *models.StreakState) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(state.UserID).Set(r.GetContext(), state)
+	if err != nil {
+		return fmt.Errorf("failed to save streak state: %w", err)
+	}
+
+	return nil
+}
@@ -155,25 +155,88 @@ func (r *UserRepository) GetAllStudents() ([]models.User, error) {
 	return students, nil
 }
 
-// GetStudentsByMentor returns students assigned to a mentor
+// FindByRole returns all users with the given role
+func (r *UserRepository) FindByRole(role string) ([]models.User, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	iter := r.GetCollection(r.collectionName).Where("role", "==", role).Documents(r.GetContext())
+	defer iter.Stop()
+
+	var users []models.User
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var user models.User
+		if err := doc.DataTo(&user); err != nil {
+			continue
+		}
+		user.ID = doc.Ref.ID
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// GetStudentsByMentor returns the students with an active assignment to a
+// mentor
 func (r *UserRepository) GetStudentsByMentor(mentorID string) ([]models.User, error) {
+	return r.findAssigned("mentorId", mentorID, "studentId")
+}
+
+// GetMentorsOfStudent returns the mentors with an active assignment to a
+// student
+func (r *UserRepository) GetMentorsOfStudent(studentID string) ([]models.User, error) {
+	return r.findAssigned("studentId", studentID, "mentorId")
+}
+
+// findAssigned looks up the active assignments where field equals id and
+// loads the users referenced by their other field in one batch
+func (r *UserRepository) findAssigned(field, id, otherField string) ([]models.User, error) {
 	if !r.IsFirestoreAvailable() {
 		return nil, fmt.Errorf("firestore not available")
 	}
 
-	// First get the mentor to find assigned students
-	mentor, err := r.FindByID(mentorID)
+	docs, err := r.GetCollection(assignmentCollection).
+		Where(field, "==", id).
+		Where("active", "==", true).
+		Documents(r.GetContext()).GetAll()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to find assignments: %w", err)
 	}
 
-	var students []models.User
-	for _, studentID := range mentor.AssignedStudents {
-		student, err := r.FindByID(studentID)
-		if err == nil {
-			students = append(students, *student)
+	var refs []*firestore.DocumentRef
+	for _, doc := range docs {
+		if userID, ok := doc.Data()[otherField].(string); ok {
+			refs = append(refs, r.GetCollection(r.collectionName).Doc(userID))
 		}
 	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
 
-	return students, nil
+	snapshots, err := r.client.GetAll(r.GetContext(), refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load assigned users: %w", err)
+	}
+
+	var users []models.User
+	for _, doc := range snapshots {
+		if !doc.Exists() {
+			continue
+		}
+
+		var user models.User
+		if err := doc.DataTo(&user); err != nil {
+			continue
+		}
+		user.ID = doc.Ref.ID
+		users = append(users, user)
+	}
+
+	return users, nil
 }
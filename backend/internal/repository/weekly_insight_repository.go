@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"mentorsphere-api/internal/models"
+)
+
+// WeeklyInsightRepository handles per-week insight data access
+type WeeklyInsightRepository struct {
+	*BaseRepository
+	collectionName string
+}
+
+// NewWeeklyInsightRepository creates a new weekly insight repository
+func NewWeeklyInsightRepository() *WeeklyInsightRepository {
+	return &WeeklyInsightRepository{
+		BaseRepository: NewBaseRepository(),
+		collectionName: "weekly_insights",
+	}
+}
+
+func weeklyInsightDocID(userID string, year, week int) string {
+	return fmt.Sprintf("%s_%d-W%02d", userID, year, week)
+}
+
+// FindByUserAndWeek finds a user's insight for an ISO week
+func (r *WeeklyInsightRepository) FindByUserAndWeek(userID string, year, week int) (*models.WeeklyInsight, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	doc, err := r.GetCollection(r.collectionName).Doc(weeklyInsightDocID(userID, year, week)).Get(r.GetContext())
+	if err != nil {
+		return nil, fmt.Errorf("weekly insight not found: %w", err)
+	}
+
+	var insight models.WeeklyInsight
+	if err := doc.DataTo(&insight); err != nil {
+		return nil, fmt.Errorf("failed to parse weekly insight: %w", err)
+	}
+
+	return &insight, nil
+}
+
+// FindByUserID finds a user's stored insights, newest week first
+func (r *WeeklyInsightRepository) FindByUserID(userID string, limit int) ([]models.WeeklyInsight, error) {
+	if !r.IsFirestoreAvailable() {
+		return nil, fmt.Errorf("firestore not available")
+	}
+
+	query := r.GetCollection(r.collectionName).
+		Where("userId", "==", userID).
+		OrderBy("weekStart", firestore.Desc)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	iter := query.Documents(r.GetContext())
+	defer iter.Stop()
+
+	var insights []models.WeeklyInsight
+	for {
+		doc, err := iter.Next()
+		if err != nil {
+			break
+		}
+
+		var insight models.WeeklyInsight
+		if err := doc.DataTo(&insight); err != nil {
+			continue
+		}
+		insights = append(insights, insight)
+	}
+
+	return insights, nil
+}
+
+// Save saves a user's insight for its week
+func (r *WeeklyInsightRepository) Save(insight *models.WeeklyInsight) error {
+	if !r.IsFirestoreAvailable() {
+		return fmt.Errorf("firestore not available")
+	}
+
+	_, err := r.GetCollection(r.collectionName).Doc(weeklyInsightDocID(insight.UserID, insight.Year, insight.WeekNumber)).Set(r.GetContext(), insight)
+	if err != nil {
+		return fmt.Errorf("failed to save weekly insight: %w", err)
+	}
+
+	return nil
+}
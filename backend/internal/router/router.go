@@ -18,6 +18,20 @@ func SetupRoutes(app *fiber.App, cfg *config.Config) {
 	studentHandler := handlers.NewStudentHandler()
 	mentorHandler := handlers.NewMentorHandler()
 	reflectionHandler := handlers.NewReflectionHandler()
+	streakHandler := handlers.NewStreakHandler()
+	achievementHandler := handlers.NewAchievementHandler()
+	riskHandler := handlers.NewRiskHandler()
+	jobHandler := handlers.NewJobHandler()
+	alertHandler := handlers.NewAlertHandler()
+	interventionHandler := handlers.NewInterventionHandler()
+	meetingHandler := handlers.NewMeetingHandler(cfg)
+	notificationHandler := handlers.NewNotificationHandler()
+	digestHandler := handlers.NewDigestHandler()
+	messageHandler := handlers.NewMessageHandler()
+	forumHandler := handlers.NewForumHandler()
+	assignmentHandler := handlers.NewAssignmentHandler()
+	eventHandler := handlers.NewEventHandler(cfg)
+	certificateHandler := handlers.NewCertificateHandler(cfg)
 
 	// Auth routes (public)
 	auth := api.Group("/auth")
@@ -35,6 +49,24 @@ func SetupRoutes(app *fiber.App, cfg *config.Config) {
 	user.Put("/settings/:section", userHandler.UpdateSettings)
 	user.Put("/password", userHandler.ChangePassword)
 	user.Delete("/", userHandler.DeleteAccount)
+	user.Get("/streak", streakHandler.GetStreak)
+	user.Get("/streak/history", streakHandler.GetHistory)
+	user.Post("/streak/freeze", streakHandler.UseFreeze)
+	user.Get("/badges", achievementHandler.GetBadges)
+	user.Post("/badges/evaluate", achievementHandler.EvaluateBadges)
+	user.Get("/certificates", certificateHandler.GetCertificates)
+	user.Post("/certificates", certificateHandler.IssueCertificates)
+	user.Get("/certificates/:serial/download", certificateHandler.Download)
+	user.Get("/calendar", meetingHandler.GetCalendarFeed)
+	user.Post("/calendar/reset", meetingHandler.ResetCalendarFeed)
+
+	// Certificate verification routes (public)
+	certificates := api.Group("/certificates")
+	certificates.Get("/public-key", certificateHandler.GetPublicKey)
+	certificates.Get("/verify/:serial", certificateHandler.Verify)
+
+	// Calendar feed routes (public, authenticated by the secret token in the URL)
+	api.Get("/calendar/:token", meetingHandler.ServeCalendar)
 
 	// Course routes (protected)
 	courses := api.Group("/courses", middleware.AuthMiddleware(cfg))
@@ -49,17 +81,98 @@ func SetupRoutes(app *fiber.App, cfg *config.Config) {
 	student.Get("/dashboard", studentHandler.GetDashboard)
 	student.Get("/courses", studentHandler.GetCourses)
 	student.Get("/activity", studentHandler.GetActivity)
+	student.Get("/interventions", interventionHandler.GetMine)
+	student.Post("/interventions/:id/acknowledge", interventionHandler.Acknowledge)
+	student.Post("/interventions/:id/respond", interventionHandler.Respond)
 
 	// Mentor routes (protected, mentor role)
 	mentor := api.Group("/mentor", middleware.AuthMiddleware(cfg))
 	mentor.Get("/dashboard", mentorHandler.GetDashboard)
 	mentor.Get("/students", mentorHandler.GetStudents)
 	mentor.Get("/students/:id", mentorHandler.GetStudentDetail)
+	mentor.Get("/students/:id/risk-history", mentorHandler.GetStudentRiskHistory)
 	mentor.Post("/interventions", mentorHandler.CreateIntervention)
 	mentor.Get("/interventions", mentorHandler.GetInterventions)
+	mentor.Get("/interventions/effectiveness", interventionHandler.GetEffectiveness)
+	mentor.Post("/interventions/bulk/preview", interventionHandler.PreviewBulk)
+	mentor.Post("/interventions/bulk", interventionHandler.CreateBulk)
+	mentor.Get("/interventions/:id", mentorHandler.GetIntervention)
 	mentor.Put("/interventions/:id", mentorHandler.UpdateInterventionStatus)
-	mentor.Get("/notifications", mentorHandler.GetNotifications)
-	mentor.Put("/notifications/:id/read", mentorHandler.MarkNotificationRead)
+	mentor.Get("/notifications", notificationHandler.GetNotifications)
+	mentor.Put("/notifications/:id/read", notificationHandler.MarkRead)
+	mentor.Get("/intervention-templates", interventionHandler.GetTemplates)
+	mentor.Post("/intervention-templates", interventionHandler.CreateTemplate)
+	mentor.Put("/intervention-templates/:id", interventionHandler.UpdateTemplate)
+	mentor.Delete("/intervention-templates/:id", interventionHandler.DeleteTemplate)
+	mentor.Get("/availability", meetingHandler.GetSlots)
+	mentor.Post("/availability", meetingHandler.CreateSlot)
+	mentor.Delete("/availability/:id", meetingHandler.DeleteSlot)
+	mentor.Get("/alert-rules", alertHandler.GetRules)
+	mentor.Put("/alert-rules/:type", alertHandler.UpdateRule)
+	mentor.Post("/alerts/evaluate", alertHandler.Evaluate)
+
+	// Notification routes (protected)
+	notifications := api.Group("/notifications", middleware.AuthMiddleware(cfg))
+	notifications.Get("/", notificationHandler.GetNotifications)
+	notifications.Get("/unread-count", notificationHandler.GetUnreadCount)
+	notifications.Put("/read-all", notificationHandler.MarkAllRead)
+	notifications.Get("/push/public-key", notificationHandler.GetPushPublicKey)
+	notifications.Post("/push/subscriptions", notificationHandler.SubscribePush)
+	notifications.Delete("/push/subscriptions", notificationHandler.UnsubscribePush)
+	notifications.Get("/:id/deliveries", notificationHandler.GetDeliveries)
+	notifications.Put("/:id/read", notificationHandler.MarkRead)
+	notifications.Put("/:id/archive", notificationHandler.Archive)
+	notifications.Put("/:id/unarchive", notificationHandler.Unarchive)
+	notifications.Delete("/:id", notificationHandler.Delete)
+
+	// Weekly digest routes (protected)
+	digests := api.Group("/digests", middleware.AuthMiddleware(cfg))
+	digests.Get("/weekly/preview", digestHandler.Preview)
+
+	// Direct message routes (protected)
+	messages := api.Group("/messages", middleware.AuthMiddleware(cfg))
+	messages.Get("/conversations", messageHandler.GetConversations)
+	messages.Get("/conversations/:id/messages", messageHandler.GetMessages)
+	messages.Post("/conversations/:id/messages", messageHandler.Send)
+	messages.Put("/conversations/:id/read", messageHandler.MarkRead)
+	messages.Get("/search", messageHandler.Search)
+
+	// Course forum routes (protected)
+	forums := api.Group("/forums", middleware.AuthMiddleware(cfg))
+	forums.Get("/courses/:courseId/threads", forumHandler.GetThreads)
+	forums.Post("/courses/:courseId/threads", forumHandler.CreateThread)
+	forums.Get("/courses/:courseId/reports", forumHandler.GetReports)
+	forums.Get("/threads/:id", forumHandler.GetThread)
+	forums.Post("/threads/:id/replies", forumHandler.Reply)
+	forums.Post("/threads/:id/upvote", forumHandler.UpvoteThread)
+	forums.Delete("/threads/:id/upvote", forumHandler.RemoveThreadUpvote)
+	forums.Post("/threads/:id/report", forumHandler.ReportThread)
+	forums.Put("/threads/:id/moderation", forumHandler.ModerateThread)
+	forums.Post("/replies/:id/upvote", forumHandler.UpvoteReply)
+	forums.Delete("/replies/:id/upvote", forumHandler.RemoveReplyUpvote)
+	forums.Post("/replies/:id/endorse", forumHandler.EndorseReply)
+	forums.Delete("/replies/:id/endorse", forumHandler.RemoveEndorsement)
+	forums.Post("/replies/:id/report", forumHandler.ReportReply)
+	forums.Put("/replies/:id/moderation", forumHandler.ModerateReply)
+	forums.Put("/reports/:id", forumHandler.ResolveReport)
+
+	// Mentor assignment routes (protected, admin or mentor)
+	assignments := api.Group("/assignments", middleware.AuthMiddleware(cfg))
+	assignments.Get("/", assignmentHandler.GetAssignments)
+	assignments.Post("/", assignmentHandler.Assign)
+	assignments.Post("/reassign", assignmentHandler.Reassign)
+	assignments.Delete("/:id", assignmentHandler.Unassign)
+
+	// Real-time event stream (protected, token may be passed as ?token=)
+	api.Get("/events", middleware.QueryTokenMiddleware(), middleware.AuthMiddleware(cfg), eventHandler.Stream)
+
+	// Meeting routes (protected)
+	meetings := api.Group("/meetings", middleware.AuthMiddleware(cfg))
+	meetings.Get("/", meetingHandler.GetMeetings)
+	meetings.Post("/", meetingHandler.Book)
+	meetings.Get("/availability", meetingHandler.GetOpenSlots)
+	meetings.Put("/:id/reschedule", meetingHandler.Reschedule)
+	meetings.Post("/:id/cancel", meetingHandler.Cancel)
 
 	// Reflection routes (protected)
 	reflections := api.Group("/reflections", middleware.AuthMiddleware(cfg))
@@ -67,6 +180,27 @@ func SetupRoutes(app *fiber.App, cfg *config.Config) {
 	reflections.Post("/generate", reflectionHandler.GenerateReflection)
 	reflections.Get("/daily", reflectionHandler.GetDailyReflection)
 	reflections.Get("/weekly", reflectionHandler.GetWeeklyInsight)
+	reflections.Get("/weekly/history", reflectionHandler.GetWeeklyInsightHistory)
 	reflections.Get("/learning-path", reflectionHandler.GetLearningPath)
 	reflections.Get("/risk-assessment", reflectionHandler.GetRiskAssessment)
+
+	// Admin routes (protected, admin role)
+	admin := api.Group("/admin", middleware.AuthMiddleware(cfg), middleware.RoleMiddleware("admin"))
+	admin.Get("/badges", achievementHandler.GetDefinitions)
+	admin.Post("/badges", achievementHandler.SaveDefinition)
+	admin.Put("/badges/:id", achievementHandler.SaveDefinition)
+	admin.Delete("/badges/:id", achievementHandler.DeactivateDefinition)
+	admin.Get("/risk-config", riskHandler.GetConfig)
+	admin.Put("/risk-config", riskHandler.UpdateConfig)
+	admin.Get("/interventions/effectiveness", interventionHandler.GetAllEffectiveness)
+	admin.Get("/notification-deliveries", notificationHandler.GetAllDeliveries)
+	admin.Get("/assignment-config", assignmentHandler.GetConfig)
+	admin.Put("/assignment-config", assignmentHandler.UpdateConfig)
+	admin.Get("/assignments/load", assignmentHandler.GetLoads)
+	admin.Post("/assignments/balance", assignmentHandler.Balance)
+	admin.Post("/assignments/backfill", assignmentHandler.Backfill)
+	admin.Put("/mentors/:id/assignment-profile", assignmentHandler.UpdateMentorProfile)
+	admin.Get("/jobs", jobHandler.GetJobs)
+	admin.Get("/jobs/:name/runs", jobHandler.GetRuns)
+	admin.Post("/jobs/:name/run", jobHandler.TriggerJob)
 }
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronRejectsInvalidExpressions(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"empty", ""},
+		{"too few fields", "0 0 * *"},
+		{"too many fields", "0 0 * * * *"},
+		{"unknown descriptor", "@yearly"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"day of month zero", "0 0 0 * *"},
+		{"month out of range", "0 0 1 13 *"},
+		{"day of week out of range", "0 0 * * 8"},
+		{"reversed range", "0 5-1 * * *"},
+		{"zero step", "*/0 * * * *"},
+		{"not a number", "a * * * *"},
+		{"broken range", "0 1- * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr); err == nil {
+				t.Errorf("ParseCron(%q) succeeded, want an error", tt.expr)
+			}
+		})
+	}
+}
+
+func TestScheduleMatches(t *testing.T) {
+	// 2026-10-19 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		t    time.Time
+		want bool
+	}{
+		{"every minute", "* * * * *", at(19, 13, 7), true},
+		{"fixed time", "30 2 * * *", at(19, 2, 30), true},
+		{"fixed time, other minute", "30 2 * * *", at(19, 2, 31), false},
+		{"step hit", "*/15 * * * *", at(19, 9, 45), true},
+		{"step miss", "*/15 * * * *", at(19, 9, 50), false},
+		{"start with step", "5/20 * * * *", at(19, 9, 25), true},
+		{"start with step, before start", "5/20 * * * *", at(19, 9, 0), false},
+		{"list", "0 8,12,18 * * *", at(19, 12, 0), true},
+		{"weekday range on Monday", "0 9 * * 1-5", at(19, 9, 0), true},
+		{"weekday range on Sunday", "0 9 * * 1-5", at(18, 9, 0), false},
+		{"7 is Sunday", "0 9 * * 7", at(18, 9, 0), true},
+		{"month mismatch", "0 0 1 1 *", at(1, 0, 0), false},
+		{"both day fields, only day of month matches", "0 0 1 * 3", at(1, 0, 0), true},
+		{"both day fields, only day of week matches", "0 0 1 * 1", at(19, 0, 0), true},
+		{"both day fields, neither matches", "0 0 1 * 3", at(19, 0, 0), false},
+		{"day of month with star day of week", "0 0 19 * *", at(19, 0, 0), true},
+		{"@hourly", "@hourly", at(19, 17, 0), true},
+		{"@daily off midnight", "@daily", at(19, 1, 0), false},
+		{"@weekly on Sunday", "@weekly", at(18, 0, 0), true},
+		{"@monthly", "@monthly", at(1, 0, 0), true},
+		{"seconds are ignored", "30 2 * * *", at(19, 2, 30).Add(59 * time.Second), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+			}
+			if got := schedule.Matches(tt.t); got != tt.want {
+				t.Errorf("Matches(%s) = %v, want %v", tt.t.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	// 2026-10-19 is a Monday
+	from := time.Date(2026, time.October, 19, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"every minute", "* * * * *", from, time.Date(2026, time.October, 19, 10, 21, 0, 0, time.UTC)},
+		{"later this hour", "*/15 * * * *", from, time.Date(2026, time.October, 19, 10, 30, 0, 0, time.UTC)},
+		{"next hour", "5 * * * *", from, time.Date(2026, time.October, 19, 11, 5, 0, 0, time.UTC)},
+		{"tomorrow", "0 2 * * *", from, time.Date(2026, time.October, 20, 2, 0, 0, 0, time.UTC)},
+		{"exactly on a slot moves past it", "0 2 * * *", time.Date(2026, time.October, 20, 2, 0, 0, 0, time.UTC), time.Date(2026, time.October, 21, 2, 0, 0, 0, time.UTC)},
+		{"next Monday", "0 7 * * 1", from, time.Date(2026, time.October, 26, 7, 0, 0, 0, time.UTC)},
+		{"weekend", "0 9 * * 6,0", from, time.Date(2026, time.October, 24, 9, 0, 0, 0, time.UTC)},
+		{"next month", "0 0 1 * *", from, time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)},
+		{"next year", "0 0 1 1 *", from, time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", from, time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"in the location of from", "0 7 * * *", from.In(jakarta), time.Date(2026, time.October, 20, 7, 0, 0, 0, jakarta)},
+		{"never", "0 0 30 2 *", from, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from.Format(time.RFC3339), got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+			}
+		})
+	}
+}
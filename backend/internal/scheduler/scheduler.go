@@ -0,0 +1,326 @@
+// Package scheduler runs background jobs on cron schedules. Leases in a
+// shared Store make sure every scheduled slot runs on one instance only.
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+// Job is a unit of background work
+type Job struct {
+	Name        string
+	Description string
+	Schedule    string
+	Run         func(ctx context.Context) error
+	// Retries after a failed attempt, waiting RetryBackoff, then twice as long, ...
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// Timeout bounds a single attempt
+	Timeout time.Duration
+}
+
+const (
+	defaultJobTimeout   = 30 * time.Minute
+	defaultRetryBackoff = 30 * time.Second
+	// Leases outlive the worst case run so a crashed holder frees the job eventually
+	leaseSlack = 5 * time.Minute
+)
+
+var (
+	// ErrJobNotFound is returned for an unknown job name
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobRunning is returned when the job is already running somewhere
+	ErrJobRunning = errors.New("job is already running")
+)
+
+type entry struct {
+	job      Job
+	schedule *Schedule
+	running  bool
+}
+
+// Scheduler triggers registered jobs every minute their schedule matches
+type Scheduler struct {
+	store    Store
+	holder   string
+	location *time.Location
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	stop    chan struct{}
+	done    sync.WaitGroup
+}
+
+// New creates a scheduler evaluating cron expressions in loc
+func New(store Store, loc *time.Location) *Scheduler {
+	if loc == nil {
+		loc = time.Local
+	}
+	return &Scheduler{
+		store:    store,
+		holder:   holderID(),
+		location: loc,
+		entries:  make(map[string]*entry),
+	}
+}
+
+// Register adds a job; the name must be unique and the schedule valid
+func (s *Scheduler) Register(job Job) error {
+	schedule, err := ParseCron(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultJobTimeout
+	}
+	if job.RetryBackoff <= 0 {
+		job.RetryBackoff = defaultRetryBackoff
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.entries[job.Name]; exists {
+		return fmt.Errorf("job %s registered twice", job.Name)
+	}
+	s.entries[job.Name] = &entry{job: job, schedule: schedule}
+	return nil
+}
+
+// Start begins checking schedules at the top of every minute
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	if s.stop != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.stop = make(chan struct{})
+	s.mu.Unlock()
+
+	s.done.Add(1)
+	go s.loop()
+	log.Printf("✅ Scheduler started with %d jobs (holder %s)", len(s.entries), s.holder)
+}
+
+// Stop halts scheduling and waits for running jobs to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if s.stop == nil {
+		s.mu.Unlock()
+		return
+	}
+	close(s.stop)
+	s.mu.Unlock()
+	s.done.Wait()
+}
+
+func (s *Scheduler) loop() {
+	defer s.done.Done()
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		slot := next.In(s.location)
+		var due []*entry
+		s.mu.Lock()
+		for _, e := range s.entries {
+			if e.schedule.Matches(slot) {
+				due = append(due, e)
+			}
+		}
+		s.mu.Unlock()
+
+		for _, e := range due {
+			s.start(e, slot, "schedule")
+		}
+	}
+}
+
+// Trigger runs a job now, outside its schedule, and returns the started run
+func (s *Scheduler) Trigger(name string) (*models.JobRun, error) {
+	s.mu.Lock()
+	e, ok := s.entries[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+
+	run := s.start(e, time.Now().In(s.location), "manual")
+	if run == nil {
+		return nil, ErrJobRunning
+	}
+	return run, nil
+}
+
+// start claims the lease and runs the job in the background, or returns nil
+// when the job is running or the lease is taken. The entry is marked running
+// under s.mu, but the store is called without holding it, so a slow store
+// does not block other jobs, Jobs or Stop.
+func (s *Scheduler) start(e *entry, slot time.Time, trigger string) *models.JobRun {
+	s.mu.Lock()
+	if e.running {
+		s.mu.Unlock()
+		return nil
+	}
+	e.running = true
+	s.done.Add(1)
+	s.mu.Unlock()
+
+	ttl := time.Duration(e.job.MaxRetries+1)*e.job.Timeout + leaseSlack
+	acquired, err := s.store.AcquireLease(e.job.Name, s.holder, slot, ttl)
+	if err != nil {
+		log.Printf("scheduler: %s: acquiring lease failed: %v", e.job.Name, err)
+	}
+	if err != nil || !acquired {
+		s.mu.Lock()
+		e.running = false
+		s.mu.Unlock()
+		s.done.Done()
+		return nil
+	}
+
+	run := &models.JobRun{
+		Job:          e.job.Name,
+		Trigger:      trigger,
+		Holder:       s.holder,
+		Status:       "running",
+		ScheduledFor: slot,
+		StartedAt:    time.Now(),
+	}
+	if err := s.store.SaveRun(run); err != nil {
+		log.Printf("scheduler: %s: saving run failed: %v", e.job.Name, err)
+	}
+
+	started := *run
+	go s.execute(e, run)
+	return &started
+}
+
+func (s *Scheduler) execute(e *entry, run *models.JobRun) {
+	defer s.done.Done()
+
+	var err error
+	backoff := e.job.RetryBackoff
+	for attempt := 0; attempt <= e.job.MaxRetries; attempt++ {
+		if attempt > 0 {
+			log.Printf("scheduler: %s: attempt %d failed, retrying in %s: %v", e.job.Name, attempt, backoff, err)
+			select {
+			case <-s.stop:
+				attempt = e.job.MaxRetries + 1
+				continue
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		run.Attempts++
+		err = s.runOnce(e.job)
+		if err == nil {
+			break
+		}
+	}
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	if err != nil {
+		run.Status = "failed"
+		run.Error = err.Error()
+		log.Printf("scheduler: %s failed after %d attempts: %v", e.job.Name, run.Attempts, err)
+	} else {
+		run.Status = "succeeded"
+	}
+	if saveErr := s.store.SaveRun(run); saveErr != nil {
+		log.Printf("scheduler: %s: saving run failed: %v", e.job.Name, saveErr)
+	}
+	if releaseErr := s.store.ReleaseLease(e.job.Name, s.holder); releaseErr != nil {
+		log.Printf("scheduler: %s: releasing lease failed: %v", e.job.Name, releaseErr)
+	}
+
+	s.mu.Lock()
+	e.running = false
+	s.mu.Unlock()
+}
+
+// runOnce runs a single attempt, turning panics into errors
+func (s *Scheduler) runOnce(job Job) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), job.Timeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+// Jobs lists the registered jobs with their next run and latest run
+func (s *Scheduler) Jobs() []models.JobInfo {
+	s.mu.Lock()
+	infos := make([]models.JobInfo, 0, len(s.entries))
+	for _, e := range s.entries {
+		infos = append(infos, models.JobInfo{
+			Name:        e.job.Name,
+			Description: e.job.Description,
+			Schedule:    e.job.Schedule,
+			NextRun:     e.schedule.Next(time.Now().In(s.location)),
+			Running:     e.running,
+		})
+	}
+	s.mu.Unlock()
+
+	for i := range infos {
+		if runs, err := s.store.FindRuns(infos[i].Name, 1); err == nil && len(runs) > 0 {
+			infos[i].LastRun = &runs[0]
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// Runs returns the latest runs of a job, newest first
+func (s *Scheduler) Runs(name string, limit int) ([]models.JobRun, error) {
+	s.mu.Lock()
+	_, ok := s.entries[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return s.store.FindRuns(name, limit)
+}
+
+func holderID() string {
+	host, _ := os.Hostname()
+	buf := make([]byte, 4)
+	rand.Read(buf)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(buf))
+}
+
+var defaultScheduler *Scheduler
+
+// SetDefault installs the scheduler used by the admin API
+func SetDefault(s *Scheduler) {
+	defaultScheduler = s
+}
+
+// Default returns the installed scheduler, or nil when scheduling is disabled
+func Default() *Scheduler {
+	return defaultScheduler
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+// blockingStore holds AcquireLease until release is closed
+type blockingStore struct {
+	*MemoryStore
+	acquiring chan struct{}
+	release   chan struct{}
+}
+
+func (b *blockingStore) AcquireLease(job, holder string, slot time.Time, ttl time.Duration) (bool, error) {
+	b.acquiring <- struct{}{}
+	<-b.release
+	return b.MemoryStore.AcquireLease(job, holder, slot, ttl)
+}
+
+func TestTriggerDoesNotHoldLockWhileAcquiringLease(t *testing.T) {
+	store := &blockingStore{
+		MemoryStore: NewMemoryStore(),
+		acquiring:   make(chan struct{}, 1),
+		release:     make(chan struct{}),
+	}
+	s := New(store, time.UTC)
+	ran := make(chan struct{})
+	if err := s.Register(Job{Name: "job", Schedule: "@daily", Run: func(ctx context.Context) error {
+		close(ran)
+		return nil
+	}}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	triggered := make(chan error, 1)
+	go func() {
+		_, err := s.Trigger("job")
+		triggered <- err
+	}()
+	<-store.acquiring
+
+	listed := make(chan []models.JobInfo, 1)
+	go func() { listed <- s.Jobs() }()
+	select {
+	case jobs := <-listed:
+		if len(jobs) != 1 || !jobs[0].Running {
+			t.Errorf("Jobs() = %+v, want the job marked running", jobs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Jobs() blocked while a lease was being acquired")
+	}
+
+	if _, err := s.Trigger("job"); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("second Trigger() error = %v, want %v", err, ErrJobRunning)
+	}
+
+	close(store.release)
+	if err := <-triggered; err != nil {
+		t.Fatalf("Trigger: %v", err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("job did not run")
+	}
+	s.done.Wait()
+}
+
+func TestTriggerWithTakenLease(t *testing.T) {
+	store := NewMemoryStore()
+	s := New(store, time.UTC)
+	if err := s.Register(Job{Name: "job", Schedule: "@daily", Run: func(ctx context.Context) error { return nil }}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if _, err := s.Trigger("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Trigger(missing) error = %v, want %v", err, ErrJobNotFound)
+	}
+
+	// Another instance holds a live lease
+	store.leases["job"] = models.JobLease{Job: "job", Holder: "other", ExpiresAt: time.Now().Add(time.Hour)}
+	if _, err := s.Trigger("job"); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("Trigger() error = %v, want %v", err, ErrJobRunning)
+	}
+	if jobs := s.Jobs(); jobs[0].Running {
+		t.Error("job left marked running after the lease was refused")
+	}
+}
@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+// Store persists leases and run history. Implementations backed by shared
+// storage make the scheduler safe to run on several instances at once.
+type Store interface {
+	// AcquireLease claims the job for holder until now+ttl. It fails when
+	// another holder has a live lease or slot was already claimed.
+	AcquireLease(job, holder string, slot time.Time, ttl time.Duration) (bool, error)
+	ReleaseLease(job, holder string) error
+	SaveRun(run *models.JobRun) error
+	FindRuns(job string, limit int) ([]models.JobRun, error)
+}
+
+// MemoryStore keeps leases and runs in process; it only coordinates a single instance
+type MemoryStore struct {
+	mu     sync.Mutex
+	leases map[string]models.JobLease
+	runs   map[string][]models.JobRun
+	nextID int
+}
+
+// maxMemoryRuns caps the run history kept per job
+const maxMemoryRuns = 100
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		leases: make(map[string]models.JobLease),
+		runs:   make(map[string][]models.JobRun),
+	}
+}
+
+func (m *MemoryStore) AcquireLease(job, holder string, slot time.Time, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	lease := m.leases[job]
+	if !slot.After(lease.LastSlot) {
+		return false, nil
+	}
+	if lease.Holder != "" && lease.Holder != holder && lease.ExpiresAt.After(now) {
+		return false, nil
+	}
+
+	m.leases[job] = models.JobLease{Job: job, Holder: holder, ExpiresAt: now.Add(ttl), LastSlot: slot}
+	return true, nil
+}
+
+func (m *MemoryStore) ReleaseLease(job, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lease, ok := m.leases[job]
+	if !ok || lease.Holder != holder {
+		return fmt.Errorf("lease not held")
+	}
+	lease.ExpiresAt = time.Now()
+	m.leases[job] = lease
+	return nil
+}
+
+func (m *MemoryStore) SaveRun(run *models.JobRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if run.ID == "" {
+		m.nextID++
+		run.ID = fmt.Sprintf("%s-%d", run.Job, m.nextID)
+	}
+
+	runs := m.runs[run.Job]
+	for i := range runs {
+		if runs[i].ID == run.ID {
+			runs[i] = *run
+			return nil
+		}
+	}
+	runs = append(runs, *run)
+	if len(runs) > maxMemoryRuns {
+		runs = runs[len(runs)-maxMemoryRuns:]
+	}
+	m.runs[run.Job] = runs
+	return nil
+}
+
+func (m *MemoryStore) FindRuns(job string, limit int) ([]models.JobRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	runs := append([]models.JobRun(nil), m.runs[job]...)
+	sort.Slice(runs, func(i, j int) bool { return runs[i].StartedAt.After(runs[j].StartedAt) })
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
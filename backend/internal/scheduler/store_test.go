@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+func TestMemoryStoreAcquireLease(t *testing.T) {
+	slot := time.Date(2026, time.October, 19, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		lease  *models.JobLease
+		holder string
+		slot   time.Time
+		want   bool
+	}{
+		{
+			name:   "no lease yet",
+			holder: "a",
+			slot:   slot,
+			want:   true,
+		},
+		{
+			name:   "slot already claimed by the same holder",
+			lease:  &models.JobLease{Holder: "a", ExpiresAt: time.Now().Add(-time.Minute), LastSlot: slot},
+			holder: "a",
+			slot:   slot,
+			want:   false,
+		},
+		{
+			name:   "slot already claimed by another holder",
+			lease:  &models.JobLease{Holder: "b", ExpiresAt: time.Now().Add(-time.Minute), LastSlot: slot},
+			holder: "a",
+			slot:   slot,
+			want:   false,
+		},
+		{
+			name:   "earlier slot",
+			lease:  &models.JobLease{Holder: "b", ExpiresAt: time.Now().Add(-time.Minute), LastSlot: slot},
+			holder: "a",
+			slot:   slot.Add(-time.Hour),
+			want:   false,
+		},
+		{
+			name:   "live lease of another holder",
+			lease:  &models.JobLease{Holder: "b", ExpiresAt: time.Now().Add(time.Hour), LastSlot: slot},
+			holder: "a",
+			slot:   slot.Add(time.Hour),
+			want:   false,
+		},
+		{
+			name:   "expired lease of another holder",
+			lease:  &models.JobLease{Holder: "b", ExpiresAt: time.Now().Add(-time.Minute), LastSlot: slot},
+			holder: "a",
+			slot:   slot.Add(time.Hour),
+			want:   true,
+		},
+		{
+			name:   "live lease of the same holder",
+			lease:  &models.JobLease{Holder: "a", ExpiresAt: time.Now().Add(time.Hour), LastSlot: slot},
+			holder: "a",
+			slot:   slot.Add(time.Hour),
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			if tt.lease != nil {
+				lease := *tt.lease
+				lease.Job = "job"
+				store.leases["job"] = lease
+			}
+
+			got, err := store.AcquireLease("job", tt.holder, tt.slot, time.Hour)
+			if err != nil {
+				t.Fatalf("AcquireLease: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AcquireLease = %v, want %v", got, tt.want)
+			}
+			if got && store.leases["job"].Holder != tt.holder {
+				t.Errorf("lease holder = %q, want %q", store.leases["job"].Holder, tt.holder)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreReleaseLease(t *testing.T) {
+	slot := time.Date(2026, time.October, 19, 2, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+
+	if err := store.ReleaseLease("job", "a"); err == nil {
+		t.Error("releasing a lease that was never acquired succeeded")
+	}
+	if acquired, _ := store.AcquireLease("job", "a", slot, time.Hour); !acquired {
+		t.Fatal("first AcquireLease failed")
+	}
+	if err := store.ReleaseLease("job", "b"); err == nil {
+		t.Error("releasing another holder's lease succeeded")
+	}
+	if acquired, _ := store.AcquireLease("job", "b", slot.Add(time.Minute), time.Hour); acquired {
+		t.Error("another holder acquired a live lease")
+	}
+	if err := store.ReleaseLease("job", "a"); err != nil {
+		t.Fatalf("ReleaseLease: %v", err)
+	}
+	if acquired, _ := store.AcquireLease("job", "b", slot, time.Hour); acquired {
+		t.Error("a released lease was acquired again for the same slot")
+	}
+	if acquired, _ := store.AcquireLease("job", "b", slot.Add(time.Minute), time.Hour); !acquired {
+		t.Error("another holder could not acquire a released lease for the next slot")
+	}
+}
+
+func TestMemoryStoreRuns(t *testing.T) {
+	store := NewMemoryStore()
+	start := time.Date(2026, time.October, 19, 2, 0, 0, 0, time.UTC)
+
+	first := &models.JobRun{Job: "job", Status: "running", StartedAt: start}
+	if err := store.SaveRun(first); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+	if first.ID == "" {
+		t.Fatal("SaveRun did not assign an ID")
+	}
+	first.Status = "succeeded"
+	if err := store.SaveRun(first); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+	for i := 1; i <= maxMemoryRuns; i++ {
+		store.SaveRun(&models.JobRun{Job: "job", Status: "succeeded", StartedAt: start.Add(time.Duration(i) * time.Minute)})
+	}
+
+	runs, err := store.FindRuns("job", 0)
+	if err != nil {
+		t.Fatalf("FindRuns: %v", err)
+	}
+	if len(runs) != maxMemoryRuns {
+		t.Fatalf("got %d runs, want %d", len(runs), maxMemoryRuns)
+	}
+	if !runs[0].StartedAt.After(runs[1].StartedAt) {
+		t.Error("runs are not newest first")
+	}
+	for _, run := range runs {
+		if run.ID == first.ID {
+			t.Error("the oldest run was kept past the cap")
+		}
+	}
+
+	latest, _ := store.FindRuns("job", 1)
+	if len(latest) != 1 || !latest[0].StartedAt.Equal(start.Add(maxMemoryRuns*time.Minute)) {
+		t.Errorf("FindRuns(job, 1) = %+v, want the latest run", latest)
+	}
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+// Achievement metrics that badge rules can reference
+const (
+	MetricStreakCurrent         = "streak.current"
+	MetricStreakLongest         = "streak.longest"
+	MetricActivityTotalMinutes  = "activity.totalMinutes"
+	MetricActivityEarlySessions = "activity.earlyMorningSessions"
+	MetricActivityMaxDailyCount = "activity.maxDailyCount"
+	MetricModulesMaxDaily       = "modules.maxDailyCompleted"
+	MetricQuizCount             = "quiz.count"
+	MetricQuizPerfectScores     = "quiz.perfectScores"
+	MetricQuizAverageScore      = "quiz.averageScore"
+	MetricModulesCompleted      = "modules.completed"
+)
+
+const (
+	earlyMorningHour = 6
+	perfectQuizScore = 100
+)
+
+var (
+	ErrBadgeNotFound    = errors.New("badge not found")
+	ErrInvalidBadgeRule = errors.New("invalid badge rule")
+)
+
+var achievementMetrics = []string{
+	MetricStreakCurrent,
+	MetricStreakLongest,
+	MetricActivityTotalMinutes,
+	MetricActivityEarlySessions,
+	MetricActivityMaxDailyCount,
+	MetricModulesMaxDaily,
+	MetricQuizCount,
+	MetricQuizPerfectScores,
+	MetricQuizAverageScore,
+	MetricModulesCompleted,
+}
+
+var ruleOperators = map[string]func(value, threshold float64) bool{
+	"gte": func(v, t float64) bool { return v >= t },
+	"gt":  func(v, t float64) bool { return v > t },
+	"eq":  func(v, t float64) bool { return v == t },
+	"lte": func(v, t float64) bool { return v <= t },
+	"lt":  func(v, t float64) bool { return v < t },
+}
+
+// Built-in badges, always available unless overridden by a stored definition
+var defaultBadgeDefinitions = []models.BadgeDefinition{
+	{ID: "early-bird", Name: "Early Bird", Icon: "🌅", Description: "Belajar sebelum jam 6 pagi", Active: true,
+		Rule: models.BadgeRule{Metric: MetricActivityEarlySessions, Operator: "gte", Threshold: 1}},
+	{ID: "consistent-learner", Name: "Consistent Learner", Icon: "🔥", Description: "Streak 7 hari berturut-turut", Active: true,
+		Rule: models.BadgeRule{Metric: MetricStreakLongest, Operator: "gte", Threshold: 7}},
+	{ID: "quiz-master", Name: "Quiz Master", Icon: "🏆", Description: "Skor 100% di 5 quiz", Active: true,
+		Rule: models.BadgeRule{Metric: MetricQuizPerfectScores, Operator: "gte", Threshold: 5}},
+	{ID: "fast-learner", Name: "Fast Learner", Icon: "⚡", Description: "Selesaikan 10 modul dalam sehari", Active: true,
+		Rule: models.BadgeRule{Metric: MetricModulesMaxDaily, Operator: "gte", Threshold: 10}},
+}
+
+// Badge data for development (used as fallback when Firebase is not configured)
+var (
+	mockBadgeDefinitions = make(map[string]models.BadgeDefinition)
+	mockBadgeAwards      = make(map[string][]models.BadgeAward)
+	mockBadgesMu         sync.Mutex
+)
+
+type AchievementService struct {
+	badgeRepo           *repository.BadgeRepository
+	awardRepo           *repository.BadgeAwardRepository
+	activityRepo        *repository.ActivityRepository
+	settingsRepo        *repository.SettingsRepository
+	userRepo            *repository.UserRepository
+	authService         *AuthService
+	streakService       *StreakService
+	courseService       *CourseService
+	notificationService *NotificationService
+}
+
+func NewAchievementService() *AchievementService {
+	return &AchievementService{
+		badgeRepo:           repository.NewBadgeRepository(),
+		awardRepo:           repository.NewBadgeAwardRepository(),
+		activityRepo:        repository.NewActivityRepository(),
+		settingsRepo:        repository.NewSettingsRepository(),
+		userRepo:            repository.NewUserRepository(),
+		authService:         NewAuthService(),
+		streakService:       NewStreakService(),
+		courseService:       NewCourseService(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// GetDefinitions returns all badge definitions, stored ones overriding defaults
+func (s *AchievementService) GetDefinitions() []models.BadgeDefinition {
+	byID := make(map[string]models.BadgeDefinition)
+	for _, badge := range defaultBadgeDefinitions {
+		byID[badge.ID] = badge
+	}
+
+	// Try Firestore first
+	stored, err := s.badgeRepo.FindAll()
+	if err != nil {
+		// Fallback to mock data
+		mockBadgesMu.Lock()
+		for _, badge := range mockBadgeDefinitions {
+			stored = append(stored, badge)
+		}
+		mockBadgesMu.Unlock()
+	}
+	for _, badge := range stored {
+		byID[badge.ID] = badge
+	}
+
+	definitions := make([]models.BadgeDefinition, 0, len(byID))
+	for _, badge := range byID {
+		definitions = append(definitions, badge)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].ID < definitions[j].ID })
+	return definitions
+}
+
+// SaveDefinition creates or updates a badge definition
+func (s *AchievementService) SaveDefinition(req models.BadgeDefinitionRequest) (*models.BadgeDefinition, error) {
+	if err := validateBadgeRule(req.Rule); err != nil {
+		return nil, err
+	}
+
+	badge := &models.BadgeDefinition{
+		ID:          strings.TrimSpace(req.ID),
+		Name:        req.Name,
+		Icon:        req.Icon,
+		Description: req.Description,
+		Rule:        req.Rule,
+		Active:      req.Active == nil || *req.Active,
+		CreatedAt:   time.Now(),
+	}
+	for _, existing := range s.GetDefinitions() {
+		if existing.ID == badge.ID && !existing.CreatedAt.IsZero() {
+			badge.CreatedAt = existing.CreatedAt
+		}
+	}
+
+	// Try Firestore first
+	if s.badgeRepo.IsFirestoreAvailable() {
+		if err := s.badgeRepo.Save(badge); err != nil {
+			return nil, err
+		}
+		return badge, nil
+	}
+
+	// Fallback to mock data
+	mockBadgesMu.Lock()
+	mockBadgeDefinitions[badge.ID] = *badge
+	mockBadgesMu.Unlock()
+	return badge, nil
+}
+
+// DeactivateDefinition stops a badge from being awarded; existing awards are kept
+func (s *AchievementService) DeactivateDefinition(badgeID string) error {
+	for _, badge := range s.GetDefinitions() {
+		if badge.ID != badgeID {
+			continue
+		}
+		active := false
+		_, err := s.SaveDefinition(models.BadgeDefinitionRequest{
+			ID:          badge.ID,
+			Name:        badge.Name,
+			Icon:        badge.Icon,
+			Description: badge.Description,
+			Rule:        badge.Rule,
+			Active:      &active,
+		})
+		return err
+	}
+	return ErrBadgeNotFound
+}
+
+// GetMetrics returns the current achievement metrics for a user
+func (s *AchievementService) GetMetrics(userID string) map[string]float64 {
+	settings := loadUserSettings(s.settingsRepo, userID)
+	loc := userLocation(settings)
+	activities := findUserActivities(s.activityRepo, userID)
+	streak := s.streakService.GetSummary(userID)
+
+	metrics := map[string]float64{
+		MetricStreakCurrent: float64(streak.CurrentStreak),
+		MetricStreakLongest: float64(streak.LongestStreak),
+	}
+
+	perDay := make(map[string]int)
+	quizTotal := 0
+	for _, activity := range activities {
+		local := activity.Date.In(loc)
+		date := local.Format(dateLayout)
+		perDay[date]++
+		metrics[MetricActivityTotalMinutes] += float64(activity.Duration)
+		if local.Hour() < earlyMorningHour {
+			metrics[MetricActivityEarlySessions]++
+		}
+		if activity.Type == "quiz" && activity.Score != nil {
+			metrics[MetricQuizCount]++
+			quizTotal += *activity.Score
+			if *activity.Score >= perfectQuizScore {
+				metrics[MetricQuizPerfectScores]++
+			}
+		}
+	}
+	for _, count := range perDay {
+		metrics[MetricActivityMaxDailyCount] = max(metrics[MetricActivityMaxDailyCount], float64(count))
+	}
+	if metrics[MetricQuizCount] > 0 {
+		metrics[MetricQuizAverageScore] = float64(quizTotal) / metrics[MetricQuizCount]
+	}
+
+	// Modules completed in total and per local day, from the student's
+	// module progress
+	modulesPerDay := make(map[string]int)
+	for _, record := range s.courseService.GetModuleProgress(userID) {
+		if record.Status != ModuleCompleted {
+			continue
+		}
+		metrics[MetricModulesCompleted]++
+		if record.CompletedAt != nil {
+			modulesPerDay[record.CompletedAt.In(loc).Format(dateLayout)]++
+		}
+	}
+	for _, count := range modulesPerDay {
+		metrics[MetricModulesMaxDaily] = max(metrics[MetricModulesMaxDaily], float64(count))
+	}
+
+	return metrics
+}
+
+// Evaluate checks every active badge rule for a user and awards newly
+// earned badges. Awarding is idempotent: a badge is only awarded once.
+func (s *AchievementService) Evaluate(userID string) []models.Badge {
+	awarded := make(map[string]bool)
+	for _, award := range s.getAwards(userID) {
+		awarded[award.BadgeID] = true
+	}
+
+	metrics := s.GetMetrics(userID)
+
+	var newBadges []models.Badge
+	for _, badge := range s.GetDefinitions() {
+		if !badge.Active || awarded[badge.ID] || !ruleSatisfied(badge.Rule, metrics) {
+			continue
+		}
+
+		award := &models.BadgeAward{
+			UserID:    userID,
+			BadgeID:   badge.ID,
+			AwardedAt: time.Now(),
+		}
+		if err := s.createAward(award); err != nil {
+			continue
+		}
+
+		s.notificationService.Notify(userID, CategoryCourseUpdates, "success", "Badge Baru",
+			fmt.Sprintf("Selamat! Anda mendapatkan badge %s %s", badge.Icon, badge.Name))
+		newBadges = append(newBadges, toBadge(badge, award))
+	}
+
+	return newBadges
+}
+
+// EvaluateActive evaluates the badges of every student with activity, quiz
+// attempts included, or module progress since the given time
+func (s *AchievementService) EvaluateActive(since time.Time) int {
+	evaluated := 0
+	for _, student := range findAllStudents(s.userRepo) {
+		if len(findUserActivitiesBetween(s.activityRepo, student.ID, since, time.Now())) == 0 &&
+			!slices.ContainsFunc(s.courseService.GetModuleProgress(student.ID), func(record models.ModuleProgress) bool {
+				return !record.UpdatedAt.Before(since)
+			}) {
+			continue
+		}
+		s.Evaluate(student.ID)
+		evaluated++
+	}
+	return evaluated
+}
+
+// GetUserBadges returns every badge the user holds. Badges are awarded by
+// Evaluate, run on new activity and streak changes.
+func (s *AchievementService) GetUserBadges(userID string) []models.Badge {
+	definitions := make(map[string]models.BadgeDefinition)
+	for _, badge := range s.GetDefinitions() {
+		definitions[badge.ID] = badge
+	}
+
+	badges := []models.Badge{}
+	for _, award := range s.getAwards(userID) {
+		if badge, ok := definitions[award.BadgeID]; ok {
+			badges = append(badges, toBadge(badge, &award))
+		}
+	}
+	return badges
+}
+
+func (s *AchievementService) getAwards(userID string) []models.BadgeAward {
+	// Try Firestore first
+	if s.awardRepo.IsFirestoreAvailable() {
+		awards, err := s.awardRepo.FindByUserID(userID)
+		if err == nil {
+			return awards
+		}
+	}
+
+	// Fallback to mock data
+	mockBadgesMu.Lock()
+	defer mockBadgesMu.Unlock()
+	return append([]models.BadgeAward(nil), mockBadgeAwards[userID]...)
+}
+
+func (s *AchievementService) createAward(award *models.BadgeAward) error {
+	// Try Firestore first
+	if s.awardRepo.IsFirestoreAvailable() {
+		return s.awardRepo.Create(award)
+	}
+
+	// Fallback to mock data
+	mockBadgesMu.Lock()
+	defer mockBadgesMu.Unlock()
+	for _, existing := range mockBadgeAwards[award.UserID] {
+		if existing.BadgeID == award.BadgeID {
+			return fmt.Errorf("badge already awarded")
+		}
+	}
+	award.ID = award.UserID + "_" + award.BadgeID
+	mockBadgeAwards[award.UserID] = append(mockBadgeAwards[award.UserID], *award)
+	return nil
+}
+
+func validateBadgeRule(rule models.BadgeRule) error {
+	if _, ok := ruleOperators[rule.Operator]; !ok {
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidBadgeRule, rule.Operator)
+	}
+	for _, metric := range achievementMetrics {
+		if metric == rule.Metric {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unknown metric %q", ErrInvalidBadgeRule, rule.Metric)
+}
+
+func ruleSatisfied(rule models.BadgeRule, metrics map[string]float64) bool {
+	compare, ok := ruleOperators[rule.Operator]
+	if !ok {
+		return false
+	}
+	return compare(metrics[rule.Metric], rule.Threshold)
+}
+
+func toBadge(badge models.BadgeDefinition, award *models.BadgeAward) models.Badge {
+	awardedAt := award.AwardedAt
+	return models.Badge{
+		ID:          badge.ID,
+		Name:        badge.Name,
+		Icon:        badge.Icon,
+		Description: badge.Description,
+		AwardedAt:   &awardedAt,
+	}
+}
+
+// AchievementMetrics lists the metric names that badge rules may use
+func AchievementMetrics() []string {
+	return append([]string(nil), achievementMetrics...)
+}
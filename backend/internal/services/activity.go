@@ -0,0 +1,130 @@
+package services
+
+import (
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	defaultTimezone = "Asia/Jakarta"
+)
+
+// findUserActivities returns every activity of a user, newest first
+func findUserActivities(activityRepo *repository.ActivityRepository, userID string) []models.ActivityLog {
+	// Try Firestore first
+	if activityRepo.IsFirestoreAvailable() {
+		activities, err := activityRepo.FindByUserID(userID, 0)
+		if err == nil {
+			return activities
+		}
+	}
+
+	// Fallback to mock data
+	var userActivities []models.ActivityLog
+	for _, activity := range mockActivityLogs {
+		if activity.UserID == userID {
+			userActivities = append(userActivities, activity)
+		}
+	}
+	return userActivities
+}
+
+// findUserActivitiesBetween returns a user's activities in [start, end]
+func findUserActivitiesBetween(activityRepo *repository.ActivityRepository, userID string, start, end time.Time) []models.ActivityLog {
+	// Try Firestore first
+	if activityRepo.IsFirestoreAvailable() {
+		activities, err := activityRepo.FindByDateRange(userID, start, end)
+		if err == nil {
+			return activities
+		}
+	}
+
+	// Fallback to mock data
+	var userActivities []models.ActivityLog
+	for _, activity := range mockActivityLogs {
+		if activity.UserID == userID && !activity.Date.Before(start) && !activity.Date.After(end) {
+			userActivities = append(userActivities, activity)
+		}
+	}
+	return userActivities
+}
+
+// loadUserSettings returns the user's settings, or defaults when none are stored
+func loadUserSettings(settingsRepo *repository.SettingsRepository, userID string) *models.UserSettings {
+	// Try Firestore first
+	if settingsRepo.IsFirestoreAvailable() {
+		settings, err := settingsRepo.FindByUserID(userID)
+		if err == nil {
+			return settings
+		}
+	}
+
+	// Fallback to mock data
+	if settings := GetUserSettings(userID); settings != nil {
+		return settings
+	}
+	return &models.UserSettings{
+		UserID: userID,
+		Notifications: models.NotificationSettings{
+			Email:           true,
+			Push:            true,
+			StudyReminder:   true,
+			WeeklyReport:    true,
+			MentorMessages:  true,
+			QuietHoursStart: "22:00",
+			QuietHoursEnd:   "07:00",
+		},
+		Learning: models.LearningSettings{
+			DailyGoal:    60,
+			ReminderTime: "08:00",
+			Timezone:     defaultTimezone,
+		},
+	}
+}
+
+// userLocation resolves the timezone configured in the user's learning settings
+func userLocation(settings *models.UserSettings) *time.Location {
+	name := defaultTimezone
+	if settings != nil && settings.Learning.Timezone != "" {
+		name = settings.Learning.Timezone
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc, err = time.LoadLocation(defaultTimezone)
+		if err != nil {
+			return time.UTC
+		}
+	}
+	return loc
+}
+
+// dailyMinutes sums activity duration per local calendar day
+func dailyMinutes(activities []models.ActivityLog, loc *time.Location) map[string]int {
+	minutes := make(map[string]int)
+	for _, activity := range activities {
+		minutes[activity.Date.In(loc).Format(dateLayout)] += activity.Duration
+	}
+	return minutes
+}
+
+// startOfDay returns local midnight of the day containing t
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+// daysInactive counts the local calendar days since the user's last activity,
+// or since joining when there is none
+func daysInactive(user *models.User, activities []models.ActivityLog, loc *time.Location) int {
+	lastActive := user.JoinedDate
+	for _, activity := range activities {
+		if activity.Date.After(lastActive) {
+			lastActive = activity.Date
+		}
+	}
+	return int(startOfDay(time.Now(), loc).Sub(startOfDay(lastActive, loc)).Hours() / 24)
+}
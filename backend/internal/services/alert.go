@@ -0,0 +1,354 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+// Alert rule types
+const (
+	AlertInactivity     = "inactivity"
+	AlertRiskThreshold  = "risk_threshold"
+	AlertFailedQuizzes  = "failed_quizzes"
+	AlertMissedDeadline = "missed_deadline"
+	AlertFallingTrend   = "falling_trend"
+)
+
+// Rules every mentor starts with until they change them
+var defaultAlertRules = []models.AlertRule{
+	{Type: AlertInactivity, Threshold: 5, Severity: "danger", CooldownHours: 72, Enabled: true},
+	{Type: AlertRiskThreshold, Threshold: 60, Severity: "danger", CooldownHours: 72, Enabled: true},
+	{Type: AlertFailedQuizzes, Threshold: 2, Severity: "warning", CooldownHours: 48, Enabled: true},
+	{Type: AlertMissedDeadline, Threshold: 1, Severity: "warning", CooldownHours: 24, Enabled: true},
+	{Type: AlertFallingTrend, Threshold: 1, Severity: "warning", CooldownHours: 72, Enabled: true},
+}
+
+var alertTitles = map[string]string{
+	AlertInactivity:     "Danger Zone",
+	AlertRiskThreshold:  "Risiko Tinggi",
+	AlertFailedQuizzes:  "Quiz Gagal Berturut-turut",
+	AlertMissedDeadline: "Deadline Terlewat",
+	AlertFallingTrend:   "Perlu Perhatian",
+}
+
+// Alert rules and events for development (used as fallback when Firebase is not configured)
+var (
+	mockAlertRules  = make(map[string]models.AlertRule)
+	mockAlertEvents = make(map[string]models.AlertEvent)
+	mockAlertsMu    sync.Mutex
+)
+
+type AlertService struct {
+	alertRepo           *repository.AlertRepository
+	userRepo            *repository.UserRepository
+	activityRepo        *repository.ActivityRepository
+	riskService         *RiskService
+	courseService       *CourseService
+	notificationService *NotificationService
+}
+
+func NewAlertService() *AlertService {
+	return &AlertService{
+		alertRepo:           repository.NewAlertRepository(),
+		userRepo:            repository.NewUserRepository(),
+		activityRepo:        repository.NewActivityRepository(),
+		riskService:         NewRiskService(),
+		courseService:       NewCourseService(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// GetRules returns the mentor's rules, defaults filled in for unconfigured types
+func (s *AlertService) GetRules(mentorID string) []models.AlertRule {
+	byType := make(map[string]models.AlertRule)
+	for _, rule := range defaultAlertRules {
+		rule.MentorID = mentorID
+		byType[rule.Type] = rule
+	}
+
+	// Try Firestore first
+	stored, err := s.alertRepo.FindRulesByMentorID(mentorID)
+	if err != nil {
+		// Fallback to mock data
+		mockAlertsMu.Lock()
+		for _, rule := range mockAlertRules {
+			if rule.MentorID == mentorID {
+				stored = append(stored, rule)
+			}
+		}
+		mockAlertsMu.Unlock()
+	}
+	for _, rule := range stored {
+		if _, known := byType[rule.Type]; known {
+			byType[rule.Type] = rule
+		}
+	}
+
+	rules := make([]models.AlertRule, 0, len(byType))
+	for _, rule := range byType {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Type < rules[j].Type })
+	return rules
+}
+
+// UpdateRule changes the settings of one of the mentor's rules
+func (s *AlertService) UpdateRule(mentorID, ruleType string, req models.UpdateAlertRuleRequest) (*models.AlertRule, error) {
+	var rule *models.AlertRule
+	for _, existing := range s.GetRules(mentorID) {
+		if existing.Type == ruleType {
+			rule = &existing
+			break
+		}
+	}
+	if rule == nil {
+		return nil, fmt.Errorf("unknown alert rule %q", ruleType)
+	}
+
+	if req.Threshold != nil {
+		if *req.Threshold <= 0 {
+			return nil, fmt.Errorf("threshold must be positive")
+		}
+		rule.Threshold = *req.Threshold
+	}
+	if req.Severity != nil {
+		if *req.Severity != "warning" && *req.Severity != "danger" {
+			return nil, fmt.Errorf("severity must be warning or danger")
+		}
+		rule.Severity = *req.Severity
+	}
+	if req.CooldownHours != nil {
+		if *req.CooldownHours < 0 {
+			return nil, fmt.Errorf("cooldownHours must not be negative")
+		}
+		rule.CooldownHours = *req.CooldownHours
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.UpdatedAt = time.Now()
+
+	// Try Firestore first
+	if s.alertRepo.IsFirestoreAvailable() {
+		if err := s.alertRepo.SaveRule(rule); err != nil {
+			return nil, err
+		}
+		return rule, nil
+	}
+
+	// Fallback to mock data
+	mockAlertsMu.Lock()
+	mockAlertRules[mentorID+"_"+ruleType] = *rule
+	mockAlertsMu.Unlock()
+	return rule, nil
+}
+
+// EvaluateAll checks the rules of every mentor against their assigned students
+func (s *AlertService) EvaluateAll() []models.Notification {
+	var fired []models.Notification
+	for _, mentor := range findUsersByRole(s.userRepo, "mentor") {
+		fired = append(fired, s.EvaluateMentor(mentor.ID)...)
+	}
+	return fired
+}
+
+// EvaluateMentor checks the mentor's rules against each assigned student and
+// notifies the mentor of matches. A rule fires at most once per student
+// within its cool-down.
+func (s *AlertService) EvaluateMentor(mentorID string) []models.Notification {
+	rules := s.GetRules(mentorID)
+	fired := []models.Notification{}
+
+	for _, student := range findAssignedStudents(s.userRepo, mentorID) {
+		facts := s.collectFacts(student)
+		for _, rule := range rules {
+			if !rule.Enabled {
+				continue
+			}
+			message, matched := alertMatches(rule, student, facts)
+			if !matched {
+				continue
+			}
+
+			// Without a readable and stored event the cool-down cannot be
+			// kept, so the alert waits for the next run
+			event, err := s.getEvent(mentorID, student.ID, rule.Type)
+			if err != nil {
+				log.Printf("alert: failed to read %s event of student %s for mentor %s: %v", rule.Type, student.ID, mentorID, err)
+				continue
+			}
+			cooldown := time.Duration(rule.CooldownHours) * time.Hour
+			if event != nil && time.Since(event.LastFiredAt) < cooldown {
+				continue
+			}
+
+			next := models.AlertEvent{MentorID: mentorID, StudentID: student.ID, RuleType: rule.Type}
+			if event != nil {
+				next = *event
+			}
+			next.Message = message
+			next.LastFiredAt = time.Now()
+			next.FireCount++
+			if err := s.saveEvent(&next); err != nil {
+				log.Printf("alert: failed to save %s event of student %s for mentor %s: %v", rule.Type, student.ID, mentorID, err)
+				continue
+			}
+
+			notification, err := s.notificationService.Notify(mentorID, CategoryAlert, rule.Severity, alertTitles[rule.Type], message)
+			if err != nil {
+				// Undo the firing so the next run tries again
+				s.restoreEvent(&next, event)
+				continue
+			}
+
+			fired = append(fired, *notification)
+		}
+	}
+	return fired
+}
+
+// alertFacts are the student figures alert rules are checked against
+type alertFacts struct {
+	inactiveDays   int
+	riskScore      int
+	riskLevel      string
+	riskTrend      models.RiskTrend
+	failedInARow   int
+	overdueModules []string
+}
+
+func (s *AlertService) collectFacts(student models.User) alertFacts {
+	// Scores come from the snapshots the risk-recompute job records; alerts
+	// only read them
+	facts := alertFacts{
+		riskScore: student.RiskScore,
+		riskLevel: riskLevel(student.RiskScore, s.riskService.GetConfig()),
+	}
+	if snapshot := s.riskService.LatestSnapshot(student.ID); snapshot != nil {
+		facts.riskScore = snapshot.Score
+		facts.riskLevel = snapshot.Level
+	}
+	facts.riskTrend = s.riskService.GetTrend(student.ID)
+
+	activities := findUserActivities(s.activityRepo, student.ID)
+	lastActive := student.JoinedDate
+	for _, activity := range activities {
+		if activity.Date.After(lastActive) {
+			lastActive = activity.Date
+		}
+	}
+	facts.inactiveDays = int(time.Since(lastActive).Hours() / 24)
+
+	// Failed quiz attempts counted back from the latest one
+	var quizzes []models.ActivityLog
+	for _, activity := range activities {
+		if activity.Type == "quiz" && activity.Score != nil {
+			quizzes = append(quizzes, activity)
+		}
+	}
+	sort.Slice(quizzes, func(i, j int) bool { return quizzes[i].Date.After(quizzes[j].Date) })
+	for _, quiz := range quizzes {
+		if *quiz.Score >= quizPassingScore {
+			break
+		}
+		facts.failedInARow++
+	}
+
+	now := time.Now()
+	for _, course := range s.courseService.GetUserCourses(student.ID) {
+		for _, module := range course.Modules {
+			if module.DueDate != nil && module.Status != ModuleCompleted && module.DueDate.Before(now) {
+				facts.overdueModules = append(facts.overdueModules, module.Title)
+			}
+		}
+	}
+	return facts
+}
+
+func alertMatches(rule models.AlertRule, student models.User, facts alertFacts) (string, bool) {
+	switch rule.Type {
+	case AlertInactivity:
+		if float64(facts.inactiveDays) >= rule.Threshold {
+			return fmt.Sprintf("%s tidak aktif selama %d hari", student.Name, facts.inactiveDays), true
+		}
+	case AlertRiskThreshold:
+		if float64(facts.riskScore) >= rule.Threshold {
+			return fmt.Sprintf("Skor risiko %s mencapai %d (%s)", student.Name, facts.riskScore, riskLevelNames[facts.riskLevel]), true
+		}
+	case AlertFailedQuizzes:
+		if float64(facts.failedInARow) >= rule.Threshold {
+			return fmt.Sprintf("%s gagal dalam %d quiz berturut-turut", student.Name, facts.failedInARow), true
+		}
+	case AlertMissedDeadline:
+		if len(facts.overdueModules) > 0 && float64(len(facts.overdueModules)) >= rule.Threshold {
+			return fmt.Sprintf("%s melewati deadline %d modul, termasuk %s", student.Name, len(facts.overdueModules), facts.overdueModules[0]), true
+		}
+	case AlertFallingTrend:
+		if facts.riskTrend.Samples >= 2 && facts.riskTrend.Slope >= rule.Threshold {
+			return fmt.Sprintf("%s menunjukkan penurunan performa (risiko naik %.1f poin per hari)", student.Name, facts.riskTrend.Slope), true
+		}
+	}
+	return "", false
+}
+
+// getEvent returns when a rule last fired for a student, or nil when it never
+// did
+func (s *AlertService) getEvent(mentorID, studentID, ruleType string) (*models.AlertEvent, error) {
+	// Try Firestore first
+	if s.alertRepo.IsFirestoreAvailable() {
+		return s.alertRepo.FindEvent(mentorID, studentID, ruleType)
+	}
+
+	// Fallback to mock data
+	mockAlertsMu.Lock()
+	defer mockAlertsMu.Unlock()
+	if event, ok := mockAlertEvents[mentorID+"_"+studentID+"_"+ruleType]; ok {
+		return &event, nil
+	}
+	return nil, nil
+}
+
+func (s *AlertService) saveEvent(event *models.AlertEvent) error {
+	// Try Firestore first
+	if s.alertRepo.IsFirestoreAvailable() {
+		return s.alertRepo.SaveEvent(event)
+	}
+
+	// Fallback to mock data
+	mockAlertsMu.Lock()
+	mockAlertEvents[event.MentorID+"_"+event.StudentID+"_"+event.RuleType] = *event
+	mockAlertsMu.Unlock()
+	return nil
+}
+
+// restoreEvent puts back the event as it was before fired was saved
+func (s *AlertService) restoreEvent(fired, previous *models.AlertEvent) {
+	var err error
+	if previous != nil {
+		err = s.saveEvent(previous)
+	} else {
+		err = s.deleteEvent(fired)
+	}
+	if err != nil {
+		log.Printf("alert: failed to restore %s event of student %s for mentor %s: %v", fired.RuleType, fired.StudentID, fired.MentorID, err)
+	}
+}
+
+func (s *AlertService) deleteEvent(event *models.AlertEvent) error {
+	// Try Firestore first
+	if s.alertRepo.IsFirestoreAvailable() {
+		return s.alertRepo.DeleteEvent(event.MentorID, event.StudentID, event.RuleType)
+	}
+
+	// Fallback to mock data
+	mockAlertsMu.Lock()
+	delete(mockAlertEvents, event.MentorID+"_"+event.StudentID+"_"+event.RuleType)
+	mockAlertsMu.Unlock()
+	return nil
+}
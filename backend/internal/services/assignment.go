@@ -0,0 +1,720 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+// Assignment sources
+const (
+	AssignmentManual   = "manual"
+	AssignmentReassign = "reassign"
+	AssignmentAuto     = "auto"
+	AssignmentBackfill = "backfill"
+)
+
+const (
+	assignmentConfigKey   = "assignment"
+	defaultMentorCapacity = 20
+	// Weights of the auto-balancing score: share of the student's courses the
+	// mentor is expert in, free capacity, and capacity not taken by at-risk
+	// students
+	balanceExpertiseWeight = 0.5
+	balanceLoadWeight      = 0.3
+	balanceRiskWeight      = 0.2
+)
+
+var (
+	ErrAssignmentNotFound  = errors.New("assignment not found")
+	ErrAlreadyAssigned     = errors.New("student is already assigned to this mentor")
+	ErrStudentHasMentor    = errors.New("student already has a mentor; reassign them instead")
+	ErrAssignmentChanged   = errors.New("the student's assignment changed meanwhile; reload and try again")
+	ErrAssignmentForbidden = errors.New("mentors can only manage their own assignments")
+	ErrInvalidAssignment   = errors.New("invalid assignment")
+)
+
+// Assignments for development (used as fallback when Firebase is not configured),
+// seeded from the fixture mentors' AssignedStudents
+var (
+	mockAssignmentsMu    sync.Mutex
+	mockAssignments      = seedMockAssignments()
+	mockAssignmentSeq    = len(mockAssignments)
+	mockAssignmentConfig = models.AssignmentConfig{DefaultCapacity: defaultMentorCapacity}
+)
+
+func seedMockAssignments() []models.MentorAssignment {
+	var assignments []models.MentorAssignment
+	for _, user := range mockUsers {
+		for _, studentID := range user.AssignedStudents {
+			assignments = append(assignments, models.MentorAssignment{
+				ID:        fmt.Sprintf("%d", len(assignments)+1),
+				MentorID:  user.ID,
+				StudentID: studentID,
+				Source:    AssignmentBackfill,
+				Active:    true,
+				StartedAt: user.JoinedDate,
+			})
+		}
+	}
+	return assignments
+}
+
+type AssignmentService struct {
+	assignmentRepo      *repository.AssignmentRepository
+	configRepo          *repository.ConfigRepository
+	userRepo            *repository.UserRepository
+	authService         *AuthService
+	riskService         *RiskService
+	notificationService *NotificationService
+}
+
+func NewAssignmentService() *AssignmentService {
+	return &AssignmentService{
+		assignmentRepo:      repository.NewAssignmentRepository(),
+		configRepo:          repository.NewConfigRepository(),
+		userRepo:            repository.NewUserRepository(),
+		authService:         NewAuthService(),
+		riskService:         NewRiskService(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// List returns assignments, newest first. Admins see all; mentors see their
+// own and the history of the students currently assigned to them.
+func (s *AssignmentService) List(actorID string, query models.AssignmentQuery) ([]models.MentorAssignment, error) {
+	actor, err := s.actor(actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role == "mentor" {
+		switch {
+		case query.StudentID != "":
+			if !s.isAssigned(actor.ID, query.StudentID) {
+				return nil, ErrAssignmentForbidden
+			}
+		case query.MentorID == "":
+			query.MentorID = actor.ID
+		case query.MentorID != actor.ID:
+			return nil, ErrAssignmentForbidden
+		}
+	}
+
+	return s.find(query), nil
+}
+
+// Assign assigns a student without a mentor to a mentor. Mentors can only
+// assign students to themselves; a student who has a mentor is moved with
+// Reassign.
+func (s *AssignmentService) Assign(actorID string, req models.AssignRequest) (*models.MentorAssignment, error) {
+	actor, err := s.actor(actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role == "mentor" && req.MentorID != actor.ID {
+		return nil, ErrAssignmentForbidden
+	}
+
+	mentor, student, err := s.pair(req.MentorID, req.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	if s.isAssigned(mentor.ID, student.ID) {
+		return nil, ErrAlreadyAssigned
+	}
+	return s.start(mentor, student, AssignmentManual, actor.ID, req.Note, nil)
+}
+
+// Reassign moves a student from one mentor to another in one step, keeping
+// the ended assignment in the history. Mentors can only hand over their own
+// students.
+func (s *AssignmentService) Reassign(actorID string, req models.ReassignRequest) (*models.MentorAssignment, error) {
+	actor, err := s.actor(actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role == "mentor" && req.FromMentorID != actor.ID {
+		return nil, ErrAssignmentForbidden
+	}
+	if req.FromMentorID == req.ToMentorID {
+		return nil, fmt.Errorf("%w: student is already assigned to this mentor", ErrInvalidAssignment)
+	}
+
+	current := s.find(models.AssignmentQuery{MentorID: req.FromMentorID, StudentID: req.StudentID, ActiveOnly: true})
+	if len(current) == 0 {
+		return nil, ErrAssignmentNotFound
+	}
+	mentor, student, err := s.pair(req.ToMentorID, req.StudentID)
+	if err != nil {
+		return nil, err
+	}
+
+	ending := current[0]
+	now := time.Now()
+	ending.Active = false
+	ending.EndedAt = &now
+	ending.EndedBy = actor.ID
+	return s.start(mentor, student, AssignmentReassign, actor.ID, req.Note, &ending)
+}
+
+// Unassign ends an assignment. Mentors can only end their own.
+func (s *AssignmentService) Unassign(actorID, assignmentID string) (*models.MentorAssignment, error) {
+	actor, err := s.actor(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := s.findByID(assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role == "mentor" && assignment.MentorID != actor.ID {
+		return nil, ErrAssignmentForbidden
+	}
+	if !assignment.Active {
+		return nil, fmt.Errorf("%w: assignment has already ended", ErrInvalidAssignment)
+	}
+
+	if err := s.end(assignment, actor.ID); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// AutoAssign assigns a new student to the best-fitting mentor when automatic
+// assignment is on. It returns nil when it is off or no mentor has room.
+func (s *AssignmentService) AutoAssign(studentID string) (*models.MentorAssignment, error) {
+	if !s.GetConfig().AutoAssign {
+		return nil, nil
+	}
+	student, err := s.authService.GetUserByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.find(models.AssignmentQuery{StudentID: studentID, ActiveOnly: true})) > 0 {
+		return nil, nil
+	}
+
+	loads := s.Loads()
+	index := bestMentor(loads, student)
+	if index < 0 {
+		log.Printf("assignment: no mentor has room for student %s", studentID)
+		return nil, nil
+	}
+	mentor, err := s.authService.GetUserByID(loads[index].MentorID)
+	if err != nil {
+		return nil, err
+	}
+	assignment, err := s.start(mentor, student, AssignmentAuto, "", "", nil)
+	if errors.Is(err, ErrStudentHasMentor) {
+		// Assigned by someone else meanwhile
+		return nil, nil
+	}
+	return assignment, err
+}
+
+// Balance assigns every student without a mentor to the best-fitting mentor,
+// highest risk first so at-risk students get the best fit. A dry run only
+// returns the plan.
+func (s *AssignmentService) Balance(actorID string, dryRun bool) (*models.BalanceResult, error) {
+	loads := s.Loads()
+	result := &models.BalanceResult{
+		DryRun:      dryRun,
+		Assignments: []models.MentorAssignment{},
+		Unassigned:  []string{},
+	}
+
+	assigned := make(map[string]bool)
+	for _, assignment := range s.find(models.AssignmentQuery{ActiveOnly: true}) {
+		assigned[assignment.StudentID] = true
+	}
+	var students []models.User
+	for _, student := range findAllStudents(s.userRepo) {
+		if !assigned[student.ID] {
+			students = append(students, student)
+		}
+	}
+	sort.SliceStable(students, func(i, j int) bool {
+		return students[i].RiskScore > students[j].RiskScore
+	})
+
+	highThreshold := s.riskService.GetConfig().HighThreshold
+	for _, student := range students {
+		index := bestMentor(loads, &student)
+		if index < 0 {
+			result.Unassigned = append(result.Unassigned, student.ID)
+			continue
+		}
+
+		assignment := &models.MentorAssignment{
+			MentorID:  loads[index].MentorID,
+			StudentID: student.ID,
+			Source:    AssignmentAuto,
+			Active:    true,
+			StartedAt: time.Now(),
+		}
+		if !dryRun {
+			mentor, err := s.authService.GetUserByID(loads[index].MentorID)
+			if err != nil {
+				return nil, err
+			}
+			assignment, err = s.start(mentor, &student, AssignmentAuto, actorID, "", nil)
+			if errors.Is(err, ErrStudentHasMentor) {
+				// Assigned by someone else meanwhile
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		result.Assignments = append(result.Assignments, *assignment)
+
+		loads[index].Students++
+		if student.RiskScore >= highThreshold {
+			loads[index].AtRisk++
+		}
+	}
+
+	result.Loads = loads
+	return result, nil
+}
+
+// Loads returns every mentor's current number of students, capacity and
+// number of high-risk students
+func (s *AssignmentService) Loads() []models.MentorLoad {
+	config := s.GetConfig()
+	highThreshold := s.riskService.GetConfig().HighThreshold
+
+	loads := []models.MentorLoad{}
+	for _, mentor := range findUsersByRole(s.userRepo, "mentor") {
+		load := models.MentorLoad{
+			MentorID:         mentor.ID,
+			Name:             mentor.Name,
+			Capacity:         mentorCapacity(&mentor, config),
+			ExpertiseCourses: mentor.ExpertiseCourses,
+		}
+		if load.ExpertiseCourses == nil {
+			load.ExpertiseCourses = []string{}
+		}
+		for _, student := range findAssignedStudents(s.userRepo, mentor.ID) {
+			load.Students++
+			if student.RiskScore >= highThreshold {
+				load.AtRisk++
+			}
+		}
+		loads = append(loads, load)
+	}
+	return loads
+}
+
+// Backfill creates active assignments for the students listed in mentors'
+// AssignedStudents that have none yet, for data created before assignments
+// were stored on their own. Backfilled assignments have the ID
+// backfill_<mentorId>_<studentId>, so running it again, also from several
+// instances at once, creates none twice. It returns the number created.
+func (s *AssignmentService) Backfill(actorID string) (int, error) {
+	created := 0
+	for _, mentor := range findUsersByRole(s.userRepo, "mentor") {
+		for _, studentID := range mentor.AssignedStudents {
+			if s.isAssigned(mentor.ID, studentID) {
+				continue
+			}
+			assignment := &models.MentorAssignment{
+				ID:         fmt.Sprintf("backfill_%s_%s", mentor.ID, studentID),
+				MentorID:   mentor.ID,
+				StudentID:  studentID,
+				Source:     AssignmentBackfill,
+				AssignedBy: actorID,
+				Active:     true,
+				StartedAt:  time.Now(),
+			}
+			ok, err := s.create(assignment)
+			if err != nil {
+				return created, err
+			}
+			if ok {
+				created++
+			}
+		}
+	}
+	return created, nil
+}
+
+// GetConfig returns the auto-balancing configuration
+func (s *AssignmentService) GetConfig() models.AssignmentConfig {
+	// Try Firestore first
+	if s.configRepo.IsFirestoreAvailable() {
+		var config models.AssignmentConfig
+		if err := s.configRepo.Find(assignmentConfigKey, &config); err == nil {
+			if config.DefaultCapacity <= 0 {
+				config.DefaultCapacity = defaultMentorCapacity
+			}
+			return config
+		}
+		return models.AssignmentConfig{DefaultCapacity: defaultMentorCapacity}
+	}
+
+	// Fallback to mock data
+	mockAssignmentsMu.Lock()
+	defer mockAssignmentsMu.Unlock()
+	return mockAssignmentConfig
+}
+
+// UpdateConfig validates and stores the auto-balancing configuration
+func (s *AssignmentService) UpdateConfig(config models.AssignmentConfig) (*models.AssignmentConfig, error) {
+	if config.DefaultCapacity <= 0 {
+		return nil, fmt.Errorf("%w: defaultCapacity must be positive", ErrInvalidAssignment)
+	}
+	config.UpdatedAt = time.Now()
+
+	// Try Firestore first
+	if s.configRepo.IsFirestoreAvailable() {
+		if err := s.configRepo.Save(assignmentConfigKey, config); err != nil {
+			return nil, err
+		}
+		return &config, nil
+	}
+
+	// Fallback to mock data
+	mockAssignmentsMu.Lock()
+	mockAssignmentConfig = config
+	mockAssignmentsMu.Unlock()
+	return &config, nil
+}
+
+// UpdateMentorProfile sets a mentor's capacity and course expertise used by
+// auto-balancing
+func (s *AssignmentService) UpdateMentorProfile(mentorID string, req models.MentorProfileRequest) (*models.User, error) {
+	mentor, err := s.authService.GetUserByID(mentorID)
+	if err != nil || mentor.Role != "mentor" {
+		return nil, fmt.Errorf("%w: mentor %q not found", ErrInvalidAssignment, mentorID)
+	}
+	if req.Capacity != nil && *req.Capacity < 0 {
+		return nil, fmt.Errorf("%w: capacity cannot be negative", ErrInvalidAssignment)
+	}
+
+	fields := make(map[string]interface{})
+	if req.Capacity != nil {
+		mentor.MentorCapacity = *req.Capacity
+		fields["mentorCapacity"] = *req.Capacity
+	}
+	if req.ExpertiseCourses != nil {
+		mentor.ExpertiseCourses = req.ExpertiseCourses
+		fields["expertiseCourses"] = req.ExpertiseCourses
+	}
+
+	// Try Firestore first
+	if s.userRepo.IsFirestoreAvailable() {
+		if err := s.userRepo.UpdateFields(mentor.ID, fields); err != nil {
+			return nil, err
+		}
+		return mentor, nil
+	}
+
+	// Fallback to mock data
+	users := GetMockUsers()
+	for i := range users {
+		if users[i].ID == mentor.ID {
+			users[i].MentorCapacity = mentor.MentorCapacity
+			users[i].ExpertiseCourses = mentor.ExpertiseCourses
+		}
+	}
+	return mentor, nil
+}
+
+// start creates an active assignment for a student without one, or replaces
+// ending, the student's active assignment, in the same write. It tells
+// everyone involved.
+func (s *AssignmentService) start(mentor, student *models.User, source, actorID, note string, ending *models.MentorAssignment) (*models.MentorAssignment, error) {
+	if s.isAssigned(mentor.ID, student.ID) {
+		return nil, ErrAlreadyAssigned
+	}
+
+	assignment := &models.MentorAssignment{
+		MentorID:   mentor.ID,
+		StudentID:  student.ID,
+		Source:     source,
+		AssignedBy: actorID,
+		Note:       note,
+		Active:     true,
+		StartedAt:  time.Now(),
+	}
+	started, err := s.startAssignment(assignment, ending)
+	if err != nil {
+		return nil, err
+	}
+	if !started && ending != nil {
+		return nil, ErrAssignmentChanged
+	}
+	if !started {
+		return nil, ErrStudentHasMentor
+	}
+	s.syncAssignedStudents(mentor.ID)
+	if ending != nil {
+		s.syncAssignedStudents(ending.MentorID)
+		s.notifyEnded(ending)
+	}
+
+	s.notificationService.Notify(student.ID, CategoryMentorMessages, "info", "Mentor Baru",
+		fmt.Sprintf("%s sekarang menjadi mentor Anda. Kirim pesan untuk saling mengenal.", mentor.Name))
+	s.notificationService.Notify(mentor.ID, CategoryMentorMessages, "info", "Mentee Baru",
+		fmt.Sprintf("%s sekarang menjadi mentee Anda", student.Name))
+	return assignment, nil
+}
+
+// end closes an active assignment and tells both sides
+func (s *AssignmentService) end(assignment *models.MentorAssignment, actorID string) error {
+	now := time.Now()
+	assignment.Active = false
+	assignment.EndedAt = &now
+	assignment.EndedBy = actorID
+	if err := s.save(assignment); err != nil {
+		return err
+	}
+	s.syncAssignedStudents(assignment.MentorID)
+	s.notifyEnded(assignment)
+	return nil
+}
+
+// notifyEnded tells both sides that an assignment has ended
+func (s *AssignmentService) notifyEnded(assignment *models.MentorAssignment) {
+	mentor, errMentor := s.authService.GetUserByID(assignment.MentorID)
+	student, errStudent := s.authService.GetUserByID(assignment.StudentID)
+	if errMentor == nil && errStudent == nil {
+		s.notificationService.Notify(student.ID, CategoryMentorMessages, "info", "Pendampingan Berakhir",
+			fmt.Sprintf("%s tidak lagi menjadi mentor Anda", mentor.Name))
+		s.notificationService.Notify(mentor.ID, CategoryMentorMessages, "info", "Pendampingan Berakhir",
+			fmt.Sprintf("%s tidak lagi menjadi mentee Anda", student.Name))
+	}
+}
+
+// syncAssignedStudents mirrors the mentor's active assignments into the
+// AssignedStudents field clients read
+func (s *AssignmentService) syncAssignedStudents(mentorID string) {
+	studentIDs := []string{}
+	for _, assignment := range s.find(models.AssignmentQuery{MentorID: mentorID, ActiveOnly: true}) {
+		studentIDs = append(studentIDs, assignment.StudentID)
+	}
+	slices.Sort(studentIDs)
+
+	// Try Firestore first
+	if s.userRepo.IsFirestoreAvailable() {
+		if err := s.userRepo.UpdateFields(mentorID, map[string]interface{}{"assignedStudents": studentIDs}); err != nil {
+			log.Printf("assignment: failed to update students of mentor %s: %v", mentorID, err)
+		}
+		return
+	}
+
+	// Fallback to mock data
+	users := GetMockUsers()
+	for i := range users {
+		if users[i].ID == mentorID {
+			users[i].AssignedStudents = studentIDs
+		}
+	}
+}
+
+// actor returns the acting user, who must be an admin or mentor
+func (s *AssignmentService) actor(actorID string) (*models.User, error) {
+	actor, err := s.authService.GetUserByID(actorID)
+	if err != nil || (actor.Role != "admin" && actor.Role != "mentor") {
+		return nil, ErrAssignmentForbidden
+	}
+	return actor, nil
+}
+
+// pair loads the mentor and student of an assignment and checks their roles
+func (s *AssignmentService) pair(mentorID, studentID string) (*models.User, *models.User, error) {
+	mentor, err := s.authService.GetUserByID(mentorID)
+	if err != nil || mentor.Role != "mentor" {
+		return nil, nil, fmt.Errorf("%w: mentor %q not found", ErrInvalidAssignment, mentorID)
+	}
+	student, err := s.authService.GetUserByID(studentID)
+	if err != nil || student.Role != "student" {
+		return nil, nil, fmt.Errorf("%w: student %q not found", ErrInvalidAssignment, studentID)
+	}
+	return mentor, student, nil
+}
+
+func (s *AssignmentService) isAssigned(mentorID, studentID string) bool {
+	return len(s.find(models.AssignmentQuery{MentorID: mentorID, StudentID: studentID, ActiveOnly: true})) > 0
+}
+
+func mentorCapacity(mentor *models.User, config models.AssignmentConfig) int {
+	if mentor.MentorCapacity > 0 {
+		return mentor.MentorCapacity
+	}
+	return config.DefaultCapacity
+}
+
+// bestMentor returns the index of the mentor with room who fits the student
+// best, or -1 when every mentor is full. Ties go to the mentor with fewer
+// students.
+func bestMentor(loads []models.MentorLoad, student *models.User) int {
+	best, bestScore := -1, 0.0
+	for i, load := range loads {
+		if load.Capacity <= 0 || load.Students >= load.Capacity {
+			continue
+		}
+
+		expertise := 0.0
+		if len(student.EnrolledCourses) > 0 {
+			matches := 0
+			for _, courseID := range student.EnrolledCourses {
+				if slices.Contains(load.ExpertiseCourses, courseID) {
+					matches++
+				}
+			}
+			expertise = float64(matches) / float64(len(student.EnrolledCourses))
+		}
+		capacity := float64(load.Capacity)
+		score := balanceExpertiseWeight*expertise +
+			balanceLoadWeight*(1-float64(load.Students)/capacity) +
+			balanceRiskWeight*(1-float64(load.AtRisk)/capacity)
+
+		if best < 0 || score > bestScore || (score == bestScore && load.Students < loads[best].Students) {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+func (s *AssignmentService) find(query models.AssignmentQuery) []models.MentorAssignment {
+	// Try Firestore first
+	if s.assignmentRepo.IsFirestoreAvailable() {
+		assignments, err := s.assignmentRepo.Find(query)
+		if err == nil {
+			if assignments == nil {
+				assignments = []models.MentorAssignment{}
+			}
+			return assignments
+		}
+	}
+
+	// Fallback to mock data
+	return findMockAssignments(query)
+}
+
+func (s *AssignmentService) findByID(assignmentID string) (*models.MentorAssignment, error) {
+	// Try Firestore first
+	if s.assignmentRepo.IsFirestoreAvailable() {
+		if assignment, err := s.assignmentRepo.FindByID(assignmentID); err == nil {
+			return assignment, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockAssignmentsMu.Lock()
+	defer mockAssignmentsMu.Unlock()
+	for _, assignment := range mockAssignments {
+		if assignment.ID == assignmentID {
+			return &assignment, nil
+		}
+	}
+	return nil, ErrAssignmentNotFound
+}
+
+// create stores an assignment with a preset ID, reporting false when it
+// already exists
+func (s *AssignmentService) create(assignment *models.MentorAssignment) (bool, error) {
+	// Try Firestore first
+	if s.assignmentRepo.IsFirestoreAvailable() {
+		return s.assignmentRepo.Create(assignment)
+	}
+
+	// Fallback to mock data
+	mockAssignmentsMu.Lock()
+	defer mockAssignmentsMu.Unlock()
+	for _, existing := range mockAssignments {
+		if existing.ID == assignment.ID {
+			return false, nil
+		}
+	}
+	mockAssignments = append(mockAssignments, *assignment)
+	return true, nil
+}
+
+// startAssignment stores a new active assignment unless the student has one,
+// or replaces ending if it is still active, in a single write
+func (s *AssignmentService) startAssignment(assignment, ending *models.MentorAssignment) (bool, error) {
+	// Try Firestore first
+	if s.assignmentRepo.IsFirestoreAvailable() {
+		return s.assignmentRepo.Start(assignment, ending)
+	}
+
+	// Fallback to mock data
+	mockAssignmentsMu.Lock()
+	defer mockAssignmentsMu.Unlock()
+	endingIndex := -1
+	for i, existing := range mockAssignments {
+		if existing.StudentID != assignment.StudentID || !existing.Active {
+			continue
+		}
+		switch {
+		case ending == nil, existing.MentorID == assignment.MentorID:
+			return false, nil
+		case existing.ID == ending.ID:
+			endingIndex = i
+		}
+	}
+	if ending != nil {
+		if endingIndex < 0 {
+			return false, nil
+		}
+		mockAssignments[endingIndex] = *ending
+	}
+	mockAssignmentSeq++
+	assignment.ID = fmt.Sprintf("%d", mockAssignmentSeq)
+	mockAssignments = append(mockAssignments, *assignment)
+	return true, nil
+}
+
+func (s *AssignmentService) save(assignment *models.MentorAssignment) error {
+	// Try Firestore first
+	if s.assignmentRepo.IsFirestoreAvailable() {
+		return s.assignmentRepo.Save(assignment)
+	}
+
+	// Fallback to mock data
+	mockAssignmentsMu.Lock()
+	defer mockAssignmentsMu.Unlock()
+	for i := range mockAssignments {
+		if mockAssignments[i].ID == assignment.ID {
+			mockAssignments[i] = *assignment
+			return nil
+		}
+	}
+	return ErrAssignmentNotFound
+}
+
+// findMockAssignments returns the mock assignments matching the query, newest
+// first
+func findMockAssignments(query models.AssignmentQuery) []models.MentorAssignment {
+	mockAssignmentsMu.Lock()
+	defer mockAssignmentsMu.Unlock()
+
+	assignments := []models.MentorAssignment{}
+	for _, assignment := range mockAssignments {
+		if query.MentorID != "" && assignment.MentorID != query.MentorID {
+			continue
+		}
+		if query.StudentID != "" && assignment.StudentID != query.StudentID {
+			continue
+		}
+		if query.ActiveOnly && !assignment.Active {
+			continue
+		}
+		assignments = append(assignments, assignment)
+	}
+	sort.SliceStable(assignments, func(i, j int) bool {
+		return assignments[i].StartedAt.After(assignments[j].StartedAt)
+	})
+	return assignments
+}
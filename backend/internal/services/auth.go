@@ -71,6 +71,15 @@ var mockUsers = []models.User{
 		AssignedStudents: []string{"1", "2"},
 		JoinedDate:       time.Now().AddDate(-2, 0, 0),
 	},
+	{
+		ID:         "6",
+		Name:       "Admin MentorSphere",
+		Email:      "admin@mentorsphere.com",
+		Password:   hashPassword("password123"),
+		Role:       "admin",
+		Avatar:     "https://api.dicebear.com/7.x/avataaars/svg?seed=Admin",
+		JoinedDate: time.Now().AddDate(-2, 0, 0),
+	},
 }
 
 var userSettings = make(map[string]models.UserSettings)
@@ -81,13 +90,15 @@ func init() {
 		userSettings[user.ID] = models.UserSettings{
 			UserID: user.ID,
 			Notifications: models.NotificationSettings{
-				Email:          true,
-				Push:           true,
-				StudyReminder:  true,
-				WeeklyReport:   true,
-				MentorMessages: true,
-				CourseUpdates:  false,
-				Promotions:     false,
+				Email:           true,
+				Push:            true,
+				StudyReminder:   true,
+				WeeklyReport:    true,
+				MentorMessages:  true,
+				CourseUpdates:   false,
+				Promotions:      false,
+				QuietHoursStart: "22:00",
+				QuietHoursEnd:   "07:00",
 			},
 			Appearance: models.AppearanceSettings{
 				Theme:    "system",
@@ -105,6 +116,7 @@ func init() {
 				ReminderTime:   "08:00",
 				AutoplayVideos: true,
 				Subtitles:      true,
+				Timezone:       defaultTimezone,
 			},
 		}
 	}
@@ -176,7 +188,6 @@ func (s *AuthService) Register(req models.RegisterRequest) (*models.User, error)
 		EnrolledCourses:  []string{},
 		TotalStudyTime:   0,
 		CompletedModules: 0,
-		RiskScore:        50,
 		JoinedDate:       time.Now(),
 	}
 
@@ -196,11 +207,13 @@ func (s *AuthService) Register(req models.RegisterRequest) (*models.User, error)
 		userSettings[newUser.ID] = models.UserSettings{
 			UserID: newUser.ID,
 			Notifications: models.NotificationSettings{
-				Email:          true,
-				Push:           true,
-				StudyReminder:  true,
-				WeeklyReport:   true,
-				MentorMessages: true,
+				Email:           true,
+				Push:            true,
+				StudyReminder:   true,
+				WeeklyReport:    true,
+				MentorMessages:  true,
+				QuietHoursStart: "22:00",
+				QuietHoursEnd:   "07:00",
 			},
 			Appearance: models.AppearanceSettings{
 				Theme:    "system",
@@ -218,6 +231,7 @@ func (s *AuthService) Register(req models.RegisterRequest) (*models.User, error)
 				ReminderTime:   "08:00",
 				AutoplayVideos: true,
 				Subtitles:      true,
+				Timezone:       defaultTimezone,
 			},
 		}
 	}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/config"
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+	"mentorsphere-api/pkg/pdf"
+)
+
+// Certificates for development (used as fallback when Firebase is not configured)
+var (
+	mockCertificates   []models.Certificate
+	mockCertificatesMu sync.Mutex
+)
+
+type CertificateService struct {
+	certificateRepo *repository.CertificateRepository
+	courseService   *CourseService
+	authService     *AuthService
+	privateKey      ed25519.PrivateKey
+	publicURL       string
+}
+
+func NewCertificateService(cfg *config.Config) *CertificateService {
+	privateKey, _, err := certificateSigningKey(cfg)
+	if err != nil {
+		// Unreachable once CheckCertificateSigningKey has passed at startup
+		log.Fatalf("Invalid CERTIFICATE_SIGNING_KEY: %v", err)
+	}
+
+	return &CertificateService{
+		certificateRepo: repository.NewCertificateRepository(),
+		courseService:   NewCourseService(),
+		authService:     NewAuthService(),
+		privateKey:      privateKey,
+		publicURL:       strings.TrimRight(cfg.PublicURL, "/"),
+	}
+}
+
+// CheckCertificateSigningKey reports a signing key that is set but invalid,
+// or missing outside development, so the server refuses to start with it
+func CheckCertificateSigningKey(cfg *config.Config) error {
+	_, derived, err := certificateSigningKey(cfg)
+	if err != nil {
+		return err
+	}
+	if derived {
+		log.Println("WARNING: CERTIFICATE_SIGNING_KEY is not set; certificates are signed with a development key derived from JWT_SECRET")
+	}
+	return nil
+}
+
+// certificateSigningKey loads the configured Ed25519 seed. Only in the
+// development environment does a missing seed fall back to one derived from
+// the JWT secret, so local setups still produce stable signatures.
+func certificateSigningKey(cfg *config.Config) (ed25519.PrivateKey, bool, error) {
+	if cfg.CertificateSigningKey != "" {
+		seed, err := base64.StdEncoding.DecodeString(cfg.CertificateSigningKey)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, false, fmt.Errorf("must be a base64-encoded %d-byte Ed25519 seed", ed25519.SeedSize)
+		}
+		return ed25519.NewKeyFromSeed(seed), false, nil
+	}
+	if cfg.Environment != "development" {
+		return nil, false, fmt.Errorf("must be set when ENVIRONMENT is %q", cfg.Environment)
+	}
+
+	seed := sha256.Sum256([]byte("certificate-signing:" + cfg.JWTSecret))
+	return ed25519.NewKeyFromSeed(seed[:]), true, nil
+}
+
+// PublicKey returns the base64-encoded key that verifies certificate signatures
+func (s *CertificateService) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(s.privateKey.Public().(ed25519.PublicKey))
+}
+
+// IssueEligible issues certificates for every enrolled course the user has
+// completed, judged by their own module progress and quiz scores, and that
+// has no certificate yet. Issuing is idempotent: a course already certified,
+// also by a concurrent call, is skipped.
+func (s *CertificateService) IssueEligible(userID string) ([]models.Certificate, error) {
+	user, err := s.authService.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := make(map[string]bool)
+	for _, certificate := range findUserCertificates(s.certificateRepo, userID) {
+		issued[certificate.CourseID] = true
+	}
+
+	newCertificates := []models.Certificate{}
+	for _, course := range s.courseService.GetUserCourses(userID) {
+		if issued[course.ID] || !courseCompleted(course) {
+			continue
+		}
+
+		certificate, err := s.issue(user, course)
+		if err != nil {
+			return newCertificates, err
+		}
+		if certificate != nil {
+			newCertificates = append(newCertificates, *certificate)
+		}
+	}
+
+	return newCertificates, nil
+}
+
+// GetUserCertificates lists the certificates issued to the user
+func (s *CertificateService) GetUserCertificates(userID string) []models.Certificate {
+	return findUserCertificates(s.certificateRepo, userID)
+}
+
+// GetUserCertificate returns one of the user's certificates by serial
+func (s *CertificateService) GetUserCertificate(userID, serial string) (*models.Certificate, error) {
+	certificate, err := s.findBySerial(serial)
+	if err != nil || certificate.UserID != userID {
+		return nil, fmt.Errorf("certificate not found")
+	}
+	return certificate, nil
+}
+
+// Verify checks that a serial exists and that its stored record carries a valid signature
+func (s *CertificateService) Verify(serial string) *models.CertificateVerification {
+	result := &models.CertificateVerification{Serial: serial}
+
+	certificate, err := s.findBySerial(serial)
+	if err != nil {
+		result.Reason = "certificate not found"
+		return result
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(certificate.Signature)
+	if err != nil || !ed25519.Verify(s.privateKey.Public().(ed25519.PublicKey), certificatePayload(certificate), signature) {
+		result.Reason = "signature mismatch"
+		return result
+	}
+
+	issuedAt := certificate.IssuedAt
+	result.Valid = true
+	result.UserName = certificate.UserName
+	result.CourseTitle = certificate.CourseTitle
+	result.IssuedAt = &issuedAt
+	return result
+}
+
+// RenderPDF renders a certificate as a one-page landscape PDF
+func (s *CertificateService) RenderPDF(certificate *models.Certificate) []byte {
+	doc := pdf.New(pdf.A4LandscapeWidth, pdf.A4LandscapeHeight)
+	doc.SetTitle("Sertifikat " + certificate.Serial)
+
+	doc.Rect(30, 30, pdf.A4LandscapeWidth-60, pdf.A4LandscapeHeight-60, 3)
+	doc.Rect(40, 40, pdf.A4LandscapeWidth-80, pdf.A4LandscapeHeight-80, 0.75)
+
+	doc.TextCentered(480, 14, true, "MENTORSPHERE")
+	doc.TextCentered(430, 30, true, "SERTIFIKAT PENYELESAIAN")
+	doc.TextCentered(390, 13, false, "Diberikan kepada")
+	doc.TextCentered(340, 28, true, certificate.UserName)
+	doc.Line(220, 328, pdf.A4LandscapeWidth-220, 328, 0.75)
+	doc.TextCentered(295, 13, false, "atas keberhasilan menyelesaikan seluruh modul dan kuis pada kursus")
+	doc.TextCentered(260, 20, true, certificate.CourseTitle)
+	doc.TextCentered(215, 12, false, "Diterbitkan pada "+certificate.IssuedAt.Format("02 January 2006"))
+
+	doc.Text(60, 120, 10, true, "Nomor seri: "+certificate.Serial)
+	doc.Text(60, 104, 9, false, "Verifikasi: "+s.verificationURL(certificate.Serial))
+	doc.Text(60, 88, 7, false, "Tanda tangan digital (Ed25519): "+certificate.Signature)
+
+	return doc.Bytes()
+}
+
+// issue signs and stores the user's certificate for a course. It returns nil
+// when the user already has one.
+func (s *CertificateService) issue(user *models.User, course models.Course) (*models.Certificate, error) {
+	serial, err := newCertificateSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	certificate := &models.Certificate{
+		Serial:      serial,
+		UserID:      user.ID,
+		UserName:    user.Name,
+		CourseID:    course.ID,
+		CourseTitle: course.Title,
+		IssuedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+	certificate.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, certificatePayload(certificate)))
+
+	// Try Firestore first
+	if s.certificateRepo.IsFirestoreAvailable() {
+		created, err := s.certificateRepo.Create(certificate)
+		if err != nil || !created {
+			return nil, err
+		}
+		return certificate, nil
+	}
+
+	// Fallback to mock data
+	mockCertificatesMu.Lock()
+	defer mockCertificatesMu.Unlock()
+	certificate.ID = user.ID + "_" + course.ID
+	for _, existing := range mockCertificates {
+		if existing.ID == certificate.ID {
+			return nil, nil
+		}
+	}
+	mockCertificates = append(mockCertificates, *certificate)
+	return certificate, nil
+}
+
+func (s *CertificateService) findBySerial(serial string) (*models.Certificate, error) {
+	// Try Firestore first
+	if s.certificateRepo.IsFirestoreAvailable() {
+		return s.certificateRepo.FindBySerial(serial)
+	}
+
+	// Fallback to mock data
+	mockCertificatesMu.Lock()
+	defer mockCertificatesMu.Unlock()
+	for _, certificate := range mockCertificates {
+		if certificate.Serial == serial {
+			return &certificate, nil
+		}
+	}
+	return nil, fmt.Errorf("certificate not found")
+}
+
+func (s *CertificateService) verificationURL(serial string) string {
+	return s.publicURL + "/api/certificates/verify/" + serial
+}
+
+// findUserCertificates lists certificates issued to a user
+func findUserCertificates(certificateRepo *repository.CertificateRepository, userID string) []models.Certificate {
+	// Try Firestore first
+	if certificateRepo.IsFirestoreAvailable() {
+		certificates, err := certificateRepo.FindByUserID(userID)
+		if err == nil {
+			return certificates
+		}
+	}
+
+	// Fallback to mock data
+	mockCertificatesMu.Lock()
+	defer mockCertificatesMu.Unlock()
+	certificates := []models.Certificate{}
+	for _, certificate := range mockCertificates {
+		if certificate.UserID == userID {
+			certificates = append(certificates, certificate)
+		}
+	}
+	return certificates
+}
+
+// courseCompleted reports whether every module is completed and every quiz passed
+func courseCompleted(course models.Course) bool {
+	if len(course.Modules) == 0 {
+		return false
+	}
+	for _, module := range course.Modules {
+		if module.Status != ModuleCompleted {
+			return false
+		}
+		if module.Type == "quiz" && (module.Score == nil || *module.Score < quizPassingScore) {
+			return false
+		}
+	}
+	return true
+}
+
+// certificatePayload is the canonical byte string covered by the signature
+func certificatePayload(certificate *models.Certificate) []byte {
+	return []byte(strings.Join([]string{
+		certificate.Serial,
+		certificate.UserID,
+		certificate.UserName,
+		certificate.CourseID,
+		certificate.CourseTitle,
+		certificate.IssuedAt.UTC().Format(time.RFC3339),
+	}, "|"))
+}
+
+func newCertificateSerial() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate serial: %w", err)
+	}
+	return fmt.Sprintf("MS-%d-%s", time.Now().Year(), strings.ToUpper(hex.EncodeToString(buf))), nil
+}
@@ -1,10 +1,27 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/events"
 	"mentorsphere-api/internal/models"
 	"mentorsphere-api/internal/repository"
 )
 
+// Module statuses of a student. Modules the student has not started are
+// available once their prerequisites are completed and locked before.
+const (
+	ModuleLocked     = "locked"
+	ModuleAvailable  = "available"
+	ModuleInProgress = "in-progress"
+	ModuleCompleted  = "completed"
+)
+
 var mockCourses = []models.Course{
 	{
 		ID:               "1",
@@ -19,12 +36,12 @@ var mockCourses = []models.Course{
 		Category:         "Data Science",
 		Level:            "Beginner",
 		Modules: []models.Module{
-			{ID: 1, Title: "Pengenalan Machine Learning", Duration: 45, Status: "completed", Type: "video"},
-			{ID: 2, Title: "Supervised vs Unsupervised Learning", Duration: 60, Status: "completed", Type: "video"},
-			{ID: 3, Title: "Linear Regression", Duration: 90, Status: "completed", Type: "reading"},
-			{ID: 4, Title: "Quiz: Konsep Dasar", Duration: 30, Status: "completed", Type: "quiz", Score: intPtr(85)},
-			{ID: 5, Title: "Decision Trees", Duration: 75, Status: "in-progress", Type: "video"},
-			{ID: 6, Title: "Random Forest", Duration: 60, Status: "locked", Type: "video"},
+			{ID: 1, Title: "Pengenalan Machine Learning", Duration: 45, Status: ModuleCompleted, Type: "video"},
+			{ID: 2, Title: "Supervised vs Unsupervised Learning", Duration: 60, Status: ModuleCompleted, Type: "video"},
+			{ID: 3, Title: "Linear Regression", Duration: 90, Status: ModuleCompleted, Type: "reading"},
+			{ID: 4, Title: "Quiz: Konsep Dasar", Duration: 30, Status: ModuleCompleted, Type: "quiz", Score: intPtr(85)},
+			{ID: 5, Title: "Decision Trees", Duration: 75, Status: ModuleInProgress, Type: "video"},
+			{ID: 6, Title: "Random Forest", Duration: 60, Status: ModuleLocked, Type: "video"},
 		},
 	},
 	{
@@ -40,11 +57,11 @@ var mockCourses = []models.Course{
 		Category:         "Web Development",
 		Level:            "Intermediate",
 		Modules: []models.Module{
-			{ID: 1, Title: "Pengenalan React", Duration: 45, Status: "completed", Type: "video"},
-			{ID: 2, Title: "JSX dan Components", Duration: 60, Status: "completed", Type: "video"},
-			{ID: 3, Title: "State dan Props", Duration: 90, Status: "completed", Type: "reading"},
-			{ID: 4, Title: "Quiz: React Basics", Duration: 30, Status: "completed", Type: "quiz", Score: intPtr(92)},
-			{ID: 5, Title: "Hooks: useState & useEffect", Duration: 75, Status: "in-progress", Type: "video"},
+			{ID: 1, Title: "Pengenalan React", Duration: 45, Status: ModuleCompleted, Type: "video"},
+			{ID: 2, Title: "JSX dan Components", Duration: 60, Status: ModuleCompleted, Type: "video"},
+			{ID: 3, Title: "State dan Props", Duration: 90, Status: ModuleCompleted, Type: "reading"},
+			{ID: 4, Title: "Quiz: React Basics", Duration: 30, Status: ModuleCompleted, Type: "quiz", Score: intPtr(92)},
+			{ID: 5, Title: "Hooks: useState & useEffect", Duration: 75, Status: ModuleInProgress, Type: "video", DueDate: timePtr(time.Now().AddDate(0, 0, -2))},
 		},
 	},
 	{
@@ -60,27 +77,106 @@ var mockCourses = []models.Course{
 		Category:         "Data Science",
 		Level:            "Beginner",
 		Modules: []models.Module{
-			{ID: 1, Title: "Pengenalan Python untuk Data", Duration: 60, Status: "completed", Type: "video"},
-			{ID: 2, Title: "NumPy Fundamentals", Duration: 75, Status: "completed", Type: "video"},
-			{ID: 3, Title: "Pandas DataFrame", Duration: 90, Status: "in-progress", Type: "reading"},
-			{ID: 4, Title: "Data Visualization", Duration: 60, Status: "locked", Type: "video"},
+			{ID: 1, Title: "Pengenalan Python untuk Data", Duration: 60, Status: ModuleCompleted, Type: "video"},
+			{ID: 2, Title: "NumPy Fundamentals", Duration: 75, Status: ModuleCompleted, Type: "video"},
+			{ID: 3, Title: "Pandas DataFrame", Duration: 90, Status: ModuleInProgress, Type: "reading", DueDate: timePtr(time.Now().AddDate(0, 0, 5))},
+			{ID: 4, Title: "Data Visualization", Duration: 60, Status: ModuleLocked, Type: "video"},
+		},
+	},
+	{
+		ID:            "4",
+		Title:         "Deep Learning dan Neural Networks",
+		Description:   "Bangun dan latih neural network untuk computer vision dan NLP menggunakan TensorFlow.",
+		Instructor:    "Dr. Hendra Kusuma",
+		Thumbnail:     "https://images.unsplash.com/photo-1677442136019-21780ecad995?w=400",
+		Duration:      "10 minggu",
+		TotalModules:  18,
+		Category:      "Data Science",
+		Level:         "Advanced",
+		Prerequisites: []string{"1"},
+		Modules: []models.Module{
+			{ID: 1, Title: "Perceptron dan Backpropagation", Duration: 60, Status: ModuleLocked, Type: "video"},
+			{ID: 2, Title: "Convolutional Neural Networks", Duration: 90, Status: ModuleLocked, Type: "video"},
+			{ID: 3, Title: "Quiz: Neural Networks", Duration: 30, Status: ModuleLocked, Type: "quiz"},
+		},
+	},
+	{
+		ID:            "5",
+		Title:         "Backend API dengan Node.js",
+		Description:   "Rancang REST API yang aman dan teruji dengan Node.js, Express, dan database relasional.",
+		Instructor:    "Prof. Maria Tan",
+		Thumbnail:     "https://images.unsplash.com/photo-1627398242454-45a1465c2479?w=400",
+		Duration:      "8 minggu",
+		TotalModules:  14,
+		Category:      "Web Development",
+		Level:         "Intermediate",
+		Prerequisites: []string{"2"},
+		Modules: []models.Module{
+			{ID: 1, Title: "Express Routing", Duration: 45, Status: ModuleLocked, Type: "video"},
+			{ID: 2, Title: "Autentikasi dengan JWT", Duration: 60, Status: ModuleLocked, Type: "reading"},
+			{ID: 3, Title: "Quiz: REST API", Duration: 30, Status: ModuleLocked, Type: "quiz"},
 		},
 	},
 }
 
+// Minimum score for a quiz module to count as passed
+const quizPassingScore = 70
+
+var (
+	ErrCourseNotFound        = errors.New("Course tidak ditemukan")
+	ErrModuleNotFound        = errors.New("Modul tidak ditemukan")
+	ErrCourseNotEnrolled     = errors.New("Anda tidak terdaftar di course ini")
+	ErrModuleLocked          = errors.New("Modul masih terkunci")
+	ErrInvalidModuleProgress = errors.New("invalid module progress")
+)
+
+// Students' module progress for development (used as fallback when Firebase
+// is not configured), seeded from the demo statuses of the mock catalog
+var (
+	mockModuleProgress   = seedModuleProgress()
+	mockModuleProgressMu sync.Mutex
+)
+
+func seedModuleProgress() map[string][]models.ModuleProgress {
+	progress := make(map[string][]models.ModuleProgress)
+	for _, user := range mockUsers {
+		for _, course := range mockCourses {
+			if !slices.Contains(user.EnrolledCourses, course.ID) {
+				continue
+			}
+			for _, module := range course.Modules {
+				if module.Status != ModuleInProgress && module.Status != ModuleCompleted {
+					continue
+				}
+				progress[user.ID] = append(progress[user.ID], models.ModuleProgress{
+					ID:       moduleProgressID(user.ID, course.ID, module.ID),
+					UserID:   user.ID,
+					CourseID: course.ID,
+					ModuleID: module.ID,
+					Status:   module.Status,
+					Score:    module.Score,
+				})
+			}
+		}
+	}
+	return progress
+}
+
 func intPtr(i int) *int {
 	return &i
 }
 
 type CourseService struct {
-	courseRepo *repository.CourseRepository
-	userRepo   *repository.UserRepository
+	courseRepo   *repository.CourseRepository
+	userRepo     *repository.UserRepository
+	progressRepo *repository.ModuleProgressRepository
 }
 
 func NewCourseService() *CourseService {
 	return &CourseService{
-		courseRepo: repository.NewCourseRepository(),
-		userRepo:   repository.NewUserRepository(),
+		courseRepo:   repository.NewCourseRepository(),
+		userRepo:     repository.NewUserRepository(),
+		progressRepo: repository.NewModuleProgressRepository(),
 	}
 }
 
@@ -115,19 +211,27 @@ func (s *CourseService) GetByID(courseID string) *models.Course {
 	return nil
 }
 
-func (s *CourseService) GetModules(courseID string) []models.Module {
-	course := s.GetByID(courseID)
+// GetModules returns a course's modules with the user's statuses and scores
+func (s *CourseService) GetModules(userID, courseID string) []models.Module {
+	course := s.GetUserCourse(userID, courseID)
 	if course != nil {
 		return course.Modules
 	}
 	return nil
 }
 
+// GetUserCourses returns the user's enrolled courses with their own module
+// statuses, scores and progress
 func (s *CourseService) GetUserCourses(userID string) []models.Course {
+	progress := s.moduleProgress(userID)
+
 	// Try Firestore first
 	if s.courseRepo.IsFirestoreAvailable() && s.userRepo.IsFirestoreAvailable() {
 		courses, err := s.courseRepo.FindByUserID(userID, s.userRepo)
 		if err == nil {
+			for i := range courses {
+				courses[i] = applyModuleProgress(courses[i], progress)
+			}
 			return courses
 		}
 	}
@@ -143,7 +247,7 @@ func (s *CourseService) GetUserCourses(userID string) []models.Course {
 	for _, course := range mockCourses {
 		for _, enrolledID := range user.EnrolledCourses {
 			if course.ID == enrolledID {
-				enrolledCourses = append(enrolledCourses, course)
+				enrolledCourses = append(enrolledCourses, applyModuleProgress(course, progress))
 				break
 			}
 		}
@@ -151,11 +255,204 @@ func (s *CourseService) GetUserCourses(userID string) []models.Course {
 	return enrolledCourses
 }
 
-func (s *CourseService) GetQuizSummary(courseID string) *models.QuizSummary {
+// GetAllForUser returns the catalog with the user's module statuses and progress
+func (s *CourseService) GetAllForUser(userID string) []models.Course {
+	progress := s.moduleProgress(userID)
+	catalog := s.GetAll()
+	courses := make([]models.Course, 0, len(catalog))
+	for _, course := range catalog {
+		courses = append(courses, applyModuleProgress(course, progress))
+	}
+	return courses
+}
+
+// GetUserCourse returns a course with the user's module statuses and progress
+func (s *CourseService) GetUserCourse(userID, courseID string) *models.Course {
 	course := s.GetByID(courseID)
 	if course == nil {
 		return nil
 	}
+	applied := applyModuleProgress(*course, s.moduleProgress(userID))
+	return &applied
+}
+
+// UpdateModuleStatus records a student's progress on a module of a course
+// they are enrolled in, and streams their new course progress to them and
+// their mentors. Students start or complete modules whose prerequisites are
+// completed; a completed module stays completed, and quizzes are completed
+// with a score, which a retake replaces.
+func (s *CourseService) UpdateModuleStatus(userID, courseID, moduleID string, req models.UpdateModuleStatusRequest) (*models.ModuleProgressUpdate, error) {
+	if req.Status != ModuleInProgress && req.Status != ModuleCompleted {
+		return nil, fmt.Errorf("%w: status must be in-progress or completed", ErrInvalidModuleProgress)
+	}
+	id, err := strconv.Atoi(moduleID)
+	if err != nil {
+		return nil, ErrModuleNotFound
+	}
+
+	user, err := NewAuthService().GetUserByID(userID)
+	if err != nil {
+		return nil, ErrCourseNotEnrolled
+	}
+	catalog := s.GetByID(courseID)
+	if catalog == nil {
+		return nil, ErrCourseNotFound
+	}
+	if !slices.Contains(user.EnrolledCourses, catalog.ID) {
+		return nil, ErrCourseNotEnrolled
+	}
+
+	progress := s.moduleProgress(userID)
+	course := applyModuleProgress(*catalog, progress)
+	index := slices.IndexFunc(course.Modules, func(m models.Module) bool { return m.ID == id })
+	if index < 0 {
+		return nil, ErrModuleNotFound
+	}
+	module := course.Modules[index]
+
+	switch {
+	case module.Status == ModuleLocked:
+		return nil, ErrModuleLocked
+	case module.Status == ModuleCompleted && req.Status == ModuleInProgress:
+		return nil, fmt.Errorf("%w: a completed module cannot go back to in-progress", ErrInvalidModuleProgress)
+	case module.Type != "quiz" && req.Score != nil:
+		return nil, fmt.Errorf("%w: only quizzes have a score", ErrInvalidModuleProgress)
+	case module.Type == "quiz" && req.Status == ModuleCompleted && req.Score == nil:
+		return nil, fmt.Errorf("%w: a quiz is completed with a score", ErrInvalidModuleProgress)
+	case req.Status == ModuleInProgress && req.Score != nil:
+		return nil, fmt.Errorf("%w: a score is only recorded when completing a quiz", ErrInvalidModuleProgress)
+	case req.Score != nil && (*req.Score < 0 || *req.Score > 100):
+		return nil, fmt.Errorf("%w: score must be between 0 and 100", ErrInvalidModuleProgress)
+	}
+
+	now := time.Now()
+	record := progress[moduleKey(catalog.ID, id)]
+	record.UserID = userID
+	record.CourseID = catalog.ID
+	record.ModuleID = id
+	record.Status = req.Status
+	record.Score = req.Score
+	record.UpdatedAt = now
+	if req.Status == ModuleCompleted && record.CompletedAt == nil {
+		record.CompletedAt = &now
+	}
+	if err := s.saveModuleProgress(&record); err != nil {
+		return nil, err
+	}
+
+	progress[moduleKey(record.CourseID, record.ModuleID)] = record
+	course = applyModuleProgress(*catalog, progress)
+	update := &models.ModuleProgressUpdate{
+		ModuleProgress:   record,
+		Progress:         course.Progress,
+		CompletedModules: course.CompletedModules,
+		TotalModules:     course.TotalModules,
+	}
+
+	userIDs := []string{userID}
+	for _, mentor := range findMentorsOfStudent(s.userRepo, userID) {
+		userIDs = append(userIDs, mentor.ID)
+	}
+	events.Publish(events.TypeProgress, update, userIDs...)
+
+	return update, nil
+}
+
+// GetModuleProgress returns the user's module progress records
+func (s *CourseService) GetModuleProgress(userID string) []models.ModuleProgress {
+	records := []models.ModuleProgress{}
+	for _, record := range s.moduleProgress(userID) {
+		records = append(records, record)
+	}
+	return records
+}
+
+// moduleProgress returns the user's progress records by course and module
+func (s *CourseService) moduleProgress(userID string) map[string]models.ModuleProgress {
+	progress := make(map[string]models.ModuleProgress)
+
+	// Try Firestore first
+	if s.progressRepo.IsFirestoreAvailable() {
+		records, err := s.progressRepo.FindByUserID(userID)
+		if err == nil {
+			for _, record := range records {
+				progress[moduleKey(record.CourseID, record.ModuleID)] = record
+			}
+			return progress
+		}
+	}
+
+	// Fallback to mock data
+	mockModuleProgressMu.Lock()
+	defer mockModuleProgressMu.Unlock()
+	for _, record := range mockModuleProgress[userID] {
+		progress[moduleKey(record.CourseID, record.ModuleID)] = record
+	}
+	return progress
+}
+
+func (s *CourseService) saveModuleProgress(record *models.ModuleProgress) error {
+	// Try Firestore first
+	if s.progressRepo.IsFirestoreAvailable() {
+		return s.progressRepo.Save(record)
+	}
+
+	// Fallback to mock data
+	mockModuleProgressMu.Lock()
+	defer mockModuleProgressMu.Unlock()
+	record.ID = moduleProgressID(record.UserID, record.CourseID, record.ModuleID)
+	records := slices.DeleteFunc(mockModuleProgress[record.UserID], func(r models.ModuleProgress) bool {
+		return r.ID == record.ID
+	})
+	mockModuleProgress[record.UserID] = append(records, *record)
+	return nil
+}
+
+func moduleProgressID(userID, courseID string, moduleID int) string {
+	return userID + "_" + moduleKey(courseID, moduleID)
+}
+
+func moduleKey(courseID string, moduleID int) string {
+	return fmt.Sprintf("%s_%d", courseID, moduleID)
+}
+
+// applyModuleProgress returns the catalog course with the student's module
+// statuses and scores, and their completed modules and progress
+func applyModuleProgress(course models.Course, progress map[string]models.ModuleProgress) models.Course {
+	modules := make([]models.Module, len(course.Modules))
+	completed := make(map[int]bool)
+	for i, module := range course.Modules {
+		module.Status, module.Score = "", nil
+		if record, ok := progress[moduleKey(course.ID, module.ID)]; ok {
+			module.Status, module.Score = record.Status, record.Score
+		}
+		if module.Status == ModuleCompleted {
+			completed[module.ID] = true
+		}
+		modules[i] = module
+	}
+	for i := range modules {
+		if modules[i].Status != "" {
+			continue
+		}
+		modules[i].Status = ModuleLocked
+		if modulePrerequisitesMet(modules, i, completed) {
+			modules[i].Status = ModuleAvailable
+		}
+	}
+
+	course.Modules = modules
+	course.TotalModules = len(modules)
+	course.CompletedModules = len(completed)
+	course.Progress = percent(len(completed), len(modules))
+	return course
+}
+
+func (s *CourseService) GetQuizSummary(userID, courseID string) *models.QuizSummary {
+	course := s.GetUserCourse(userID, courseID)
+	if course == nil {
+		return nil
+	}
 
 	var quizzes []models.QuizDTO
 	totalScore := 0
@@ -165,7 +462,7 @@ func (s *CourseService) GetQuizSummary(courseID string) *models.QuizSummary {
 		if module.Type == "quiz" {
 			passed := false
 			if module.Score != nil {
-				passed = *module.Score >= 70
+				passed = *module.Score >= quizPassingScore
 				totalScore += *module.Score
 				scoredCount++
 			}
@@ -188,7 +485,7 @@ func (s *CourseService) GetQuizSummary(courseID string) *models.QuizSummary {
 
 	completedQuizzes := 0
 	for _, q := range quizzes {
-		if q.Status == "completed" {
+		if q.Status == ModuleCompleted {
 			completedQuizzes++
 		}
 	}
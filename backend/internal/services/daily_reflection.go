@@ -0,0 +1,302 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/llm"
+	"mentorsphere-api/internal/models"
+)
+
+const (
+	// Days before the reflected date used as the comparison baseline
+	reflectionBaselineDays = 7
+	// Percentage change treated as a meaningful shift from the baseline
+	reflectionChangeThreshold = 10
+	// Share of the day's minutes on one course that counts as focused study
+	reflectionFocusShare = 70
+)
+
+// Daily reflections for development (used as fallback when Firebase is not configured)
+var (
+	mockDailyReflections   = make(map[string]models.DailyReflection)
+	mockDailyReflectionsMu sync.Mutex
+)
+
+// GetDailyReflection returns the reflection for a date (YYYY-MM-DD, default
+// today). Past dates are served from storage and computed once if missing;
+// today is recomputed because activity is still coming in.
+func (s *ReflectionService) GetDailyReflection(userID string, date string) (*models.DailyReflection, error) {
+	loc := userLocation(loadUserSettings(s.settingsRepo, userID))
+	today := startOfDay(time.Now(), loc)
+
+	day := today
+	if date != "" {
+		parsed, err := time.ParseInLocation(dateLayout, date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date, expected YYYY-MM-DD")
+		}
+		day = parsed
+	}
+	if day.After(today) {
+		return nil, fmt.Errorf("cannot reflect on a future date")
+	}
+
+	if day.Before(today) {
+		if daily := s.findDailyReflection(userID, day.Format(dateLayout)); daily != nil {
+			return daily, nil
+		}
+	}
+
+	return s.GenerateDailyReflection(userID, day), nil
+}
+
+// GenerateDailyReflection computes the reflection for the local day starting
+// at day from the user's activity and stores it
+func (s *ReflectionService) GenerateDailyReflection(userID string, day time.Time) *models.DailyReflection {
+	settings := loadUserSettings(s.settingsRepo, userID)
+	loc := userLocation(settings)
+	start := startOfDay(day, loc)
+	end := start.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	activities := findUserActivitiesBetween(s.activityRepo, userID, start, end)
+	baseline := findUserActivitiesBetween(s.activityRepo, userID, start.AddDate(0, 0, -reflectionBaselineDays), start.Add(-time.Nanosecond))
+
+	daily := s.buildDailyReflection(activities, baseline, settings.Learning.DailyGoal)
+	daily.UserID = userID
+	daily.Date = start.Format(dateLayout)
+	daily.GeneratedAt = time.Now()
+	daily.Summary, daily.SummarySource = generateDailySummary(daily, settings)
+
+	s.saveDailyReflection(daily)
+	return daily
+}
+
+func (s *ReflectionService) buildDailyReflection(activities, baseline []models.ActivityLog, dailyGoal int) *models.DailyReflection {
+	daily := &models.DailyReflection{
+		MinutesByCourse: []models.CourseMinutes{},
+		MinutesByType:   make(map[string]int),
+		QuizResults:     []models.QuizResult{},
+		ActivityCount:   len(activities),
+	}
+
+	byCourse := make(map[string]int)
+	for _, activity := range activities {
+		daily.TotalMinutes += activity.Duration
+		daily.MinutesByType[activity.Type] += activity.Duration
+		byCourse[activity.CourseID] += activity.Duration
+		if activity.Type == "quiz" && activity.Score != nil {
+			daily.QuizResults = append(daily.QuizResults, models.QuizResult{
+				Title:    activity.Title,
+				CourseID: activity.CourseID,
+				Score:    *activity.Score,
+				Passed:   *activity.Score >= quizPassingScore,
+			})
+		}
+	}
+	for courseID, minutes := range byCourse {
+		daily.MinutesByCourse = append(daily.MinutesByCourse, models.CourseMinutes{
+			CourseID:    courseID,
+			CourseTitle: s.courseTitle(courseID),
+			Minutes:     minutes,
+		})
+	}
+	sort.Slice(daily.MinutesByCourse, func(i, j int) bool {
+		return daily.MinutesByCourse[i].Minutes > daily.MinutesByCourse[j].Minutes
+	})
+
+	baselineMinutes := 0
+	for _, activity := range baseline {
+		baselineMinutes += activity.Duration
+	}
+	daily.RecentAverage = baselineMinutes / reflectionBaselineDays
+	if daily.RecentAverage > 0 {
+		daily.ChangePercent = (daily.TotalMinutes - daily.RecentAverage) * 100 / daily.RecentAverage
+	}
+
+	daily.Strengths, daily.Improvements = dailyStrengthsAndImprovements(daily, dailyGoal)
+	daily.Mood = dailyMood(daily, dailyGoal)
+	return daily
+}
+
+func (s *ReflectionService) courseTitle(courseID string) string {
+	if course := s.courseService.GetByID(courseID); course != nil {
+		return course.Title
+	}
+	return courseID
+}
+
+func (s *ReflectionService) findDailyReflection(userID, date string) *models.DailyReflection {
+	// Try Firestore first
+	if s.dailyRepo.IsFirestoreAvailable() {
+		daily, err := s.dailyRepo.FindByUserAndDate(userID, date)
+		if err == nil {
+			return daily
+		}
+	}
+
+	// Fallback to mock data
+	mockDailyReflectionsMu.Lock()
+	defer mockDailyReflectionsMu.Unlock()
+	if daily, ok := mockDailyReflections[userID+"_"+date]; ok {
+		return &daily
+	}
+	return nil
+}
+
+func (s *ReflectionService) saveDailyReflection(daily *models.DailyReflection) {
+	// Try Firestore first
+	if s.dailyRepo.IsFirestoreAvailable() {
+		if err := s.dailyRepo.Save(daily); err == nil {
+			return
+		}
+	}
+
+	// Fallback to mock data
+	mockDailyReflectionsMu.Lock()
+	mockDailyReflections[daily.UserID+"_"+daily.Date] = *daily
+	mockDailyReflectionsMu.Unlock()
+}
+
+// generateDailySummary asks the text generator for a summary grounded in the
+// day's figures, falling back to the rule-based summary
+func generateDailySummary(daily *models.DailyReflection, settings *models.UserSettings) (string, string) {
+	summary, generated := llm.GenerateOrFallback(llm.Request{
+		Template: llm.TemplateDailyReflection,
+		Language: settings.Appearance.Language,
+		Facts: map[string]interface{}{
+			"date":            daily.Date,
+			"totalMinutes":    daily.TotalMinutes,
+			"activityCount":   daily.ActivityCount,
+			"dailyGoal":       settings.Learning.DailyGoal,
+			"minutesByCourse": daily.MinutesByCourse,
+			"minutesByType":   daily.MinutesByType,
+			"quizResults":     daily.QuizResults,
+			"recentAverage":   daily.RecentAverage,
+			"changePercent":   daily.ChangePercent,
+			"strengths":       daily.Strengths,
+			"improvements":    daily.Improvements,
+		},
+	}, dailySummary(daily))
+
+	if generated {
+		return summary, "ai"
+	}
+	return summary, "rules"
+}
+
+func dailySummary(daily *models.DailyReflection) string {
+	if daily.TotalMinutes == 0 {
+		if daily.RecentAverage > 0 {
+			return fmt.Sprintf("Belum ada aktivitas belajar yang tercatat. Rata-rata Anda dalam %d hari sebelumnya adalah %d menit per hari.",
+				reflectionBaselineDays, daily.RecentAverage)
+		}
+		return "Belum ada aktivitas belajar yang tercatat pada hari ini."
+	}
+
+	summary := fmt.Sprintf("Anda belajar selama %d menit dalam %d aktivitas", daily.TotalMinutes, daily.ActivityCount)
+	if len(daily.MinutesByCourse) > 0 {
+		top := daily.MinutesByCourse[0]
+		summary += fmt.Sprintf(", paling banyak pada %s (%d menit)", top.CourseTitle, top.Minutes)
+	}
+	summary += "."
+
+	switch {
+	case daily.RecentAverage == 0:
+		summary += fmt.Sprintf(" Ini adalah aktivitas pertama Anda dalam %d hari terakhir.", reflectionBaselineDays)
+	case daily.ChangePercent >= reflectionChangeThreshold:
+		summary += fmt.Sprintf(" Waktu belajar Anda %d%% lebih tinggi dari rata-rata %d hari sebelumnya (%d menit).",
+			daily.ChangePercent, reflectionBaselineDays, daily.RecentAverage)
+	case daily.ChangePercent <= -reflectionChangeThreshold:
+		summary += fmt.Sprintf(" Waktu belajar Anda %d%% lebih rendah dari rata-rata %d hari sebelumnya (%d menit).",
+			-daily.ChangePercent, reflectionBaselineDays, daily.RecentAverage)
+	default:
+		summary += fmt.Sprintf(" Waktu belajar Anda sejalan dengan rata-rata %d hari sebelumnya (%d menit).",
+			reflectionBaselineDays, daily.RecentAverage)
+	}
+
+	if len(daily.QuizResults) > 0 {
+		summary += fmt.Sprintf(" Rata-rata skor quiz Anda hari ini %d.", averageQuizScore(daily.QuizResults))
+	}
+	return summary
+}
+
+func dailyStrengthsAndImprovements(daily *models.DailyReflection, dailyGoal int) ([]string, []string) {
+	strengths := []string{}
+	improvements := []string{}
+
+	if daily.TotalMinutes == 0 {
+		improvements = append(improvements, "Mulai dengan sesi singkat 15 menit untuk menjaga konsistensi")
+		return strengths, improvements
+	}
+
+	if dailyGoal > 0 {
+		if daily.TotalMinutes >= dailyGoal {
+			strengths = append(strengths, fmt.Sprintf("Target harian %d menit tercapai", dailyGoal))
+		} else {
+			improvements = append(improvements, fmt.Sprintf("Tambah %d menit lagi untuk mencapai target harian %d menit",
+				dailyGoal-daily.TotalMinutes, dailyGoal))
+		}
+	}
+
+	if daily.RecentAverage > 0 {
+		if daily.ChangePercent >= reflectionChangeThreshold {
+			strengths = append(strengths, fmt.Sprintf("Waktu belajar naik %d%% dibanding rata-rata %d hari sebelumnya",
+				daily.ChangePercent, reflectionBaselineDays))
+		} else if daily.ChangePercent <= -reflectionChangeThreshold {
+			improvements = append(improvements, fmt.Sprintf("Waktu belajar turun %d%% dibanding rata-rata %d hari sebelumnya",
+				-daily.ChangePercent, reflectionBaselineDays))
+		}
+	}
+
+	if len(daily.MinutesByCourse) > 0 && daily.MinutesByCourse[0].Minutes*100 >= daily.TotalMinutes*reflectionFocusShare {
+		strengths = append(strengths, fmt.Sprintf("Fokus pada satu topik: %s", daily.MinutesByCourse[0].CourseTitle))
+	}
+
+	if len(daily.QuizResults) > 0 {
+		if avg := averageQuizScore(daily.QuizResults); avg >= quizPassingScore {
+			strengths = append(strengths, fmt.Sprintf("Skor quiz rata-rata %d, di atas batas lulus", avg))
+		}
+		for _, quiz := range daily.QuizResults {
+			if !quiz.Passed {
+				improvements = append(improvements, fmt.Sprintf("Ulangi materi untuk %s (skor %d)", quiz.Title, quiz.Score))
+			}
+		}
+	}
+
+	if len(daily.MinutesByType) >= 2 {
+		strengths = append(strengths, fmt.Sprintf("Menggabungkan %d jenis aktivitas belajar", len(daily.MinutesByType)))
+	} else if daily.MinutesByType["quiz"] == 0 {
+		improvements = append(improvements, "Selingi materi dengan quiz untuk menguji pemahaman")
+	}
+
+	return strengths, improvements
+}
+
+func dailyMood(daily *models.DailyReflection, dailyGoal int) string {
+	if daily.TotalMinutes == 0 {
+		return "negative"
+	}
+	for _, quiz := range daily.QuizResults {
+		if !quiz.Passed {
+			return "neutral"
+		}
+	}
+	if (dailyGoal > 0 && daily.TotalMinutes >= dailyGoal) || daily.ChangePercent >= 0 {
+		return "positive"
+	}
+	return "neutral"
+}
+
+func averageQuizScore(results []models.QuizResult) int {
+	if len(results) == 0 {
+		return 0
+	}
+	total := 0
+	for _, quiz := range results {
+		total += quiz.Score
+	}
+	return total / len(results)
+}
@@ -0,0 +1,509 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/delivery"
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+	"mentorsphere-api/pkg/webpush"
+)
+
+// Notification categories. All but alert and system can be switched off in
+// the user's NotificationSettings.
+const (
+	CategoryStudyReminder  = "studyReminder"
+	CategoryWeeklyReport   = "weeklyReport"
+	CategoryMentorMessages = "mentorMessages"
+	CategoryCourseUpdates  = "courseUpdates"
+	CategoryPromotions     = "promotions"
+	// Early-warning alerts go out on every enabled channel
+	CategoryAlert = "alert"
+	// System notices stay in the notification center
+	CategorySystem = "system"
+)
+
+// Delivery statuses
+const (
+	DeliveryPending  = "pending"
+	DeliverySent     = "sent"
+	DeliveryRetrying = "retrying"
+	DeliveryFailed   = "failed"
+	DeliverySkipped  = "skipped"
+)
+
+// Waits before the 2nd, 3rd and 4th attempt; a delivery failing all four fails for good
+var deliveryBackoff = []time.Duration{5 * time.Minute, 15 * time.Minute, time.Hour}
+
+// Bounds a single send over one channel
+const deliveryTimeout = 30 * time.Second
+
+var (
+	ErrInvalidPushSubscription = errors.New("invalid push subscription")
+	ErrPushSubscriptionTaken   = errors.New("push endpoint belongs to another user")
+)
+
+// Hosts of the browsers' push services; subscriptions elsewhere are refused so
+// deliveries cannot be pointed at arbitrary servers. A leading dot matches
+// any subdomain.
+var pushServiceHosts = []string{
+	"fcm.googleapis.com",
+	"android.googleapis.com",
+	"updates.push.services.mozilla.com",
+	"web.push.apple.com",
+	".notify.windows.com",
+}
+
+var (
+	mockDeliveriesMu      sync.Mutex
+	mockDeliveries        []models.NotificationDelivery
+	mockPushSubscriptions []models.PushSubscription
+)
+
+type DeliveryService struct {
+	deliveryRepo     *repository.DeliveryRepository
+	notificationRepo *repository.NotificationRepository
+	settingsRepo     *repository.SettingsRepository
+	authService      *AuthService
+}
+
+func NewDeliveryService() *DeliveryService {
+	return &DeliveryService{
+		deliveryRepo:     repository.NewDeliveryRepository(),
+		notificationRepo: repository.NewNotificationRepository(),
+		settingsRepo:     repository.NewSettingsRepository(),
+		authService:      NewAuthService(),
+	}
+}
+
+// Deliver records the in-app delivery of a stored notification and sends it
+// by e-mail and Web Push in the background, as far as the user's settings allow
+func (s *DeliveryService) Deliver(notification *models.Notification) {
+	settings := loadUserSettings(s.settingsRepo, notification.UserID)
+	s.create(notification, delivery.ChannelInApp, DeliverySent, "")
+
+	for _, channel := range []string{delivery.ChannelEmail, delivery.ChannelPush} {
+		if reason := s.skipReason(channel, notification, settings); reason != "" {
+			s.create(notification, channel, DeliverySkipped, reason)
+			continue
+		}
+
+		record := s.create(notification, channel, DeliveryPending, "")
+		go s.attempt(record, *notification)
+	}
+}
+
+// RetryDue sends deliveries whose retry time has come, and pending ones an
+// interrupted process never finished
+func (s *DeliveryService) RetryDue() (int, error) {
+	due := s.findDue(time.Now())
+	for i := range due {
+		record := &due[i]
+		notification := s.findNotification(record.NotificationID)
+		if notification == nil {
+			record.Status = DeliveryFailed
+			record.Reason = "notification was deleted"
+			record.NextAttemptAt = nil
+			record.UpdatedAt = time.Now()
+			s.save(record)
+			continue
+		}
+		s.attempt(record, *notification)
+	}
+	return len(due), nil
+}
+
+// ListByNotification returns the delivery records of a notification
+func (s *DeliveryService) ListByNotification(notificationID string) []models.NotificationDelivery {
+	// Try Firestore first
+	if s.deliveryRepo.IsFirestoreAvailable() {
+		deliveries, err := s.deliveryRepo.FindDeliveriesByNotification(notificationID)
+		if err == nil {
+			return nonNilDeliveries(deliveries)
+		}
+	}
+
+	// Fallback to mock data
+	return s.filterMock(func(d models.NotificationDelivery) bool {
+		return d.NotificationID == notificationID
+	}, 0)
+}
+
+// ListByStatus returns the newest deliveries with a status, or of any status
+func (s *DeliveryService) ListByStatus(status string, limit int) ([]models.NotificationDelivery, error) {
+	statuses := []string{DeliveryPending, DeliverySent, DeliveryRetrying, DeliveryFailed, DeliverySkipped}
+	if status != "" && !slices.Contains(statuses, status) {
+		return nil, fmt.Errorf("status must be one of %s", strings.Join(statuses, ", "))
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	// Try Firestore first
+	if s.deliveryRepo.IsFirestoreAvailable() {
+		deliveries, err := s.deliveryRepo.FindDeliveriesByStatus(status, limit)
+		if err == nil {
+			return nonNilDeliveries(deliveries), nil
+		}
+	}
+
+	// Fallback to mock data
+	return s.filterMock(func(d models.NotificationDelivery) bool {
+		return status == "" || d.Status == status
+	}, limit), nil
+}
+
+// Subscribe stores a browser's push subscription for the user
+func (s *DeliveryService) Subscribe(userID, userAgent string, req models.PushSubscriptionRequest) (*models.PushSubscription, error) {
+	if !isPushServiceEndpoint(req.Endpoint) {
+		return nil, fmt.Errorf("%w: endpoint must be an https URL of a known push service", ErrInvalidPushSubscription)
+	}
+	// Encrypting an empty payload validates both keys
+	if _, err := webpush.Encrypt(webpush.Subscription{P256DH: req.Keys.P256DH, Auth: req.Keys.Auth}, nil); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPushSubscription, err)
+	}
+
+	subscription := &models.PushSubscription{
+		ID:        subscriptionID(req.Endpoint),
+		UserID:    userID,
+		Endpoint:  req.Endpoint,
+		P256DH:    req.Keys.P256DH,
+		Auth:      req.Keys.Auth,
+		UserAgent: userAgent,
+		CreatedAt: time.Now(),
+	}
+
+	// Try Firestore first
+	if s.deliveryRepo.IsFirestoreAvailable() {
+		saved, err := s.deliveryRepo.SaveSubscription(subscription)
+		if err != nil {
+			return nil, err
+		}
+		if !saved {
+			return nil, ErrPushSubscriptionTaken
+		}
+		return subscription, nil
+	}
+
+	// Fallback to mock data
+	mockDeliveriesMu.Lock()
+	defer mockDeliveriesMu.Unlock()
+	if slices.ContainsFunc(mockPushSubscriptions, func(p models.PushSubscription) bool {
+		return p.ID == subscription.ID && p.UserID != userID
+	}) {
+		return nil, ErrPushSubscriptionTaken
+	}
+	mockPushSubscriptions = slices.DeleteFunc(mockPushSubscriptions, func(p models.PushSubscription) bool {
+		return p.ID == subscription.ID
+	})
+	mockPushSubscriptions = append(mockPushSubscriptions, *subscription)
+	return subscription, nil
+}
+
+// isPushServiceEndpoint reports whether endpoint is an https URL on one of the
+// pushServiceHosts
+func isPushServiceEndpoint(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range pushServiceHosts {
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unsubscribe removes one of the user's push subscriptions by endpoint
+func (s *DeliveryService) Unsubscribe(userID, endpoint string) error {
+	id := subscriptionID(endpoint)
+	for _, subscription := range s.findSubscriptions(userID) {
+		if subscription.ID == id {
+			s.deleteSubscriptions([]string{id})
+			return nil
+		}
+	}
+	return ErrInvalidPushSubscription
+}
+
+// attempt sends a delivery once and records the result, scheduling a retry
+// after a failure until the attempts are used up
+func (s *DeliveryService) attempt(record *models.NotificationDelivery, notification models.Notification) {
+	transport := delivery.Get(record.Channel)
+	recipient, err := s.recipient(record.UserID, record.Channel)
+	if err == nil && transport == nil {
+		err = fmt.Errorf("no transport for channel %s", record.Channel)
+	}
+
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+		err = transport.Send(ctx, *recipient, delivery.Message{
+			NotificationID: notification.ID,
+			Category:       notification.Category,
+			Type:           notification.Type,
+			Title:          notification.Title,
+			Body:           notification.Message,
+		})
+		cancel()
+	}
+
+	var expired *delivery.ExpiredSubscriptionsError
+	if errors.As(err, &expired) {
+		s.deleteSubscriptions(expired.IDs)
+		if expired.Delivered {
+			err = nil
+		}
+	}
+
+	now := time.Now()
+	record.Attempts++
+	record.UpdatedAt = now
+	record.NextAttemptAt = nil
+	switch {
+	case err == nil:
+		record.Status = DeliverySent
+		record.Reason = ""
+		record.SentAt = &now
+	case record.Attempts > len(deliveryBackoff):
+		record.Status = DeliveryFailed
+		record.Reason = err.Error()
+	default:
+		next := now.Add(deliveryBackoff[record.Attempts-1])
+		record.Status = DeliveryRetrying
+		record.Reason = err.Error()
+		record.NextAttemptAt = &next
+	}
+	if err != nil {
+		log.Printf("delivery[%s] of notification %s failed (attempt %d): %v",
+			record.Channel, record.NotificationID, record.Attempts, err)
+	}
+
+	s.save(record)
+}
+
+// skipReason tells why a channel must not be used for the notification, or
+// returns an empty string when it may
+func (s *DeliveryService) skipReason(channel string, notification *models.Notification, settings *models.UserSettings) string {
+	prefs := settings.Notifications
+	switch {
+	case notification.Category == CategorySystem:
+		return "system notices are in-app only"
+	case !categoryEnabled(notification.Category, prefs):
+		return notification.Category + " notifications are disabled"
+	case channel == delivery.ChannelEmail && !prefs.Email:
+		return "e-mail notifications are disabled"
+	case channel == delivery.ChannelPush && !prefs.Push:
+		return "push notifications are disabled"
+	}
+
+	recipient, err := s.recipient(notification.UserID, channel)
+	switch {
+	case err != nil:
+		return err.Error()
+	case channel == delivery.ChannelEmail && recipient.Email == "":
+		return "no e-mail address"
+	case channel == delivery.ChannelPush && len(recipient.Subscriptions) == 0:
+		return "no push subscriptions"
+	}
+	return ""
+}
+
+// categoryEnabled checks the category's toggle; categories without one are on
+func categoryEnabled(category string, prefs models.NotificationSettings) bool {
+	switch category {
+	case CategoryStudyReminder:
+		return prefs.StudyReminder
+	case CategoryWeeklyReport:
+		return prefs.WeeklyReport
+	case CategoryMentorMessages:
+		return prefs.MentorMessages
+	case CategoryCourseUpdates:
+		return prefs.CourseUpdates
+	case CategoryPromotions:
+		return prefs.Promotions
+	}
+	return true
+}
+
+func (s *DeliveryService) recipient(userID, channel string) (*delivery.Recipient, error) {
+	user, err := s.authService.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient := &delivery.Recipient{UserID: user.ID, Name: user.Name, Email: user.Email}
+	if channel == delivery.ChannelPush {
+		recipient.Subscriptions = s.findSubscriptions(userID)
+	}
+	return recipient, nil
+}
+
+func (s *DeliveryService) create(notification *models.Notification, channel, status, reason string) *models.NotificationDelivery {
+	now := time.Now()
+	record := &models.NotificationDelivery{
+		NotificationID: notification.ID,
+		UserID:         notification.UserID,
+		Channel:        channel,
+		Status:         status,
+		Reason:         reason,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	switch status {
+	case DeliverySent:
+		record.SentAt = &now
+	case DeliveryPending:
+		// Picked up by the retry job should this process stop before sending
+		next := now.Add(deliveryBackoff[0])
+		record.NextAttemptAt = &next
+	}
+
+	// Try Firestore first
+	if s.deliveryRepo.IsFirestoreAvailable() {
+		if err := s.deliveryRepo.CreateDelivery(record); err == nil {
+			return record
+		}
+	}
+
+	// Fallback to mock data
+	mockDeliveriesMu.Lock()
+	defer mockDeliveriesMu.Unlock()
+	record.ID = fmt.Sprintf("%d", len(mockDeliveries)+1)
+	mockDeliveries = append(mockDeliveries, *record)
+	return record
+}
+
+func (s *DeliveryService) save(record *models.NotificationDelivery) {
+	// Try Firestore first
+	if s.deliveryRepo.IsFirestoreAvailable() {
+		if err := s.deliveryRepo.SaveDelivery(record); err == nil {
+			return
+		}
+	}
+
+	// Fallback to mock data
+	mockDeliveriesMu.Lock()
+	defer mockDeliveriesMu.Unlock()
+	for i := range mockDeliveries {
+		if mockDeliveries[i].ID == record.ID {
+			mockDeliveries[i] = *record
+			return
+		}
+	}
+}
+
+func (s *DeliveryService) findDue(now time.Time) []models.NotificationDelivery {
+	// Try Firestore first
+	if s.deliveryRepo.IsFirestoreAvailable() {
+		deliveries, err := s.deliveryRepo.FindDueDeliveries(now)
+		if err == nil {
+			return deliveries
+		}
+	}
+
+	// Fallback to mock data
+	return s.filterMock(func(d models.NotificationDelivery) bool {
+		return (d.Status == DeliveryPending || d.Status == DeliveryRetrying) &&
+			d.NextAttemptAt != nil && !d.NextAttemptAt.After(now)
+	}, 0)
+}
+
+func (s *DeliveryService) findNotification(notificationID string) *models.Notification {
+	// Try Firestore first
+	if s.notificationRepo.IsFirestoreAvailable() {
+		notification, err := s.notificationRepo.FindByID(notificationID)
+		if err == nil {
+			return notification
+		}
+	}
+
+	// Fallback to mock data
+	mockNotificationsMu.Lock()
+	defer mockNotificationsMu.Unlock()
+	for _, notification := range mockNotifications {
+		if notification.ID == notificationID {
+			return &notification
+		}
+	}
+	return nil
+}
+
+func (s *DeliveryService) findSubscriptions(userID string) []models.PushSubscription {
+	// Try Firestore first
+	if s.deliveryRepo.IsFirestoreAvailable() {
+		subscriptions, err := s.deliveryRepo.FindSubscriptionsByUserID(userID)
+		if err == nil {
+			return subscriptions
+		}
+	}
+
+	// Fallback to mock data
+	mockDeliveriesMu.Lock()
+	defer mockDeliveriesMu.Unlock()
+	var subscriptions []models.PushSubscription
+	for _, subscription := range mockPushSubscriptions {
+		if subscription.UserID == userID {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions
+}
+
+func (s *DeliveryService) deleteSubscriptions(ids []string) {
+	// Try Firestore first
+	if s.deliveryRepo.IsFirestoreAvailable() {
+		for _, id := range ids {
+			s.deliveryRepo.DeleteSubscription(id)
+		}
+		return
+	}
+
+	// Fallback to mock data
+	mockDeliveriesMu.Lock()
+	defer mockDeliveriesMu.Unlock()
+	mockPushSubscriptions = slices.DeleteFunc(mockPushSubscriptions, func(p models.PushSubscription) bool {
+		return slices.Contains(ids, p.ID)
+	})
+}
+
+// filterMock returns matching mock deliveries, newest first
+func (s *DeliveryService) filterMock(match func(models.NotificationDelivery) bool, limit int) []models.NotificationDelivery {
+	mockDeliveriesMu.Lock()
+	defer mockDeliveriesMu.Unlock()
+
+	deliveries := []models.NotificationDelivery{}
+	for i := len(mockDeliveries) - 1; i >= 0; i-- {
+		if match(mockDeliveries[i]) {
+			deliveries = append(deliveries, mockDeliveries[i])
+		}
+		if limit > 0 && len(deliveries) == limit {
+			break
+		}
+	}
+	return deliveries
+}
+
+func nonNilDeliveries(deliveries []models.NotificationDelivery) []models.NotificationDelivery {
+	if deliveries == nil {
+		return []models.NotificationDelivery{}
+	}
+	return deliveries
+}
+
+// subscriptionID derives a stable document ID from a push endpoint
+func subscriptionID(endpoint string) string {
+	sum := sha256.Sum256([]byte(endpoint))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/delivery"
+	"mentorsphere-api/internal/digest"
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+// Students and mentors get a digest; other roles have nothing to report
+var digestRoles = []string{"student", "mentor"}
+
+var ErrDigestUnavailable = errors.New("weekly digests are only available to students and mentors")
+
+// Digest sends for development (used as fallback when Firebase is not configured)
+var (
+	mockDigestSends   = make(map[string]models.DigestSend)
+	mockDigestSendsMu sync.Mutex
+)
+
+type DigestService struct {
+	digestRepo          *repository.DigestRepository
+	userRepo            *repository.UserRepository
+	activityRepo        *repository.ActivityRepository
+	settingsRepo        *repository.SettingsRepository
+	authService         *AuthService
+	courseService       *CourseService
+	streakService       *StreakService
+	riskService         *RiskService
+	reflectionService   *ReflectionService
+	interventionService *InterventionService
+}
+
+func NewDigestService() *DigestService {
+	return &DigestService{
+		digestRepo:          repository.NewDigestRepository(),
+		userRepo:            repository.NewUserRepository(),
+		activityRepo:        repository.NewActivityRepository(),
+		settingsRepo:        repository.NewSettingsRepository(),
+		authService:         NewAuthService(),
+		courseService:       NewCourseService(),
+		streakService:       NewStreakService(),
+		riskService:         NewRiskService(),
+		reflectionService:   NewReflectionService(),
+		interventionService: NewInterventionService(),
+	}
+}
+
+// Preview renders the user's digest for an ISO week without sending it.
+// Year and week 0 mean last week, the one the weekly job reports on.
+func (s *DigestService) Preview(userID string, year, week int) (*models.WeeklyDigest, error) {
+	user, err := s.authService.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	settings := loadUserSettings(s.settingsRepo, userID)
+	loc := userLocation(settings)
+
+	start := isoWeekStartOf(time.Now(), loc).AddDate(0, 0, -7)
+	if year != 0 || week != 0 {
+		if year == 0 {
+			year, _ = time.Now().In(loc).ISOWeek()
+		}
+		if start, err = isoWeekStart(year, week, loc); err != nil {
+			return nil, err
+		}
+	}
+	if start.After(time.Now()) {
+		return nil, fmt.Errorf("cannot report on a future week")
+	}
+
+	return s.build(user, settings, start)
+}
+
+// SendWeekly e-mails last week's digest to every student and mentor who gets
+// weekly reports. A failing user does not stop the others; the failures are
+// reported together so the run is retried, and users already sent to are
+// skipped then.
+func (s *DigestService) SendWeekly(ctx context.Context) (int, error) {
+	sent := 0
+	var errs []error
+	for _, role := range digestRoles {
+		for _, user := range findUsersByRole(s.userRepo, role) {
+			if err := ctx.Err(); err != nil {
+				return sent, err
+			}
+			ok, err := s.send(ctx, &user)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("user %s: %w", user.ID, err))
+			}
+			if ok {
+				sent++
+			}
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// send e-mails the user's digest for last week unless weekly reports or
+// e-mails are off, or it was sent already
+func (s *DigestService) send(ctx context.Context, user *models.User) (bool, error) {
+	settings := loadUserSettings(s.settingsRepo, user.ID)
+	if !settings.Notifications.WeeklyReport || !settings.Notifications.Email || user.Email == "" {
+		return false, nil
+	}
+
+	start := isoWeekStartOf(time.Now(), userLocation(settings)).AddDate(0, 0, -7)
+	d, err := s.build(user, settings, start)
+	if err != nil {
+		return false, err
+	}
+
+	record := &models.DigestSend{
+		UserID: user.ID,
+		Week:   isoWeekKey(d.Year, d.WeekNumber),
+		SentAt: time.Now(),
+	}
+	claimed, err := s.claim(record)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	transport := delivery.Get(delivery.ChannelEmail)
+	if transport == nil {
+		s.release(record)
+		return false, fmt.Errorf("no transport for channel %s", delivery.ChannelEmail)
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	err = transport.Send(sendCtx, delivery.Recipient{UserID: user.ID, Name: user.Name, Email: user.Email}, delivery.Message{
+		Category: CategoryWeeklyReport,
+		Type:     "info",
+		Title:    d.Subject,
+		Body:     d.Text,
+		HTML:     d.HTML,
+	})
+	if err != nil {
+		// Let the retried run send it
+		s.release(record)
+		log.Printf("digest: failed to send week %s to user %s: %v", record.Week, user.ID, err)
+		return false, err
+	}
+	return true, nil
+}
+
+// build gathers the figures of the week starting at start and renders them
+func (s *DigestService) build(user *models.User, settings *models.UserSettings, start time.Time) (*models.WeeklyDigest, error) {
+	end := start.AddDate(0, 0, 7).Add(-time.Nanosecond)
+	year, week := start.ISOWeek()
+
+	d := &models.WeeklyDigest{
+		UserID:      user.ID,
+		Name:        user.Name,
+		Role:        user.Role,
+		Language:    settings.Appearance.Language,
+		Year:        year,
+		WeekNumber:  week,
+		WeekStart:   start.Format(dateLayout),
+		WeekEnd:     end.Format(dateLayout),
+		GeneratedAt: time.Now(),
+	}
+
+	switch user.Role {
+	case "student":
+		d.Student = s.studentDigest(user.ID, start, end)
+	case "mentor":
+		d.Mentor = s.mentorDigest(user.ID, start, end)
+	default:
+		return nil, ErrDigestUnavailable
+	}
+
+	if err := digest.Render(d); err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+	return d, nil
+}
+
+func (s *DigestService) studentDigest(userID string, start, end time.Time) *models.StudentDigest {
+	year, week := start.ISOWeek()
+	student := &models.StudentDigest{NextSteps: []models.DigestModule{}}
+	if insight, err := s.reflectionService.GetWeeklyInsight(userID, year, week); err == nil {
+		student.MinutesStudied = insight.TotalStudyTime
+		student.PreviousMinutes = insight.PreviousWeekTotal
+		student.ChangePercent = insight.ChangePercent
+		student.ActiveDays = insight.ActiveDays
+	}
+
+	streak := s.streakService.GetSummary(userID)
+	student.CurrentStreak = streak.CurrentStreak
+	student.LongestStreak = streak.LongestStreak
+
+	completed := 0
+	for _, course := range s.courseService.GetUserCourses(userID) {
+		for _, module := range course.Modules {
+			switch module.Status {
+			case ModuleCompleted:
+				completed++
+			case ModuleInProgress:
+				student.NextSteps = append(student.NextSteps, models.DigestModule{Module: module.Title, Course: course.Title})
+			}
+		}
+	}
+	student.ModulesCompleted = s.modulesCompletedBetween(userID, start, end, completed)
+
+	return student
+}
+
+// modulesCompletedBetween compares the completed-module counts of the risk
+// snapshots before and at the end of the week; for a week that has not ended
+// the current count is the end value. Without a snapshot before the week no
+// baseline is known and 0 is reported.
+func (s *DigestService) modulesCompletedBetween(userID string, start, end time.Time, current int) int {
+	before, atEnd := s.snapshotsAround(userID, start, end)
+	if before == nil {
+		return 0
+	}
+
+	completed := current
+	if end.Before(time.Now()) {
+		if atEnd == nil {
+			return 0
+		}
+		completed = atEnd.CompletedModules
+	}
+	return max(completed-before.CompletedModules, 0)
+}
+
+// snapshotsAround returns the user's last risk snapshot before start and the
+// last one up to end
+func (s *DigestService) snapshotsAround(userID string, start, end time.Time) (*models.RiskSnapshot, *models.RiskSnapshot) {
+	days := int(time.Since(start).Hours()/24) + 14
+	startKey, endKey := start.Format(dateLayout), end.Format(dateLayout)
+
+	var before, atEnd *models.RiskSnapshot
+	for _, snapshot := range s.riskService.getSnapshots(userID, days) {
+		if snapshot.Date < startKey && (before == nil || snapshot.Date > before.Date) {
+			before = &snapshot
+		}
+		if snapshot.Date <= endKey && (atEnd == nil || snapshot.Date > atEnd.Date) {
+			atEnd = &snapshot
+		}
+	}
+	return before, atEnd
+}
+
+func (s *DigestService) mentorDigest(mentorID string, start, end time.Time) *models.MentorDigest {
+	mentor := &models.MentorDigest{
+		NewAtRisk:            []models.DigestStudent{},
+		PendingInterventions: []models.DigestIntervention{},
+	}
+
+	progressTotal := 0
+	for _, student := range findAssignedStudents(s.userRepo, mentorID) {
+		mentor.Students++
+
+		minutes := sumMinutes(findUserActivitiesBetween(s.activityRepo, student.ID, start, end))
+		mentor.TotalMinutes += minutes
+		if minutes > 0 {
+			mentor.ActiveStudents++
+		}
+
+		courses := s.courseService.GetUserCourses(student.ID)
+		if len(courses) > 0 {
+			sum := 0
+			for _, course := range courses {
+				sum += course.Progress
+			}
+			progressTotal += sum / len(courses)
+		}
+
+		// Newly at risk: high at the end of the week but not before it
+		before, atEnd := s.snapshotsAround(student.ID, start, end)
+		if atEnd != nil && atEnd.Level == "high" && atEnd.Date >= start.Format(dateLayout) &&
+			(before == nil || before.Level != "high") {
+			mentor.NewAtRisk = append(mentor.NewAtRisk, models.DigestStudent{
+				ID:        student.ID,
+				Name:      student.Name,
+				RiskScore: atEnd.Score,
+			})
+		}
+	}
+	if mentor.Students > 0 {
+		mentor.AverageProgress = progressTotal / mentor.Students
+	}
+
+	// Pending: reached the student but not answered yet
+	for _, intervention := range s.interventionService.ListByMentor(mentorID) {
+		open := intervention.Status == InterventionSent ||
+			(intervention.Status == InterventionAcknowledged && intervention.Response == nil)
+		if !open {
+			continue
+		}
+		sentAt := intervention.CreatedAt
+		if intervention.SentAt != nil {
+			sentAt = *intervention.SentAt
+		}
+		mentor.PendingInterventions = append(mentor.PendingInterventions, models.DigestIntervention{
+			ID:          intervention.ID,
+			StudentName: intervention.StudentName,
+			Type:        intervention.Type,
+			Status:      intervention.Status,
+			DaysOpen:    int(time.Since(sentAt).Hours() / 24),
+		})
+	}
+
+	return mentor
+}
+
+// claim records the week's digest, reporting false when it was already sent
+func (s *DigestService) claim(record *models.DigestSend) (bool, error) {
+	// Try Firestore first
+	if s.digestRepo.IsFirestoreAvailable() {
+		return s.digestRepo.Create(record)
+	}
+
+	// Fallback to mock data
+	mockDigestSendsMu.Lock()
+	defer mockDigestSendsMu.Unlock()
+	record.ID = record.UserID + "_" + record.Week
+	if _, ok := mockDigestSends[record.ID]; ok {
+		return false, nil
+	}
+	mockDigestSends[record.ID] = *record
+	return true, nil
+}
+
+func (s *DigestService) release(record *models.DigestSend) {
+	// Try Firestore first
+	if s.digestRepo.IsFirestoreAvailable() {
+		if err := s.digestRepo.Delete(record.ID); err == nil {
+			return
+		}
+	}
+
+	// Fallback to mock data
+	mockDigestSendsMu.Lock()
+	delete(mockDigestSends, record.ID)
+	mockDigestSendsMu.Unlock()
+}
@@ -0,0 +1,1037 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+const (
+	defaultForumPageSize = 20
+	maxForumPageSize     = 100
+	maxForumTitleLength  = 200
+	maxForumBodyLength   = 10000
+	maxForumReasonLength = 500
+	maxForumMentions     = 10
+	// Length of the excerpt stored with a report and shown in notifications
+	forumExcerptLength = 140
+)
+
+// Forum report statuses
+const (
+	ForumReportOpen      = "open"
+	ForumReportResolved  = "resolved"
+	ForumReportDismissed = "dismissed"
+)
+
+var forumSorts = []string{"activity", "new", "top"}
+
+var (
+	ErrForumPostNotFound   = errors.New("forum post not found")
+	ErrForumReportNotFound = errors.New("forum report not found")
+	ErrForumForbidden      = errors.New("course forum is only open to enrolled students and mentors")
+	ErrForumModeratorOnly  = errors.New("only mentors can moderate the forum")
+	ErrForumLocked         = errors.New("thread is locked")
+	ErrInvalidForumRequest = errors.New("invalid forum request")
+)
+
+// Forum data for development (used as fallback when Firebase is not configured)
+var (
+	mockForumMu      sync.Mutex
+	mockForumThreads = []models.ForumThread{
+		{
+			ID:             "1",
+			CourseID:       "1",
+			AuthorID:       "4",
+			AuthorName:     "Dr. Hendra Kusuma",
+			AuthorRole:     "mentor",
+			Title:          "Selamat datang di forum Dasar-Dasar Machine Learning",
+			Body:           "Gunakan forum ini untuk bertanya dan berbagi catatan. Buat thread per modul agar diskusi mudah dicari.",
+			Upvoters:       []string{"1", "2"},
+			UpvoteCount:    2,
+			Pinned:         true,
+			LastActivityAt: time.Now().AddDate(0, 0, -10),
+			CreatedAt:      time.Now().AddDate(0, 0, -10),
+			UpdatedAt:      time.Now().AddDate(0, 0, -10),
+		},
+		{
+			ID:              "2",
+			CourseID:        "1",
+			ModuleID:        5,
+			AuthorID:        "2",
+			AuthorName:      "Siti Rahayu",
+			AuthorRole:      "student",
+			Title:           "Kapan sebaiknya melakukan pruning pada decision tree?",
+			Body:            "Saya bingung membedakan pre-pruning dan post-pruning. Mana yang lebih umum dipakai?",
+			Upvoters:        []string{"1"},
+			UpvoteCount:     1,
+			ReplyCount:      1,
+			EndorsedReplyID: "1",
+			LastActivityAt:  time.Now().AddDate(0, 0, -2),
+			CreatedAt:       time.Now().AddDate(0, 0, -3),
+			UpdatedAt:       time.Now().AddDate(0, 0, -2),
+		},
+	}
+	mockForumReplies = []models.ForumReply{
+		{
+			ID:          "1",
+			ThreadID:    "2",
+			CourseID:    "1",
+			AuthorID:    "4",
+			AuthorName:  "Dr. Hendra Kusuma",
+			AuthorRole:  "mentor",
+			Body:        "Post-pruning lebih umum: pohon dibuat penuh lalu cabang yang tidak menurunkan error validasi dipangkas. Pre-pruning lebih cepat tapi berisiko berhenti terlalu dini.",
+			Upvoters:    []string{"1", "2"},
+			UpvoteCount: 2,
+			Endorsed:    true,
+			EndorsedBy:  "4",
+			CreatedAt:   time.Now().AddDate(0, 0, -2),
+			UpdatedAt:   time.Now().AddDate(0, 0, -2),
+		},
+	}
+	mockForumReports   []models.ForumReport
+	mockForumThreadSeq = len(mockForumThreads)
+	mockForumReplySeq  = len(mockForumReplies)
+	mockForumReportSeq = 0
+)
+
+type ForumService struct {
+	forumRepo           *repository.ForumRepository
+	reportRepo          *repository.ForumReportRepository
+	userRepo            *repository.UserRepository
+	authService         *AuthService
+	courseService       *CourseService
+	notificationService *NotificationService
+}
+
+func NewForumService() *ForumService {
+	return &ForumService{
+		forumRepo:           repository.NewForumRepository(),
+		reportRepo:          repository.NewForumReportRepository(),
+		userRepo:            repository.NewUserRepository(),
+		authService:         NewAuthService(),
+		courseService:       NewCourseService(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// ListThreads returns a page of the threads of a course the user can see,
+// pinned threads first
+func (s *ForumService) ListThreads(userID string, query models.ForumThreadQuery) (*models.ForumThreadPage, error) {
+	user, course, err := s.access(userID, query.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if query.Sort == "" {
+		query.Sort = "activity"
+	}
+	if !slices.Contains(forumSorts, query.Sort) {
+		return nil, fmt.Errorf("%w: sort must be one of %s", ErrInvalidForumRequest, strings.Join(forumSorts, ", "))
+	}
+	if query.Limit <= 0 || query.Limit > maxForumPageSize {
+		query.Limit = defaultForumPageSize
+	}
+	query.Offset = max(query.Offset, 0)
+
+	moderator := s.isForumModerator(user, course.ID)
+	threads := []models.ForumThread{}
+	for _, thread := range s.findThreads(course.ID) {
+		if query.ModuleID != 0 && thread.ModuleID != query.ModuleID {
+			continue
+		}
+		if !canSeeForumPost(user, moderator, thread.AuthorID, thread.Hidden) {
+			continue
+		}
+		thread.Upvoted = slices.Contains(thread.Upvoters, userID)
+		threads = append(threads, thread)
+	}
+
+	sort.SliceStable(threads, func(i, j int) bool {
+		a, b := threads[i], threads[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		switch query.Sort {
+		case "new":
+			return a.CreatedAt.After(b.CreatedAt)
+		case "top":
+			if a.UpvoteCount != b.UpvoteCount {
+				return a.UpvoteCount > b.UpvoteCount
+			}
+		}
+		return a.LastActivityAt.After(b.LastActivityAt)
+	})
+
+	page := &models.ForumThreadPage{Threads: []models.ForumThread{}, Total: len(threads)}
+	if query.Offset < len(threads) {
+		page.Threads = threads[query.Offset:min(query.Offset+query.Limit, len(threads))]
+	}
+	return page, nil
+}
+
+// GetThread returns a thread with the replies the user can see
+func (s *ForumService) GetThread(userID, threadID string) (*models.ForumThreadDetail, error) {
+	user, thread, err := s.visibleThread(userID, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	moderator := s.isForumModerator(user, thread.CourseID)
+	detail := &models.ForumThreadDetail{ForumThread: *thread, Replies: []models.ForumReply{}}
+	for _, reply := range s.findReplies(thread.ID) {
+		if !canSeeForumPost(user, moderator, reply.AuthorID, reply.Hidden) {
+			continue
+		}
+		reply.Upvoted = slices.Contains(reply.Upvoters, userID)
+		detail.Replies = append(detail.Replies, reply)
+	}
+	return detail, nil
+}
+
+// CreateThread starts a thread in a course, about one of its modules when
+// ModuleID is set, and notifies the mentioned users
+func (s *ForumService) CreateThread(userID, courseID string, req models.CreateForumThreadRequest) (*models.ForumThread, error) {
+	user, course, err := s.access(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	body := strings.TrimSpace(req.Body)
+	if title == "" || len([]rune(title)) > maxForumTitleLength {
+		return nil, fmt.Errorf("%w: title is required and at most %d characters", ErrInvalidForumRequest, maxForumTitleLength)
+	}
+	if err := validateForumBody(body); err != nil {
+		return nil, err
+	}
+	if req.ModuleID != 0 && !slices.ContainsFunc(course.Modules, func(m models.Module) bool { return m.ID == req.ModuleID }) {
+		return nil, ErrModuleNotFound
+	}
+	mentions, err := s.resolveMentions(user, course.ID, req.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	thread := &models.ForumThread{
+		CourseID:       course.ID,
+		ModuleID:       req.ModuleID,
+		AuthorID:       user.ID,
+		AuthorName:     user.Name,
+		AuthorRole:     user.Role,
+		Title:          title,
+		Body:           body,
+		Mentions:       mentions,
+		Upvoters:       []string{},
+		LastActivityAt: now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.createThread(thread); err != nil {
+		return nil, err
+	}
+
+	s.notifyMentions(user, mentions, thread.Title)
+	return thread, nil
+}
+
+// Reply adds a reply to a thread and notifies the thread's author and the
+// mentioned users. Only mentors can reply to locked threads.
+func (s *ForumService) Reply(userID, threadID string, req models.CreateForumReplyRequest) (*models.ForumReply, error) {
+	user, thread, err := s.visibleThread(userID, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.Locked && !s.isForumModerator(user, thread.CourseID) {
+		return nil, ErrForumLocked
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if err := validateForumBody(body); err != nil {
+		return nil, err
+	}
+	mentions, err := s.resolveMentions(user, thread.CourseID, req.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reply := &models.ForumReply{
+		ThreadID:   thread.ID,
+		CourseID:   thread.CourseID,
+		AuthorID:   user.ID,
+		AuthorName: user.Name,
+		AuthorRole: user.Role,
+		Body:       body,
+		Mentions:   mentions,
+		Upvoters:   []string{},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.createReply(reply); err != nil {
+		return nil, err
+	}
+
+	if thread.AuthorID != user.ID && !slices.Contains(mentions, thread.AuthorID) {
+		s.notificationService.Notify(thread.AuthorID, CategoryCourseUpdates, "info", "Balasan Baru di Forum",
+			fmt.Sprintf("%s membalas diskusi \"%s\"", user.Name, thread.Title))
+	}
+	s.notifyMentions(user, mentions, thread.Title)
+	return reply, nil
+}
+
+// UpvoteThread adds or removes the user's upvote of a thread
+func (s *ForumService) UpvoteThread(userID, threadID string, upvote bool) (*models.ForumThread, error) {
+	_, thread, err := s.visibleThread(userID, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.AuthorID == userID {
+		return nil, fmt.Errorf("%w: cannot upvote your own post", ErrInvalidForumRequest)
+	}
+
+	upvoters, err := s.setThreadUpvote(thread.ID, userID, upvote)
+	if err != nil {
+		return nil, err
+	}
+	thread.Upvoters = upvoters
+	thread.UpvoteCount = len(upvoters)
+	thread.Upvoted = upvote
+	return thread, nil
+}
+
+// UpvoteReply adds or removes the user's upvote of a reply
+func (s *ForumService) UpvoteReply(userID, replyID string, upvote bool) (*models.ForumReply, error) {
+	_, _, reply, err := s.visibleReply(userID, replyID)
+	if err != nil {
+		return nil, err
+	}
+	if reply.AuthorID == userID {
+		return nil, fmt.Errorf("%w: cannot upvote your own post", ErrInvalidForumRequest)
+	}
+
+	upvoters, err := s.setReplyUpvote(reply.ID, userID, upvote)
+	if err != nil {
+		return nil, err
+	}
+	reply.Upvoters = upvoters
+	reply.UpvoteCount = len(upvoters)
+	reply.Upvoted = upvote
+	return reply, nil
+}
+
+// EndorseReply marks a reply as the answer to its thread, replacing an
+// earlier endorsed reply, or removes the mark
+func (s *ForumService) EndorseReply(userID, replyID string, endorse bool) (*models.ForumReply, error) {
+	user, thread, reply, err := s.visibleReply(userID, replyID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isForumModerator(user, thread.CourseID) {
+		return nil, ErrForumModeratorOnly
+	}
+
+	if reply.Endorsed == endorse {
+		reply.Upvoted = slices.Contains(reply.Upvoters, userID)
+		return reply, nil
+	}
+
+	reply.UpdatedAt = time.Now()
+	if err := s.endorseReply(thread.ID, reply.ID, user.ID, endorse, reply.UpdatedAt); err != nil {
+		return nil, err
+	}
+	reply.Endorsed = endorse
+	reply.EndorsedBy = ""
+	if endorse {
+		reply.EndorsedBy = user.ID
+	}
+
+	if endorse && reply.AuthorID != user.ID {
+		s.notificationService.Notify(reply.AuthorID, CategoryCourseUpdates, "success", "Jawaban Anda Didukung Mentor",
+			fmt.Sprintf("%s menandai jawaban Anda di \"%s\" sebagai jawaban terbaik", user.Name, thread.Title))
+	}
+	reply.Upvoted = slices.Contains(reply.Upvoters, userID)
+	return reply, nil
+}
+
+// ModerateThread pins, locks or hides a thread
+func (s *ForumService) ModerateThread(userID, threadID string, req models.ForumModerationRequest) (*models.ForumThread, error) {
+	user, thread, err := s.visibleThread(userID, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isForumModerator(user, thread.CourseID) {
+		return nil, ErrForumModeratorOnly
+	}
+
+	thread.UpdatedAt = time.Now()
+	fields := map[string]interface{}{"updatedAt": thread.UpdatedAt}
+	if req.Pinned != nil {
+		thread.Pinned = *req.Pinned
+		fields["pinned"] = thread.Pinned
+	}
+	if req.Locked != nil {
+		thread.Locked = *req.Locked
+		fields["locked"] = thread.Locked
+	}
+	if req.Hidden != nil {
+		thread.Hidden = *req.Hidden
+		fields["hidden"] = thread.Hidden
+	}
+	if err := s.updateThread(thread.ID, fields); err != nil {
+		return nil, err
+	}
+	thread.Upvoted = slices.Contains(thread.Upvoters, userID)
+	return thread, nil
+}
+
+// ModerateReply hides or unhides a reply; replies cannot be pinned or locked
+func (s *ForumService) ModerateReply(userID, replyID string, req models.ForumModerationRequest) (*models.ForumReply, error) {
+	if req.Pinned != nil || req.Locked != nil {
+		return nil, fmt.Errorf("%w: replies can only be hidden", ErrInvalidForumRequest)
+	}
+	user, thread, reply, err := s.visibleReply(userID, replyID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isForumModerator(user, thread.CourseID) {
+		return nil, ErrForumModeratorOnly
+	}
+
+	reply.UpdatedAt = time.Now()
+	fields := map[string]interface{}{"updatedAt": reply.UpdatedAt}
+	if req.Hidden != nil {
+		reply.Hidden = *req.Hidden
+		fields["hidden"] = reply.Hidden
+	}
+	if err := s.updateReply(reply.ID, fields); err != nil {
+		return nil, err
+	}
+	reply.Upvoted = slices.Contains(reply.Upvoters, userID)
+	return reply, nil
+}
+
+// ReportThread flags a thread for the mentors of the course to review
+func (s *ForumService) ReportThread(userID, threadID string, req models.ForumReportRequest) (*models.ForumReport, error) {
+	_, thread, err := s.visibleThread(userID, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return s.report(userID, thread, nil, req.Reason)
+}
+
+// ReportReply flags a reply for the mentors of the course to review
+func (s *ForumService) ReportReply(userID, replyID string, req models.ForumReportRequest) (*models.ForumReport, error) {
+	_, thread, reply, err := s.visibleReply(userID, replyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.report(userID, thread, reply, req.Reason)
+}
+
+// ListReports returns the reports of a course, newest first, optionally only
+// those with the given status
+func (s *ForumService) ListReports(userID, courseID, status string) ([]models.ForumReport, error) {
+	user, course, err := s.access(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isForumModerator(user, course.ID) {
+		return nil, ErrForumModeratorOnly
+	}
+	if status != "" && status != ForumReportOpen && status != ForumReportResolved && status != ForumReportDismissed {
+		return nil, fmt.Errorf("%w: status must be one of open, resolved, dismissed", ErrInvalidForumRequest)
+	}
+
+	return s.findReports(course.ID, status), nil
+}
+
+// ResolveReport closes an open report. Resolving it hides the reported
+// thread or reply; dismissing it leaves the content as it is.
+func (s *ForumService) ResolveReport(userID, reportID string, req models.ResolveForumReportRequest) (*models.ForumReport, error) {
+	if req.Status != ForumReportResolved && req.Status != ForumReportDismissed {
+		return nil, fmt.Errorf("%w: status must be resolved or dismissed", ErrInvalidForumRequest)
+	}
+
+	report, err := s.findReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+	user, _, err := s.access(userID, report.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isForumModerator(user, report.CourseID) {
+		return nil, ErrForumModeratorOnly
+	}
+	if report.Status != ForumReportOpen {
+		return nil, fmt.Errorf("%w: report is already %s", ErrInvalidForumRequest, report.Status)
+	}
+
+	if req.Status == ForumReportResolved {
+		hidden := true
+		if report.ReplyID != "" {
+			_, err = s.ModerateReply(userID, report.ReplyID, models.ForumModerationRequest{Hidden: &hidden})
+		} else {
+			_, err = s.ModerateThread(userID, report.ThreadID, models.ForumModerationRequest{Hidden: &hidden})
+		}
+		if err != nil && !errors.Is(err, ErrForumPostNotFound) {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	report.Status = req.Status
+	report.ResolvedBy = user.ID
+	report.ResolvedAt = &now
+	if err := s.saveReport(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *ForumService) report(userID string, thread *models.ForumThread, reply *models.ForumReply, reason string) (*models.ForumReport, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > maxForumReasonLength {
+		return nil, fmt.Errorf("%w: reason is required and at most %d characters", ErrInvalidForumRequest, maxForumReasonLength)
+	}
+
+	report := &models.ForumReport{
+		CourseID:   thread.CourseID,
+		ThreadID:   thread.ID,
+		ReporterID: userID,
+		Reason:     reason,
+		Excerpt:    excerpt(thread.Title+": "+thread.Body, forumExcerptLength),
+		Status:     ForumReportOpen,
+		CreatedAt:  time.Now(),
+	}
+	if reply != nil {
+		report.ReplyID = reply.ID
+		report.Excerpt = excerpt(reply.Body, forumExcerptLength)
+	}
+
+	for _, existing := range s.findReports(thread.CourseID, ForumReportOpen) {
+		if existing.ReporterID == userID && existing.ThreadID == report.ThreadID && existing.ReplyID == report.ReplyID {
+			return nil, fmt.Errorf("%w: you already reported this post", ErrInvalidForumRequest)
+		}
+	}
+	if err := s.createReport(report); err != nil {
+		return nil, err
+	}
+
+	for _, mentor := range s.courseMentors(thread.CourseID) {
+		s.notificationService.Notify(mentor.ID, CategorySystem, "warning", "Laporan Forum Baru",
+			fmt.Sprintf("Postingan di \"%s\" dilaporkan: %s", thread.Title, reason))
+	}
+	return report, nil
+}
+
+// access returns the user and the course when the user may take part in the
+// course forum: mentors and admins always, students when enrolled
+func (s *ForumService) access(userID, courseID string) (*models.User, *models.Course, error) {
+	user, err := s.authService.GetUserByID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	course := s.courseService.GetByID(courseID)
+	if course == nil {
+		return nil, nil, ErrCourseNotFound
+	}
+	if !s.canJoinForum(user, course.ID) {
+		return nil, nil, ErrForumForbidden
+	}
+	return user, course, nil
+}
+
+// visibleThread loads a thread the user can see
+func (s *ForumService) visibleThread(userID, threadID string) (*models.User, *models.ForumThread, error) {
+	thread, err := s.findThread(threadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, _, err := s.access(userID, thread.CourseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canSeeForumPost(user, s.isForumModerator(user, thread.CourseID), thread.AuthorID, thread.Hidden) {
+		return nil, nil, ErrForumPostNotFound
+	}
+	thread.Upvoted = slices.Contains(thread.Upvoters, userID)
+	return user, thread, nil
+}
+
+// visibleReply loads a reply the user can see, in a thread the user can see
+func (s *ForumService) visibleReply(userID, replyID string) (*models.User, *models.ForumThread, *models.ForumReply, error) {
+	reply, err := s.findReply(replyID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	user, thread, err := s.visibleThread(userID, reply.ThreadID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !canSeeForumPost(user, s.isForumModerator(user, thread.CourseID), reply.AuthorID, reply.Hidden) {
+		return nil, nil, nil, ErrForumPostNotFound
+	}
+	return user, thread, reply, nil
+}
+
+// resolveMentions keeps the mentioned users who can read the course forum,
+// without duplicates and the author
+func (s *ForumService) resolveMentions(author *models.User, courseID string, ids []string) ([]string, error) {
+	mentions := []string{}
+	for _, id := range ids {
+		if id == author.ID || slices.Contains(mentions, id) {
+			continue
+		}
+		if user, err := s.authService.GetUserByID(id); err == nil && s.canJoinForum(user, courseID) {
+			mentions = append(mentions, user.ID)
+		}
+	}
+	if len(mentions) > maxForumMentions {
+		return nil, fmt.Errorf("%w: at most %d users can be mentioned", ErrInvalidForumRequest, maxForumMentions)
+	}
+	return mentions, nil
+}
+
+func (s *ForumService) notifyMentions(author *models.User, mentions []string, title string) {
+	for _, id := range mentions {
+		s.notificationService.Notify(id, CategoryCourseUpdates, "info", "Anda Disebut di Forum",
+			fmt.Sprintf("%s menyebut Anda di diskusi \"%s\"", author.Name, title))
+	}
+}
+
+// courseMentors returns the mentors with an assigned student enrolled in the
+// course, who moderate its forum
+func (s *ForumService) courseMentors(courseID string) []models.User {
+	var mentors []models.User
+	for _, mentor := range findUsersByRole(s.userRepo, "mentor") {
+		if s.mentorsCourse(mentor.ID, courseID) {
+			mentors = append(mentors, mentor)
+		}
+	}
+	return mentors
+}
+
+// mentorsCourse reports whether the mentor has an assigned student enrolled in
+// the course
+func (s *ForumService) mentorsCourse(mentorID, courseID string) bool {
+	return slices.ContainsFunc(findAssignedStudents(s.userRepo, mentorID), func(student models.User) bool {
+		return slices.Contains(student.EnrolledCourses, courseID)
+	})
+}
+
+// canJoinForum lets the course's students and moderators read and post
+func (s *ForumService) canJoinForum(user *models.User, courseID string) bool {
+	return slices.Contains(user.EnrolledCourses, courseID) || s.isForumModerator(user, courseID)
+}
+
+// isForumModerator reports whether the user moderates the course forum:
+// admins moderate every forum, mentors those of their students' courses
+func (s *ForumService) isForumModerator(user *models.User, courseID string) bool {
+	return user.Role == "admin" || (user.Role == "mentor" && s.mentorsCourse(user.ID, courseID))
+}
+
+// canSeeForumPost hides hidden posts from everyone but moderators and the author
+func canSeeForumPost(user *models.User, moderator bool, authorID string, hidden bool) bool {
+	return !hidden || moderator || user.ID == authorID
+}
+
+func validateForumBody(body string) error {
+	if body == "" || len([]rune(body)) > maxForumBodyLength {
+		return fmt.Errorf("%w: body is required and at most %d characters", ErrInvalidForumRequest, maxForumBodyLength)
+	}
+	return nil
+}
+
+func toggleUpvote(upvoters []string, userID string, upvote bool) []string {
+	upvoters = slices.DeleteFunc(slices.Clone(upvoters), func(id string) bool { return id == userID })
+	if upvote {
+		upvoters = append(upvoters, userID)
+	}
+	return upvoters
+}
+
+func excerpt(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length]) + "…"
+}
+
+// countForumPostsSince counts the threads and replies a user wrote since the
+// given time
+func countForumPostsSince(forumRepo *repository.ForumRepository, userID string, since time.Time) int {
+	// Try Firestore first
+	if forumRepo.IsFirestoreAvailable() {
+		count, err := forumRepo.CountPostsSince(userID, since)
+		if err == nil {
+			return count
+		}
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	count := 0
+	for _, thread := range mockForumThreads {
+		if thread.AuthorID == userID && !thread.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	for _, reply := range mockForumReplies {
+		if reply.AuthorID == userID && !reply.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *ForumService) findThreads(courseID string) []models.ForumThread {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		threads, err := s.forumRepo.FindThreadsByCourse(courseID)
+		if err == nil {
+			return threads
+		}
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	var threads []models.ForumThread
+	for _, thread := range mockForumThreads {
+		if thread.CourseID == courseID {
+			thread.Upvoters = slices.Clone(thread.Upvoters)
+			threads = append(threads, thread)
+		}
+	}
+	return threads
+}
+
+func (s *ForumService) findThread(threadID string) (*models.ForumThread, error) {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		if thread, err := s.forumRepo.FindThreadByID(threadID); err == nil {
+			return thread, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	for _, thread := range mockForumThreads {
+		if thread.ID == threadID {
+			thread.Upvoters = slices.Clone(thread.Upvoters)
+			return &thread, nil
+		}
+	}
+	return nil, ErrForumPostNotFound
+}
+
+func (s *ForumService) createThread(thread *models.ForumThread) error {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		return s.forumRepo.CreateThread(thread)
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	mockForumThreadSeq++
+	thread.ID = fmt.Sprintf("%d", mockForumThreadSeq)
+	mockForumThreads = append(mockForumThreads, *thread)
+	return nil
+}
+
+// updateThread writes only the given fields of a thread, so concurrent
+// changes to its other fields are kept
+func (s *ForumService) updateThread(threadID string, fields map[string]interface{}) error {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		return s.forumRepo.UpdateThreadFields(threadID, fields)
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	for i := range mockForumThreads {
+		if mockForumThreads[i].ID != threadID {
+			continue
+		}
+		thread := &mockForumThreads[i]
+		for field, value := range fields {
+			switch field {
+			case "pinned":
+				thread.Pinned = value.(bool)
+			case "locked":
+				thread.Locked = value.(bool)
+			case "hidden":
+				thread.Hidden = value.(bool)
+			case "updatedAt":
+				thread.UpdatedAt = value.(time.Time)
+			}
+		}
+		return nil
+	}
+	return ErrForumPostNotFound
+}
+
+func (s *ForumService) setThreadUpvote(threadID, userID string, upvote bool) ([]string, error) {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		return s.forumRepo.SetThreadUpvote(threadID, userID, upvote)
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	for i := range mockForumThreads {
+		if mockForumThreads[i].ID == threadID {
+			thread := &mockForumThreads[i]
+			thread.Upvoters = toggleUpvote(thread.Upvoters, userID, upvote)
+			thread.UpvoteCount = len(thread.Upvoters)
+			return slices.Clone(thread.Upvoters), nil
+		}
+	}
+	return nil, ErrForumPostNotFound
+}
+
+func (s *ForumService) findReplies(threadID string) []models.ForumReply {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		replies, err := s.forumRepo.FindReplies(threadID)
+		if err == nil {
+			return replies
+		}
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	var replies []models.ForumReply
+	for _, reply := range mockForumReplies {
+		if reply.ThreadID == threadID {
+			reply.Upvoters = slices.Clone(reply.Upvoters)
+			replies = append(replies, reply)
+		}
+	}
+	return replies
+}
+
+func (s *ForumService) findReply(replyID string) (*models.ForumReply, error) {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		if reply, err := s.forumRepo.FindReplyByID(replyID); err == nil {
+			return reply, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	for _, reply := range mockForumReplies {
+		if reply.ID == replyID {
+			reply.Upvoters = slices.Clone(reply.Upvoters)
+			return &reply, nil
+		}
+	}
+	return nil, ErrForumPostNotFound
+}
+
+// createReply stores a reply and counts it on its thread
+func (s *ForumService) createReply(reply *models.ForumReply) error {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		return s.forumRepo.CreateReply(reply)
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	for i := range mockForumThreads {
+		if mockForumThreads[i].ID == reply.ThreadID {
+			mockForumThreads[i].ReplyCount++
+			mockForumThreads[i].LastActivityAt = reply.CreatedAt
+		}
+	}
+	mockForumReplySeq++
+	reply.ID = fmt.Sprintf("%d", mockForumReplySeq)
+	mockForumReplies = append(mockForumReplies, *reply)
+	return nil
+}
+
+// updateReply writes only the given fields of a reply
+func (s *ForumService) updateReply(replyID string, fields map[string]interface{}) error {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		return s.forumRepo.UpdateReplyFields(replyID, fields)
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	for i := range mockForumReplies {
+		if mockForumReplies[i].ID != replyID {
+			continue
+		}
+		reply := &mockForumReplies[i]
+		for field, value := range fields {
+			switch field {
+			case "hidden":
+				reply.Hidden = value.(bool)
+			case "updatedAt":
+				reply.UpdatedAt = value.(time.Time)
+			}
+		}
+		return nil
+	}
+	return ErrForumPostNotFound
+}
+
+func (s *ForumService) setReplyUpvote(replyID, userID string, upvote bool) ([]string, error) {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		return s.forumRepo.SetReplyUpvote(replyID, userID, upvote)
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	for i := range mockForumReplies {
+		if mockForumReplies[i].ID == replyID {
+			reply := &mockForumReplies[i]
+			reply.Upvoters = toggleUpvote(reply.Upvoters, userID, upvote)
+			reply.UpvoteCount = len(reply.Upvoters)
+			return slices.Clone(reply.Upvoters), nil
+		}
+	}
+	return nil, ErrForumPostNotFound
+}
+
+// endorseReply marks a reply as its thread's answer, unmarking the earlier
+// one, or removes the mark
+func (s *ForumService) endorseReply(threadID, replyID, endorsedBy string, endorse bool, at time.Time) error {
+	// Try Firestore first
+	if s.forumRepo.IsFirestoreAvailable() {
+		return s.forumRepo.EndorseReply(threadID, replyID, endorsedBy, endorse, at)
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	threadIndex := slices.IndexFunc(mockForumThreads, func(t models.ForumThread) bool { return t.ID == threadID })
+	if threadIndex < 0 {
+		return ErrForumPostNotFound
+	}
+	thread := &mockForumThreads[threadIndex]
+	for i := range mockForumReplies {
+		reply := &mockForumReplies[i]
+		switch {
+		case reply.ID == replyID:
+			reply.Endorsed = endorse
+			reply.EndorsedBy = ""
+			if endorse {
+				reply.EndorsedBy = endorsedBy
+			}
+			reply.UpdatedAt = at
+		case endorse && reply.ID == thread.EndorsedReplyID:
+			reply.Endorsed = false
+			reply.EndorsedBy = ""
+		}
+	}
+	switch {
+	case endorse:
+		thread.EndorsedReplyID = replyID
+		thread.UpdatedAt = at
+	case thread.EndorsedReplyID == replyID:
+		thread.EndorsedReplyID = ""
+		thread.UpdatedAt = at
+	}
+	return nil
+}
+
+func (s *ForumService) findReports(courseID, status string) []models.ForumReport {
+	// Try Firestore first
+	if s.reportRepo.IsFirestoreAvailable() {
+		reports, err := s.reportRepo.FindByCourse(courseID, status)
+		if err == nil {
+			if reports == nil {
+				reports = []models.ForumReport{}
+			}
+			return reports
+		}
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	reports := []models.ForumReport{}
+	for i := len(mockForumReports) - 1; i >= 0; i-- {
+		report := mockForumReports[i]
+		if report.CourseID == courseID && (status == "" || report.Status == status) {
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
+
+func (s *ForumService) findReport(reportID string) (*models.ForumReport, error) {
+	// Try Firestore first
+	if s.reportRepo.IsFirestoreAvailable() {
+		if report, err := s.reportRepo.FindByID(reportID); err == nil {
+			return report, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	for _, report := range mockForumReports {
+		if report.ID == reportID {
+			return &report, nil
+		}
+	}
+	return nil, ErrForumReportNotFound
+}
+
+func (s *ForumService) createReport(report *models.ForumReport) error {
+	// Try Firestore first
+	if s.reportRepo.IsFirestoreAvailable() {
+		return s.reportRepo.Create(report)
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	mockForumReportSeq++
+	report.ID = fmt.Sprintf("%d", mockForumReportSeq)
+	mockForumReports = append(mockForumReports, *report)
+	return nil
+}
+
+func (s *ForumService) saveReport(report *models.ForumReport) error {
+	// Try Firestore first
+	if s.reportRepo.IsFirestoreAvailable() {
+		return s.reportRepo.Save(report)
+	}
+
+	// Fallback to mock data
+	mockForumMu.Lock()
+	defer mockForumMu.Unlock()
+	for i := range mockForumReports {
+		if mockForumReports[i].ID == report.ID {
+			mockForumReports[i] = *report
+			return nil
+		}
+	}
+	return ErrForumReportNotFound
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"mentorsphere-api/internal/llm"
+	"mentorsphere-api/internal/models"
+)
+
+// Week-over-week change (percent) reported as an increase or a drop
+const insightChangeThreshold = 10
+
+// buildInsight derives today's dashboard insight from the student's recent
+// activity, streak and in-progress modules
+func (s *StudentService) buildInsight(userID string, courses []models.Course, streak *models.StreakSummary) AIInsight {
+	settings := loadUserSettings(s.settingsRepo, userID)
+	loc := userLocation(settings)
+	today := startOfDay(time.Now(), loc)
+	weekStart := today.AddDate(0, 0, -6)
+
+	thisWeek := sumMinutes(findUserActivitiesBetween(s.activityRepo, userID, weekStart, time.Now()))
+	lastWeek := sumMinutes(findUserActivitiesBetween(s.activityRepo, userID, weekStart.AddDate(0, 0, -7), weekStart.Add(-time.Nanosecond)))
+
+	nextModule, nextCourse := nextInProgressModule(courses)
+
+	insightType, fallback := insightMessage(thisWeek, lastWeek, streak.CurrentStreak, nextModule)
+
+	message, generated := llm.GenerateOrFallback(llm.Request{
+		Template: llm.TemplateStudentInsight,
+		Language: settings.Appearance.Language,
+		Facts: map[string]interface{}{
+			"minutesThisWeek": thisWeek,
+			"minutesLastWeek": lastWeek,
+			"currentStreak":   streak.CurrentStreak,
+			"dailyGoal":       streak.DailyGoal,
+			"todayMinutes":    streak.TodayMinutes,
+			"nextModule":      nextModule,
+			"nextCourse":      nextCourse,
+		},
+	}, fallback)
+
+	source := "rules"
+	if generated {
+		source = "ai"
+	}
+
+	return AIInsight{
+		Title:   "Insight Hari Ini",
+		Message: message,
+		Type:    insightType,
+		Source:  source,
+	}
+}
+
+func insightMessage(thisWeek, lastWeek, streak int, nextModule string) (string, string) {
+	insightType := "info"
+	var message string
+
+	switch {
+	case thisWeek == 0 && lastWeek == 0:
+		insightType = "warning"
+		message = "Belum ada aktivitas belajar dalam dua minggu terakhir."
+	case lastWeek == 0:
+		insightType = "positive"
+		message = fmt.Sprintf("Anda sudah belajar %d menit dalam 7 hari terakhir.", thisWeek)
+	default:
+		change := (thisWeek - lastWeek) * 100 / lastWeek
+		switch {
+		case change >= insightChangeThreshold:
+			insightType = "positive"
+			message = fmt.Sprintf("Produktivitas Anda meningkat %d%% dibanding minggu lalu.", change)
+		case change <= -insightChangeThreshold:
+			insightType = "warning"
+			message = fmt.Sprintf("Waktu belajar Anda turun %d%% dibanding minggu lalu.", -change)
+		default:
+			message = fmt.Sprintf("Waktu belajar Anda stabil dibanding minggu lalu (%d menit).", thisWeek)
+		}
+	}
+
+	if streak >= 3 {
+		message += fmt.Sprintf(" Streak belajar Anda sudah %d hari.", streak)
+	}
+	if nextModule != "" {
+		message += fmt.Sprintf(" Pertahankan momentum dengan fokus pada modul %s hari ini.", nextModule)
+	}
+
+	return insightType, message
+}
+
+// nextInProgressModule returns the first in-progress module and its course
+func nextInProgressModule(courses []models.Course) (string, string) {
+	for _, course := range courses {
+		for _, module := range course.Modules {
+			if module.Status == ModuleInProgress {
+				return module.Title, course.Title
+			}
+		}
+	}
+	return "", ""
+}
+
+func sumMinutes(activities []models.ActivityLog) int {
+	total := 0
+	for _, activity := range activities {
+		total += activity.Duration
+	}
+	return total
+}
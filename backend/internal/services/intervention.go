@@ -0,0 +1,696 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/events"
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+// Intervention statuses
+const (
+	InterventionDraft        = "draft"
+	InterventionSent         = "sent"
+	InterventionScheduled    = "scheduled"
+	InterventionAcknowledged = "acknowledged"
+	InterventionCompleted    = "completed"
+	InterventionCancelled    = "cancelled"
+)
+
+// Intervention types a mentor can create
+var interventionTypes = []string{"reminder", "meeting", "resource"}
+
+const (
+	// Recorded as the user of transitions made by background jobs
+	systemUserID             = "system"
+	defaultOutcomeWindowDays = 7
+	maxOutcomeWindowDays     = 60
+)
+
+var (
+	ErrInterventionNotFound  = errors.New("intervention not found")
+	ErrInterventionForbidden = errors.New("intervention belongs to another user")
+)
+
+// Statuses a mentor may move an intervention to, by current status
+var mentorTransitions = map[string][]string{
+	InterventionDraft:        {InterventionSent, InterventionScheduled, InterventionCancelled},
+	InterventionScheduled:    {InterventionSent, InterventionCompleted, InterventionCancelled},
+	InterventionSent:         {InterventionCompleted, InterventionCancelled},
+	InterventionAcknowledged: {InterventionCompleted, InterventionCancelled},
+}
+
+// Statuses a student may move an intervention to, by current status
+var studentTransitions = map[string][]string{
+	InterventionSent:      {InterventionAcknowledged},
+	InterventionScheduled: {InterventionAcknowledged},
+}
+
+var mockInterventionsMu sync.Mutex
+
+type InterventionService struct {
+	interventionRepo    *repository.InterventionRepository
+	templateRepo        *repository.InterventionTemplateRepository
+	settingsRepo        *repository.SettingsRepository
+	userRepo            *repository.UserRepository
+	activityRepo        *repository.ActivityRepository
+	authService         *AuthService
+	courseService       *CourseService
+	riskService         *RiskService
+	notificationService *NotificationService
+	messageService      *MessageService
+}
+
+func NewInterventionService() *InterventionService {
+	return &InterventionService{
+		interventionRepo:    repository.NewInterventionRepository(),
+		templateRepo:        repository.NewInterventionTemplateRepository(),
+		settingsRepo:        repository.NewSettingsRepository(),
+		userRepo:            repository.NewUserRepository(),
+		activityRepo:        repository.NewActivityRepository(),
+		authService:         NewAuthService(),
+		courseService:       NewCourseService(),
+		riskService:         NewRiskService(),
+		notificationService: NewNotificationService(),
+		messageService:      NewMessageService(),
+	}
+}
+
+// Create adds an intervention for one of the mentor's students. Unless it is a
+// draft it is delivered right away: as scheduled when it has a future date,
+// as sent otherwise. Without a message the template is rendered for the student.
+func (s *InterventionService) Create(mentorID string, req models.CreateInterventionRequest) (*models.Intervention, error) {
+	if req.TemplateID != "" && strings.TrimSpace(req.Message) == "" {
+		rendered, err := s.PreviewBulk(mentorID, models.BulkInterventionRequest{
+			TemplateID: req.TemplateID,
+			Type:       req.Type,
+			Filter:     models.StudentFilter{StudentIDs: []string{req.StudentID}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(rendered) == 0 {
+			return nil, ErrInterventionForbidden
+		}
+		req.Type = rendered[0].Type
+		req.Message = rendered[0].Message
+	}
+	if !slices.Contains(interventionTypes, req.Type) {
+		return nil, fmt.Errorf("type must be one of %s", strings.Join(interventionTypes, ", "))
+	}
+	if strings.TrimSpace(req.Message) == "" {
+		return nil, fmt.Errorf("message is required")
+	}
+	if req.OutcomeWindowDays < 0 || req.OutcomeWindowDays > maxOutcomeWindowDays {
+		return nil, fmt.Errorf("outcomeWindowDays must be between 1 and %d, or 0 for the default of %d", maxOutcomeWindowDays, defaultOutcomeWindowDays)
+	}
+
+	student := s.findAssignedStudent(mentorID, req.StudentID)
+	if student == nil {
+		return nil, ErrInterventionForbidden
+	}
+
+	now := time.Now()
+	intervention := &models.Intervention{
+		StudentID:         req.StudentID,
+		StudentName:       req.StudentName,
+		MentorID:          mentorID,
+		Type:              req.Type,
+		Message:           req.Message,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		ScheduledDate:     req.ScheduledDate,
+		Attachments:       req.Attachments,
+		Transitions:       []models.InterventionTransition{},
+		OutcomeWindowDays: req.OutcomeWindowDays,
+	}
+	if intervention.StudentName == "" {
+		intervention.StudentName = student.Name
+	}
+	if intervention.OutcomeWindowDays == 0 {
+		intervention.OutcomeWindowDays = defaultOutcomeWindowDays
+	}
+
+	status := InterventionDraft
+	if !req.Draft {
+		status = InterventionSent
+		if req.ScheduledDate != nil && req.ScheduledDate.After(now) {
+			status = InterventionScheduled
+		}
+	}
+	s.applyTransition(intervention, status, mentorID, now)
+
+	// Try Firestore first
+	if s.interventionRepo.IsFirestoreAvailable() {
+		if err := s.interventionRepo.Create(intervention); err == nil {
+			s.notifyTransition(intervention, "")
+			publishIntervention(intervention)
+			return intervention, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockInterventionsMu.Lock()
+	intervention.ID = fmt.Sprintf("%d", len(mockInterventions)+1)
+	mockInterventions = append(mockInterventions, *intervention)
+	mockInterventionsMu.Unlock()
+
+	s.notifyTransition(intervention, "")
+	publishIntervention(intervention)
+	return intervention, nil
+}
+
+// GetForMentor returns one of the mentor's interventions
+func (s *InterventionService) GetForMentor(mentorID, interventionID string) (*models.Intervention, error) {
+	intervention, err := s.find(interventionID)
+	if err != nil {
+		return nil, err
+	}
+	if intervention.MentorID != mentorID {
+		return nil, ErrInterventionForbidden
+	}
+	return intervention, nil
+}
+
+// ListByMentor returns the mentor's interventions, newest first
+func (s *InterventionService) ListByMentor(mentorID string) []models.Intervention {
+	// Try Firestore first
+	if s.interventionRepo.IsFirestoreAvailable() {
+		interventions, err := s.interventionRepo.FindByMentorID(mentorID)
+		if err == nil {
+			return interventions
+		}
+	}
+
+	// Fallback to mock data
+	return filterMockInterventions(func(i models.Intervention) bool { return i.MentorID == mentorID })
+}
+
+// ListByStudent returns the interventions addressed to a student, newest
+// first. Drafts are left out unless includeDrafts is set.
+func (s *InterventionService) ListByStudent(studentID string, includeDrafts bool) []models.Intervention {
+	var interventions []models.Intervention
+
+	// Try Firestore first
+	found := false
+	if s.interventionRepo.IsFirestoreAvailable() {
+		stored, err := s.interventionRepo.FindByStudentID(studentID)
+		if err == nil {
+			interventions = stored
+			found = true
+		}
+	}
+
+	// Fallback to mock data
+	if !found {
+		interventions = filterMockInterventions(func(i models.Intervention) bool { return i.StudentID == studentID })
+	}
+
+	if includeDrafts {
+		return interventions
+	}
+	visible := []models.Intervention{}
+	for _, intervention := range interventions {
+		if intervention.Status != InterventionDraft {
+			visible = append(visible, intervention)
+		}
+	}
+	return visible
+}
+
+// UpdateStatus moves one of the mentor's interventions to a new status. A
+// response recorded by the mentor (e.g. the student's answer in a meeting) is
+// stored like a response from the student.
+func (s *InterventionService) UpdateStatus(mentorID, interventionID string, req models.UpdateInterventionRequest) (*models.Intervention, error) {
+	intervention, err := s.GetForMentor(mentorID, interventionID)
+	if err != nil {
+		return nil, err
+	}
+	if !transitionAllowed(mentorTransitions, intervention.Status, req.Status) {
+		return nil, fmt.Errorf("cannot change intervention from %s to %s", intervention.Status, req.Status)
+	}
+	if intervention.Status == InterventionDraft && s.findAssignedStudent(mentorID, intervention.StudentID) == nil {
+		return nil, ErrInterventionForbidden
+	}
+
+	now := time.Now()
+	from := intervention.Status
+	s.applyTransition(intervention, req.Status, mentorID, now)
+	if req.Response != nil {
+		intervention.Response = req.Response
+		intervention.RespondedAt = &now
+	}
+
+	if err := s.save(intervention); err != nil {
+		return nil, err
+	}
+	s.notifyTransition(intervention, from)
+	return intervention, nil
+}
+
+// Acknowledge marks an intervention as seen by its student
+func (s *InterventionService) Acknowledge(studentID, interventionID string) (*models.Intervention, error) {
+	intervention, err := s.getForStudent(studentID, interventionID)
+	if err != nil {
+		return nil, err
+	}
+	if !transitionAllowed(studentTransitions, intervention.Status, InterventionAcknowledged) {
+		return nil, fmt.Errorf("cannot acknowledge an intervention that is %s", intervention.Status)
+	}
+
+	from := intervention.Status
+	s.applyTransition(intervention, InterventionAcknowledged, studentID, time.Now())
+	if err := s.save(intervention); err != nil {
+		return nil, err
+	}
+	s.notifyTransition(intervention, from)
+	return intervention, nil
+}
+
+// Respond stores the student's answer to an intervention, acknowledging it
+// first if needed
+func (s *InterventionService) Respond(studentID, interventionID, response string) (*models.Intervention, error) {
+	response = strings.TrimSpace(response)
+	if response == "" {
+		return nil, fmt.Errorf("response is required")
+	}
+
+	intervention, err := s.getForStudent(studentID, interventionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if intervention.Status != InterventionAcknowledged {
+		if !transitionAllowed(studentTransitions, intervention.Status, InterventionAcknowledged) {
+			return nil, fmt.Errorf("cannot respond to an intervention that is %s", intervention.Status)
+		}
+		s.applyTransition(intervention, InterventionAcknowledged, studentID, now)
+	}
+	intervention.Response = &response
+	intervention.RespondedAt = &now
+	intervention.UpdatedAt = now
+
+	if err := s.save(intervention); err != nil {
+		return nil, err
+	}
+	s.notificationService.Notify(intervention.MentorID, CategoryMentorMessages, "info", "Balasan Intervensi",
+		fmt.Sprintf("%s membalas intervensi Anda: %q", intervention.StudentName, response))
+	s.messageService.PostInterventionResponse(intervention, response)
+	return intervention, nil
+}
+
+// FindMeetingIntervention returns the meeting intervention a booking between
+// mentor and student belongs to: the given one, or without interventionID the
+// newest open meeting intervention not linked to a meeting yet (nil if none)
+func (s *InterventionService) FindMeetingIntervention(interventionID, mentorID, studentID string) (*models.Intervention, error) {
+	if interventionID == "" {
+		for _, candidate := range s.ListByStudent(studentID, false) {
+			if candidate.MentorID == mentorID && candidate.Type == "meeting" &&
+				openForMeeting(candidate.Status) && candidate.MeetingID == "" {
+				return &candidate, nil
+			}
+		}
+		return nil, nil
+	}
+
+	intervention, err := s.find(interventionID)
+	if err != nil {
+		return nil, err
+	}
+	if intervention.MentorID != mentorID || intervention.StudentID != studentID {
+		return nil, ErrInterventionForbidden
+	}
+	if intervention.Type != "meeting" || !openForMeeting(intervention.Status) {
+		return nil, fmt.Errorf("intervention %s is not an open meeting intervention", interventionID)
+	}
+	return intervention, nil
+}
+
+// LinkMeeting attaches a booked meeting to its intervention and moves the
+// intervention's scheduled date to the meeting
+func (s *InterventionService) LinkMeeting(interventionID string, meeting *models.Meeting) error {
+	intervention, err := s.find(interventionID)
+	if err != nil {
+		return err
+	}
+
+	start := meeting.Start
+	intervention.MeetingID = meeting.ID
+	intervention.ScheduledDate = &start
+	intervention.UpdatedAt = time.Now()
+	return s.save(intervention)
+}
+
+// UnlinkMeeting detaches a cancelled meeting from its intervention
+func (s *InterventionService) UnlinkMeeting(interventionID string) error {
+	intervention, err := s.find(interventionID)
+	if err != nil {
+		return err
+	}
+	intervention.MeetingID = ""
+	intervention.UpdatedAt = time.Now()
+	return s.save(intervention)
+}
+
+// CompleteForMeeting completes the intervention of a meeting that was held
+func (s *InterventionService) CompleteForMeeting(interventionID string) error {
+	intervention, err := s.find(interventionID)
+	if err != nil {
+		return err
+	}
+	if !openForMeeting(intervention.Status) {
+		return nil
+	}
+
+	s.applyTransition(intervention, InterventionCompleted, systemUserID, time.Now())
+	return s.save(intervention)
+}
+
+// EvaluateOutcomes records the outcome of every delivered intervention whose
+// outcome window has passed. It returns the number of interventions evaluated.
+func (s *InterventionService) EvaluateOutcomes() (int, error) {
+	candidates := s.findPendingOutcomes()
+
+	var errs []error
+	evaluated := 0
+	now := time.Now()
+	for i := range candidates {
+		intervention := &candidates[i]
+		windowDays := outcomeWindowDays(intervention)
+		if intervention.SentAt == nil || now.Before(intervention.SentAt.AddDate(0, 0, windowDays)) {
+			continue
+		}
+
+		intervention.Outcome = s.measureOutcome(intervention, windowDays)
+		if err := s.save(intervention); err != nil {
+			errs = append(errs, fmt.Errorf("intervention %s: %w", intervention.ID, err))
+			continue
+		}
+		evaluated++
+
+		if intervention.Outcome.ReEngaged {
+			s.notificationService.Notify(intervention.MentorID, CategorySystem, "success", "Intervensi Berhasil",
+				fmt.Sprintf("%s kembali aktif setelah %s", intervention.StudentName, intervention.Type))
+		}
+	}
+	return evaluated, errors.Join(errs...)
+}
+
+// measureOutcome compares the windowDays before the intervention was sent
+// with the windowDays after it. The student counts as re-engaged when they
+// studied more or completed modules after the intervention. It only reads the
+// activity log and the stored risk snapshots, so a report can measure any
+// window without changing the student's risk.
+func (s *InterventionService) measureOutcome(intervention *models.Intervention, windowDays int) *models.InterventionOutcome {
+	sentAt := *intervention.SentAt
+	end := sentAt.AddDate(0, 0, windowDays)
+
+	outcome := &models.InterventionOutcome{
+		EvaluatedAt:   time.Now(),
+		WindowDays:    windowDays,
+		MinutesBefore: sumMinutes(findUserActivitiesBetween(s.activityRepo, intervention.StudentID, sentAt.AddDate(0, 0, -windowDays), sentAt)),
+		MinutesAfter:  sumMinutes(findUserActivitiesBetween(s.activityRepo, intervention.StudentID, sentAt, end)),
+	}
+
+	// The student at the end of the window: the first snapshot from that day
+	// on, or the last one before it. Without a captured baseline the last
+	// snapshot before sending is used, and no change is assumed when there is
+	// none.
+	snapshots := s.riskService.getSnapshots(intervention.StudentID, int(time.Since(sentAt).Hours()/24)+windowDays+1)
+	sentDate, endDate := sentAt.Format(dateLayout), end.Format(dateLayout)
+	var before, after *models.RiskSnapshot
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		if snapshot.Date <= sentDate {
+			before = snapshot
+		}
+		if after == nil || after.Date < endDate {
+			after = snapshot
+		}
+	}
+
+	riskBefore, riskAfter := -1, -1
+	modulesBefore, modulesAfter := -1, -1
+	if before != nil {
+		riskBefore, modulesBefore = before.Score, before.CompletedModules
+	}
+	if after != nil {
+		riskAfter, modulesAfter = after.Score, after.CompletedModules
+	}
+	if intervention.RiskScoreAtSend != nil {
+		riskBefore = *intervention.RiskScoreAtSend
+	}
+	if intervention.ModulesAtSend != nil {
+		modulesBefore = *intervention.ModulesAtSend
+	}
+	if riskBefore < 0 {
+		riskBefore = riskAfter
+	}
+	if riskAfter < 0 {
+		riskAfter = riskBefore
+	}
+	if modulesBefore < 0 {
+		modulesBefore = modulesAfter
+	}
+	if modulesAfter < 0 {
+		modulesAfter = modulesBefore
+	}
+	outcome.RiskBefore = max(riskBefore, 0)
+	outcome.RiskAfter = max(riskAfter, 0)
+	outcome.ModulesBefore = max(modulesBefore, 0)
+	outcome.ModulesAfter = max(modulesAfter, 0)
+
+	outcome.RiskDelta = outcome.RiskAfter - outcome.RiskBefore
+	outcome.ReEngaged = outcome.MinutesAfter > outcome.MinutesBefore || outcome.ModulesAfter > outcome.ModulesBefore
+	return outcome
+}
+
+// applyTransition sets the new status, its timestamp and the transition log
+// entry. Delivering an intervention also captures the student's baseline.
+func (s *InterventionService) applyTransition(intervention *models.Intervention, status, userID string, at time.Time) {
+	intervention.Transitions = append(intervention.Transitions, models.InterventionTransition{
+		From:   intervention.Status,
+		To:     status,
+		At:     at,
+		UserID: userID,
+	})
+	intervention.Status = status
+	intervention.UpdatedAt = at
+
+	switch status {
+	case InterventionSent, InterventionScheduled:
+		if intervention.SentAt == nil {
+			intervention.SentAt = &at
+			s.captureBaseline(intervention)
+		}
+	case InterventionAcknowledged:
+		intervention.AcknowledgedAt = &at
+	case InterventionCompleted:
+		intervention.CompletedAt = &at
+	case InterventionCancelled:
+		intervention.CancelledAt = &at
+	}
+}
+
+// outcomeWindowDays returns the days after sending the intervention's outcome
+// is measured over
+func outcomeWindowDays(intervention *models.Intervention) int {
+	if intervention.OutcomeWindowDays <= 0 {
+		return defaultOutcomeWindowDays
+	}
+	return intervention.OutcomeWindowDays
+}
+
+func (s *InterventionService) captureBaseline(intervention *models.Intervention) {
+	if user, err := s.authService.GetUserByID(intervention.StudentID); err == nil {
+		score := user.RiskScore
+		intervention.RiskScoreAtSend = &score
+	}
+	modules := s.completedModules(intervention.StudentID)
+	intervention.ModulesAtSend = &modules
+}
+
+// notifyTransition tells the other party about a status change
+func (s *InterventionService) notifyTransition(intervention *models.Intervention, from string) {
+	switch intervention.Status {
+	case InterventionSent:
+		if from == InterventionScheduled {
+			return
+		}
+		s.notificationService.Notify(intervention.StudentID, CategoryMentorMessages, "info", "Pesan dari Mentor", intervention.Message)
+		s.messageService.PostIntervention(intervention)
+	case InterventionScheduled:
+		message := intervention.Message
+		if intervention.ScheduledDate != nil {
+			message = fmt.Sprintf("%s (%s)", message, intervention.ScheduledDate.Format("02 Jan 2006 15:04"))
+		}
+		s.notificationService.Notify(intervention.StudentID, CategoryMentorMessages, "info", "Jadwal dari Mentor", message)
+		s.messageService.PostIntervention(intervention)
+	case InterventionAcknowledged:
+		s.notificationService.Notify(intervention.MentorID, CategoryMentorMessages, "info", "Intervensi Dibaca",
+			fmt.Sprintf("%s telah membaca intervensi Anda", intervention.StudentName))
+	case InterventionCancelled:
+		if from != InterventionDraft {
+			s.notificationService.Notify(intervention.StudentID, CategoryMentorMessages, "warning", "Intervensi Dibatalkan",
+				"Mentor Anda membatalkan intervensi: "+intervention.Message)
+		}
+	}
+}
+
+func (s *InterventionService) getForStudent(studentID, interventionID string) (*models.Intervention, error) {
+	intervention, err := s.find(interventionID)
+	if err != nil {
+		return nil, err
+	}
+	if intervention.StudentID != studentID {
+		return nil, ErrInterventionForbidden
+	}
+	if intervention.Status == InterventionDraft {
+		return nil, ErrInterventionNotFound
+	}
+	return intervention, nil
+}
+
+func (s *InterventionService) find(interventionID string) (*models.Intervention, error) {
+	// Try Firestore first
+	if s.interventionRepo.IsFirestoreAvailable() {
+		intervention, err := s.interventionRepo.FindByID(interventionID)
+		if err == nil {
+			return intervention, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockInterventionsMu.Lock()
+	defer mockInterventionsMu.Unlock()
+	for _, intervention := range mockInterventions {
+		if intervention.ID == interventionID {
+			return &intervention, nil
+		}
+	}
+	return nil, ErrInterventionNotFound
+}
+
+func (s *InterventionService) save(intervention *models.Intervention) error {
+	// Try Firestore first
+	if s.interventionRepo.IsFirestoreAvailable() {
+		if err := s.interventionRepo.Save(intervention); err == nil {
+			publishIntervention(intervention)
+			return nil
+		}
+	}
+
+	// Fallback to mock data
+	mockInterventionsMu.Lock()
+	found := false
+	for i := range mockInterventions {
+		if mockInterventions[i].ID == intervention.ID {
+			mockInterventions[i] = *intervention
+			found = true
+			break
+		}
+	}
+	mockInterventionsMu.Unlock()
+
+	if !found {
+		return ErrInterventionNotFound
+	}
+	publishIntervention(intervention)
+	return nil
+}
+
+// publishIntervention streams the intervention to its mentor, and to the
+// student once it is no longer a draft
+func publishIntervention(intervention *models.Intervention) {
+	userIDs := []string{intervention.MentorID}
+	if intervention.Status != InterventionDraft {
+		userIDs = append(userIDs, intervention.StudentID)
+	}
+	events.Publish(events.TypeIntervention, intervention, userIDs...)
+}
+
+// findPendingOutcomes returns delivered interventions without an outcome yet
+func (s *InterventionService) findPendingOutcomes() []models.Intervention {
+	var pending []models.Intervention
+	for _, intervention := range s.findDelivered() {
+		if intervention.Outcome == nil {
+			pending = append(pending, intervention)
+		}
+	}
+	return pending
+}
+
+// findDelivered returns every intervention that reached its student and was not cancelled
+func (s *InterventionService) findDelivered() []models.Intervention {
+	delivered := []string{InterventionSent, InterventionScheduled, InterventionAcknowledged, InterventionCompleted}
+
+	// Try Firestore first
+	if s.interventionRepo.IsFirestoreAvailable() {
+		interventions, err := s.interventionRepo.FindByStatuses(delivered)
+		if err == nil {
+			return interventions
+		}
+	}
+
+	// Fallback to mock data
+	return filterMockInterventions(func(i models.Intervention) bool {
+		return slices.Contains(delivered, i.Status)
+	})
+}
+
+func (s *InterventionService) findAssignedStudent(mentorID, studentID string) *models.User {
+	for _, student := range findAssignedStudents(s.userRepo, mentorID) {
+		if student.ID == studentID {
+			return &student
+		}
+	}
+	return nil
+}
+
+func (s *InterventionService) completedModules(userID string) int {
+	completed := 0
+	for _, course := range s.courseService.GetUserCourses(userID) {
+		for _, module := range course.Modules {
+			if module.Status == ModuleCompleted {
+				completed++
+			}
+		}
+	}
+	return completed
+}
+
+func openForMeeting(status string) bool {
+	return status == InterventionSent || status == InterventionScheduled || status == InterventionAcknowledged
+}
+
+func transitionAllowed(transitions map[string][]string, from, to string) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// filterMockInterventions returns the matching mock interventions, newest first
+func filterMockInterventions(match func(models.Intervention) bool) []models.Intervention {
+	mockInterventionsMu.Lock()
+	defer mockInterventionsMu.Unlock()
+
+	interventions := []models.Intervention{}
+	for _, intervention := range mockInterventions {
+		if match(intervention) {
+			interventions = append(interventions, intervention)
+		}
+	}
+	sort.Slice(interventions, func(i, j int) bool { return interventions[i].CreatedAt.After(interventions[j].CreatedAt) })
+	return interventions
+}
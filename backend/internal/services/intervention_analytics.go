@@ -0,0 +1,203 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+const (
+	defaultEffectivenessDays = 90
+	maxEffectivenessDays     = 365
+)
+
+// EffectivenessQuery selects the interventions of an effectiveness report.
+// An empty MentorID covers every mentor.
+type EffectivenessQuery struct {
+	MentorID   string
+	WindowDays int
+	From       string
+	To         string
+}
+
+// effectivenessSums accumulates outcomes before they are averaged
+type effectivenessSums struct {
+	stats   models.EffectivenessStats
+	minutes [2]int
+	risk    int
+	modules int
+}
+
+func (a *effectivenessSums) add(outcome *models.InterventionOutcome) {
+	a.stats.Interventions++
+	if outcome.ReEngaged {
+		a.stats.ReEngaged++
+	}
+	a.minutes[0] += outcome.MinutesBefore
+	a.minutes[1] += outcome.MinutesAfter
+	a.risk += outcome.RiskDelta
+	a.modules += outcome.ModulesAfter - outcome.ModulesBefore
+}
+
+func (a *effectivenessSums) result() models.EffectivenessStats {
+	stats := a.stats
+	if n := float64(stats.Interventions); n > 0 {
+		stats.ReEngagementRate = round1(float64(stats.ReEngaged) * 100 / n)
+		stats.AvgMinutesBefore = round1(float64(a.minutes[0]) / n)
+		stats.AvgMinutesAfter = round1(float64(a.minutes[1]) / n)
+		stats.AvgMinutesChange = round1(float64(a.minutes[1]-a.minutes[0]) / n)
+		stats.AvgRiskDelta = round1(float64(a.risk) / n)
+		stats.AvgModulesGained = round1(float64(a.modules) / n)
+	}
+	return stats
+}
+
+// GetEffectiveness measures the interventions sent in the query's date range
+// over its window and aggregates them per type, per student risk level, per
+// type and risk level, and per mentor. Interventions whose window has not
+// passed yet, or whose outcome has not been recorded yet, are only counted as
+// pending.
+func (s *InterventionService) GetEffectiveness(query EffectivenessQuery) (*models.EffectivenessReport, error) {
+	if query.WindowDays == 0 {
+		query.WindowDays = defaultOutcomeWindowDays
+	}
+	if query.WindowDays < 1 || query.WindowDays > maxOutcomeWindowDays {
+		return nil, fmt.Errorf("windowDays must be between 1 and %d", maxOutcomeWindowDays)
+	}
+
+	to := time.Now()
+	if query.To != "" {
+		parsed, err := time.Parse(dateLayout, query.To)
+		if err != nil {
+			return nil, fmt.Errorf("to must be a date in YYYY-MM-DD format")
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -defaultEffectivenessDays)
+	if query.From != "" {
+		parsed, err := time.Parse(dateLayout, query.From)
+		if err != nil {
+			return nil, fmt.Errorf("from must be a date in YYYY-MM-DD format")
+		}
+		from = parsed
+	}
+	if from.After(to) || to.Sub(from) > maxEffectivenessDays*24*time.Hour {
+		return nil, fmt.Errorf("from must be before to and at most %d days earlier", maxEffectivenessDays)
+	}
+	fromDate, toDate := from.Format(dateLayout), to.Format(dateLayout)
+
+	report := &models.EffectivenessReport{
+		MentorID:           query.MentorID,
+		WindowDays:         query.WindowDays,
+		From:               fromDate,
+		To:                 toDate,
+		ByType:             []models.EffectivenessStats{},
+		ByRiskLevel:        []models.EffectivenessStats{},
+		ByTypeAndRiskLevel: []models.EffectivenessStats{},
+		ByMentor:           []models.EffectivenessStats{},
+		BestTypeByRisk:     make(map[string]string),
+		GeneratedAt:        time.Now(),
+	}
+
+	config := s.riskService.GetConfig()
+	overall := &effectivenessSums{}
+	groups := map[string]map[string]*effectivenessSums{
+		"type": {}, "risk": {}, "typeRisk": {}, "mentor": {},
+	}
+	group := func(kind, key string, stats models.EffectivenessStats) *effectivenessSums {
+		if groups[kind][key] == nil {
+			groups[kind][key] = &effectivenessSums{stats: stats}
+		}
+		return groups[kind][key]
+	}
+
+	now := time.Now()
+	for _, intervention := range s.findDelivered() {
+		if query.MentorID != "" && intervention.MentorID != query.MentorID {
+			continue
+		}
+		if intervention.SentAt == nil {
+			continue
+		}
+		sentDate := intervention.SentAt.Format(dateLayout)
+		if sentDate < fromDate || sentDate > toDate {
+			continue
+		}
+		if now.Before(intervention.SentAt.AddDate(0, 0, query.WindowDays)) {
+			report.Pending++
+			continue
+		}
+
+		// Outcomes of the intervention's own window are recorded by the
+		// intervention-outcomes job; other windows are measured from the
+		// stored snapshots
+		outcome := intervention.Outcome
+		if outcome == nil || outcome.WindowDays != query.WindowDays {
+			if outcome == nil && query.WindowDays == outcomeWindowDays(&intervention) {
+				report.Pending++
+				continue
+			}
+			outcome = s.measureOutcome(&intervention, query.WindowDays)
+		}
+		level := riskLevel(outcome.RiskBefore, config)
+
+		overall.add(outcome)
+		group("type", intervention.Type, models.EffectivenessStats{Type: intervention.Type}).add(outcome)
+		group("risk", level, models.EffectivenessStats{RiskLevel: level}).add(outcome)
+		group("typeRisk", intervention.Type+"/"+level, models.EffectivenessStats{Type: intervention.Type, RiskLevel: level}).add(outcome)
+		group("mentor", intervention.MentorID, models.EffectivenessStats{MentorID: intervention.MentorID}).add(outcome)
+	}
+
+	report.Overall = overall.result()
+	report.ByType = sortedEffectiveness(groups["type"])
+	report.ByRiskLevel = sortedEffectiveness(groups["risk"])
+	report.ByTypeAndRiskLevel = sortedEffectiveness(groups["typeRisk"])
+	report.ByMentor = sortedEffectiveness(groups["mentor"])
+	for i := range report.ByMentor {
+		if mentor, err := s.authService.GetUserByID(report.ByMentor[i].MentorID); err == nil {
+			report.ByMentor[i].MentorName = mentor.Name
+		}
+	}
+
+	// The type that re-engaged students of each risk level best, ties broken by risk reduction
+	for _, stats := range report.ByTypeAndRiskLevel {
+		best, ok := groups["typeRisk"][report.BestTypeByRisk[stats.RiskLevel]+"/"+stats.RiskLevel]
+		if !ok {
+			report.BestTypeByRisk[stats.RiskLevel] = stats.Type
+			continue
+		}
+		current := best.result()
+		if stats.ReEngagementRate > current.ReEngagementRate ||
+			(stats.ReEngagementRate == current.ReEngagementRate && stats.AvgRiskDelta < current.AvgRiskDelta) {
+			report.BestTypeByRisk[stats.RiskLevel] = stats.Type
+		}
+	}
+
+	return report, nil
+}
+
+// sortedEffectiveness returns the group results ordered by type, risk level and mentor
+func sortedEffectiveness(groups map[string]*effectivenessSums) []models.EffectivenessStats {
+	stats := make([]models.EffectivenessStats, 0, len(groups))
+	for _, sums := range groups {
+		stats = append(stats, sums.result())
+	}
+	levelOrder := map[string]int{"low": 0, "medium": 1, "high": 2}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Type != stats[j].Type {
+			return stats[i].Type < stats[j].Type
+		}
+		if stats[i].RiskLevel != stats[j].RiskLevel {
+			return levelOrder[stats[i].RiskLevel] < levelOrder[stats[j].RiskLevel]
+		}
+		return stats[i].MentorID < stats[j].MentorID
+	})
+	return stats
+}
+
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
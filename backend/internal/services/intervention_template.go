@@ -0,0 +1,462 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+// Most students a single bulk request may address
+const maxBulkInterventions = 100
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrTemplateReadOnly = errors.New("template is built in or belongs to another mentor")
+)
+
+// templatePlaceholder matches {{name}} with optional inner spaces
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// Placeholders a template may use
+var templateVariables = []string{"studentName", "firstName", "courseName", "daysInactive", "riskScore", "mentorName"}
+
+// Templates every mentor can use
+var builtInTemplates = []models.InterventionTemplate{
+	{
+		ID:              "builtin-inactivity",
+		Name:            "Pengingat tidak aktif",
+		Type:            "reminder",
+		DefaultLanguage: "id",
+		Variants: map[string]string{
+			"id": "Halo {{firstName}}, saya perhatikan Anda belum belajar selama {{daysInactive}} hari. Yuk lanjutkan {{courseName}} hari ini! Kabari saya jika ada kendala.",
+			"en": "Hi {{firstName}}, I noticed you haven't studied for {{daysInactive}} days. Let's continue {{courseName}} today! Let me know if anything is holding you back.",
+		},
+	},
+	{
+		ID:              "builtin-risk-meeting",
+		Name:            "Ajakan konsultasi",
+		Type:            "meeting",
+		DefaultLanguage: "id",
+		Variants: map[string]string{
+			"id": "Halo {{firstName}}, skor risiko belajar Anda saat ini {{riskScore}}. Mari kita jadwalkan sesi konsultasi untuk membahas progres Anda di {{courseName}}.",
+			"en": "Hi {{firstName}}, your learning risk score is currently {{riskScore}}. Let's schedule a session to talk about your progress in {{courseName}}.",
+		},
+	},
+	{
+		ID:              "builtin-resource",
+		Name:            "Materi tambahan",
+		Type:            "resource",
+		DefaultLanguage: "id",
+		Variants: map[string]string{
+			"id": "Halo {{firstName}}, saya lampirkan materi tambahan untuk membantu Anda di {{courseName}}. Semoga bermanfaat! - {{mentorName}}",
+			"en": "Hi {{firstName}}, I've attached extra material to help you with {{courseName}}. Hope it helps! - {{mentorName}}",
+		},
+	},
+}
+
+// Intervention templates for development (used as fallback when Firebase is not configured)
+var (
+	mockTemplates   []models.InterventionTemplate
+	mockTemplatesMu sync.Mutex
+	// mockTemplateSeq numbers mock templates, so IDs stay unique after deletes
+	mockTemplateSeq int
+)
+
+// GetTemplates returns the built-in templates followed by the mentor's own
+func (s *InterventionService) GetTemplates(mentorID string) []models.InterventionTemplate {
+	templates := []models.InterventionTemplate{}
+	for _, template := range builtInTemplates {
+		template.BuiltIn = true
+		templates = append(templates, template)
+	}
+	return append(templates, s.findTemplates(mentorID)...)
+}
+
+// CreateTemplate stores a new template of the mentor
+func (s *InterventionService) CreateTemplate(mentorID string, req models.SaveInterventionTemplateRequest) (*models.InterventionTemplate, error) {
+	template := &models.InterventionTemplate{
+		MentorID:  mentorID,
+		CreatedAt: time.Now(),
+	}
+	if err := applyTemplateRequest(template, req); err != nil {
+		return nil, err
+	}
+
+	// Try Firestore first
+	if s.templateRepo.IsFirestoreAvailable() {
+		if err := s.templateRepo.Create(template); err != nil {
+			return nil, err
+		}
+		return template, nil
+	}
+
+	// Fallback to mock data
+	mockTemplatesMu.Lock()
+	defer mockTemplatesMu.Unlock()
+	mockTemplateSeq++
+	template.ID = fmt.Sprintf("%d", mockTemplateSeq)
+	mockTemplates = append(mockTemplates, *template)
+	return template, nil
+}
+
+// UpdateTemplate replaces the content of one of the mentor's templates
+func (s *InterventionService) UpdateTemplate(mentorID, templateID string, req models.SaveInterventionTemplateRequest) (*models.InterventionTemplate, error) {
+	template, err := s.findTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.BuiltIn || template.MentorID != mentorID {
+		return nil, ErrTemplateReadOnly
+	}
+	if err := applyTemplateRequest(template, req); err != nil {
+		return nil, err
+	}
+
+	// Try Firestore first
+	if s.templateRepo.IsFirestoreAvailable() {
+		if err := s.templateRepo.Save(template); err != nil {
+			return nil, err
+		}
+		return template, nil
+	}
+
+	// Fallback to mock data
+	mockTemplatesMu.Lock()
+	defer mockTemplatesMu.Unlock()
+	for i := range mockTemplates {
+		if mockTemplates[i].ID == templateID {
+			mockTemplates[i] = *template
+		}
+	}
+	return template, nil
+}
+
+// DeleteTemplate removes one of the mentor's templates
+func (s *InterventionService) DeleteTemplate(mentorID, templateID string) error {
+	template, err := s.findTemplate(templateID)
+	if err != nil {
+		return err
+	}
+	if template.BuiltIn || template.MentorID != mentorID {
+		return ErrTemplateReadOnly
+	}
+
+	// Try Firestore first
+	if s.templateRepo.IsFirestoreAvailable() {
+		return s.templateRepo.Delete(templateID)
+	}
+
+	// Fallback to mock data
+	mockTemplatesMu.Lock()
+	defer mockTemplatesMu.Unlock()
+	for i := range mockTemplates {
+		if mockTemplates[i].ID == templateID {
+			mockTemplates = append(mockTemplates[:i], mockTemplates[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// PreviewBulk renders the messages the filtered students would receive
+func (s *InterventionService) PreviewBulk(mentorID string, req models.BulkInterventionRequest) ([]models.RenderedIntervention, error) {
+	template, err := s.bulkTemplate(mentorID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	students := s.filterStudents(mentorID, req.Filter)
+	if len(students) > maxBulkInterventions {
+		return nil, fmt.Errorf("the filter matches %d students; at most %d are allowed", len(students), maxBulkInterventions)
+	}
+
+	mentorName := ""
+	if mentor, err := s.authService.GetUserByID(mentorID); err == nil {
+		mentorName = mentor.Name
+	}
+
+	rendered := []models.RenderedIntervention{}
+	for _, student := range students {
+		rendered = append(rendered, s.render(template, &student, req.Filter.CourseID, mentorName))
+	}
+	return rendered, nil
+}
+
+// CreateBulk creates one intervention per filtered student with their rendered
+// message. Students that fail are reported without stopping the others.
+func (s *InterventionService) CreateBulk(mentorID string, req models.BulkInterventionRequest) (*models.BulkInterventionResult, error) {
+	rendered, err := s.PreviewBulk(mentorID, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(rendered) == 0 {
+		return nil, fmt.Errorf("no students match the filter")
+	}
+
+	result := &models.BulkInterventionResult{
+		Created: []models.Intervention{},
+		Failed:  []models.BulkFailure{},
+	}
+	for _, message := range rendered {
+		intervention, err := s.Create(mentorID, models.CreateInterventionRequest{
+			StudentID:         message.StudentID,
+			StudentName:       message.StudentName,
+			Type:              message.Type,
+			Message:           message.Message,
+			ScheduledDate:     req.ScheduledDate,
+			Draft:             req.Draft,
+			OutcomeWindowDays: req.OutcomeWindowDays,
+		})
+		if err != nil {
+			result.Failed = append(result.Failed, models.BulkFailure{StudentID: message.StudentID, Error: err.Error()})
+			continue
+		}
+		result.Created = append(result.Created, *intervention)
+	}
+	return result, nil
+}
+
+// bulkTemplate returns the request's template, or an ad-hoc one built from
+// its message. An explicit type overrides the template's type.
+func (s *InterventionService) bulkTemplate(mentorID string, req models.BulkInterventionRequest) (*models.InterventionTemplate, error) {
+	var template *models.InterventionTemplate
+	if req.TemplateID != "" {
+		found, err := s.findTemplate(req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		if !found.BuiltIn && found.MentorID != mentorID {
+			return nil, ErrTemplateReadOnly
+		}
+		template = found
+	} else {
+		template = &models.InterventionTemplate{Type: req.Type}
+		if err := applyTemplateRequest(template, models.SaveInterventionTemplateRequest{
+			Name:     "bulk",
+			Type:     req.Type,
+			Variants: map[string]string{"id": req.Message},
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Type != "" {
+		if !slices.Contains(interventionTypes, req.Type) {
+			return nil, fmt.Errorf("type must be one of %s", strings.Join(interventionTypes, ", "))
+		}
+		template.Type = req.Type
+	}
+	return template, nil
+}
+
+// filterStudents returns the mentor's assigned students matching the filter
+func (s *InterventionService) filterStudents(mentorID string, filter models.StudentFilter) []models.User {
+	config := s.riskService.GetConfig()
+
+	var students []models.User
+	for _, student := range findAssignedStudents(s.userRepo, mentorID) {
+		if len(filter.StudentIDs) > 0 && !slices.Contains(filter.StudentIDs, student.ID) {
+			continue
+		}
+		if filter.RiskLevel != "" && riskLevel(student.RiskScore, config) != filter.RiskLevel {
+			continue
+		}
+		if student.RiskScore < filter.MinRiskScore {
+			continue
+		}
+		if filter.CourseID != "" && s.enrolledCourse(student.ID, filter.CourseID) == nil {
+			continue
+		}
+		if filter.MinDaysInactive > 0 {
+			loc := userLocation(loadUserSettings(s.settingsRepo, student.ID))
+			if daysInactive(&student, findUserActivities(s.activityRepo, student.ID), loc) < filter.MinDaysInactive {
+				continue
+			}
+		}
+		students = append(students, student)
+	}
+	return students
+}
+
+// render fills the template in for one student, in the student's language when
+// the template has a variant for it
+func (s *InterventionService) render(template *models.InterventionTemplate, student *models.User, courseID, mentorName string) models.RenderedIntervention {
+	settings := loadUserSettings(s.settingsRepo, student.ID)
+	language := template.DefaultLanguage
+	if _, ok := template.Variants[settings.Appearance.Language]; ok {
+		language = settings.Appearance.Language
+	}
+
+	var course *models.Course
+	if courseID != "" {
+		course = s.enrolledCourse(student.ID, courseID)
+	} else {
+		course = s.currentCourse(student.ID)
+	}
+	courseName := ""
+	if course != nil {
+		courseName = course.Title
+	}
+
+	inactive := daysInactive(student, findUserActivities(s.activityRepo, student.ID), userLocation(settings))
+	firstName, _, _ := strings.Cut(student.Name, " ")
+	values := map[string]string{
+		"studentName":  student.Name,
+		"firstName":    firstName,
+		"courseName":   courseName,
+		"daysInactive": strconv.Itoa(inactive),
+		"riskScore":    strconv.Itoa(student.RiskScore),
+		"mentorName":   mentorName,
+	}
+	message := templatePlaceholder.ReplaceAllStringFunc(template.Variants[language], func(match string) string {
+		return values[templatePlaceholder.FindStringSubmatch(match)[1]]
+	})
+
+	return models.RenderedIntervention{
+		StudentID:    student.ID,
+		StudentName:  student.Name,
+		Language:     language,
+		Type:         template.Type,
+		Message:      message,
+		CourseName:   courseName,
+		RiskScore:    student.RiskScore,
+		DaysInactive: inactive,
+	}
+}
+
+func (s *InterventionService) enrolledCourse(userID, courseID string) *models.Course {
+	for _, course := range s.courseService.GetUserCourses(userID) {
+		if course.ID == courseID {
+			return &course
+		}
+	}
+	return nil
+}
+
+// currentCourse returns the first unfinished enrolled course, or the first enrolled one
+func (s *InterventionService) currentCourse(userID string) *models.Course {
+	courses := s.courseService.GetUserCourses(userID)
+	for _, course := range courses {
+		if !courseCompleted(course) {
+			return &course
+		}
+	}
+	if len(courses) > 0 {
+		return &courses[0]
+	}
+	return nil
+}
+
+func (s *InterventionService) findTemplates(mentorID string) []models.InterventionTemplate {
+	var templates []models.InterventionTemplate
+
+	// Try Firestore first
+	found := false
+	if s.templateRepo.IsFirestoreAvailable() {
+		stored, err := s.templateRepo.FindByMentorID(mentorID)
+		if err == nil {
+			templates = stored
+			found = true
+		}
+	}
+
+	// Fallback to mock data
+	if !found {
+		mockTemplatesMu.Lock()
+		for _, template := range mockTemplates {
+			if template.MentorID == mentorID {
+				templates = append(templates, template)
+			}
+		}
+		mockTemplatesMu.Unlock()
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+func (s *InterventionService) findTemplate(templateID string) (*models.InterventionTemplate, error) {
+	for _, template := range builtInTemplates {
+		if template.ID == templateID {
+			template.BuiltIn = true
+			return &template, nil
+		}
+	}
+
+	// Try Firestore first
+	if s.templateRepo.IsFirestoreAvailable() {
+		template, err := s.templateRepo.FindByID(templateID)
+		if err == nil {
+			return template, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockTemplatesMu.Lock()
+	defer mockTemplatesMu.Unlock()
+	for _, template := range mockTemplates {
+		if template.ID == templateID {
+			return &template, nil
+		}
+	}
+	return nil, ErrTemplateNotFound
+}
+
+// applyTemplateRequest validates the request and copies it into the template
+func applyTemplateRequest(template *models.InterventionTemplate, req models.SaveInterventionTemplateRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if !slices.Contains(interventionTypes, req.Type) {
+		return fmt.Errorf("type must be one of %s", strings.Join(interventionTypes, ", "))
+	}
+
+	variants := make(map[string]string)
+	for language, body := range req.Variants {
+		body = strings.TrimSpace(body)
+		if body == "" {
+			continue
+		}
+		for _, match := range templatePlaceholder.FindAllStringSubmatch(body, -1) {
+			if !slices.Contains(templateVariables, match[1]) {
+				return fmt.Errorf("unknown placeholder {{%s}}; available: %s", match[1], strings.Join(templateVariables, ", "))
+			}
+		}
+		variants[strings.ToLower(language)] = body
+	}
+	if len(variants) == 0 {
+		return fmt.Errorf("at least one message variant is required")
+	}
+
+	defaultLanguage := strings.ToLower(req.DefaultLanguage)
+	if defaultLanguage == "" {
+		if _, ok := variants["id"]; ok {
+			defaultLanguage = "id"
+		} else {
+			languages := make([]string, 0, len(variants))
+			for language := range variants {
+				languages = append(languages, language)
+			}
+			sort.Strings(languages)
+			defaultLanguage = languages[0]
+		}
+	}
+	if _, ok := variants[defaultLanguage]; !ok {
+		return fmt.Errorf("defaultLanguage %q has no variant", defaultLanguage)
+	}
+
+	template.Name = strings.TrimSpace(req.Name)
+	template.Type = req.Type
+	template.Variants = variants
+	template.DefaultLanguage = defaultLanguage
+	template.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"mentorsphere-api/internal/config"
+	"mentorsphere-api/internal/repository"
+	"mentorsphere-api/internal/scheduler"
+)
+
+const (
+	jobRetries      = 3
+	jobRetryBackoff = time.Minute
+	// Jobs running every few minutes retry once, so the retry is over before
+	// the next scheduled run picks up the work anyway
+	frequentJobRetries      = 1
+	frequentJobRetryBackoff = time.Minute
+	// Days of activity rollups refreshed per run, so late-synced activity is picked up
+	rollupRefreshDays = 7
+	// Activity window of the badge-awards job, longer than its schedule so a
+	// delayed run misses nothing
+	badgeActivityLookback = time.Hour
+)
+
+// NewJobStore returns the shared Firestore store, or an in-process store in mock mode
+func NewJobStore() scheduler.Store {
+	repo := repository.NewJobRepository()
+	if repo.IsFirestoreAvailable() {
+		return repo
+	}
+	return scheduler.NewMemoryStore()
+}
+
+// RegisterJobs adds the platform's background jobs to the scheduler
+func RegisterJobs(s *scheduler.Scheduler, cfg *config.Config) error {
+	userRepo := repository.NewUserRepository()
+	riskService := NewRiskService()
+	reflectionService := NewReflectionService()
+	rollupService := NewRollupService()
+	alertService := NewAlertService()
+	interventionService := NewInterventionService()
+	meetingService := NewMeetingService()
+	deliveryService := NewDeliveryService()
+	reminderService := NewReminderService()
+	digestService := NewDigestService()
+	achievementService := NewAchievementService()
+	certificateService := NewCertificateService(cfg)
+
+	jobs := []scheduler.Job{
+		{
+			Name:        "activity-rollups",
+			Description: "Refresh per-day activity totals of every student",
+			Schedule:    "15 0 * * *",
+			Run: forEachStudent(userRepo, func(userID string) error {
+				_, err := rollupService.Refresh(userID, rollupRefreshDays)
+				return err
+			}),
+		},
+		{
+			Name:        "badge-awards",
+			Description: "Award badges earned by students with new activity or quiz attempts",
+			Schedule:    "*/15 * * * *",
+			Run: func(ctx context.Context) error {
+				evaluated := achievementService.EvaluateActive(time.Now().Add(-badgeActivityLookback))
+				if evaluated > 0 {
+					log.Printf("jobs: evaluated badges of %d students", evaluated)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "certificate-issuance",
+			Description: "Issue certificates for courses students have completed",
+			Schedule:    "*/15 * * * *",
+			Run: forEachStudent(userRepo, func(userID string) error {
+				_, err := certificateService.IssueEligible(userID)
+				return err
+			}),
+		},
+		{
+			Name:        "daily-reflections",
+			Description: "Generate yesterday's daily reflection for every student",
+			Schedule:    "30 0 * * *",
+			Run: forEachStudent(userRepo, func(userID string) error {
+				reflectionService.GenerateDailyReflection(userID, time.Now().AddDate(0, 0, -1))
+				return nil
+			}),
+		},
+		{
+			Name:        "risk-recompute",
+			Description: "Recompute risk scores and record today's risk snapshots",
+			Schedule:    "0 1 * * *",
+			Run: forEachStudent(userRepo, func(userID string) error {
+				_, err := riskService.Assess(userID)
+				return err
+			}),
+		},
+		{
+			Name:        "weekly-insights",
+			Description: "Compute last week's insight for every student",
+			Schedule:    "0 2 * * 1",
+			Run: forEachStudent(userRepo, func(userID string) error {
+				reflectionService.GenerateWeeklyInsight(userID, time.Now().AddDate(0, 0, -7))
+				return nil
+			}),
+		},
+		{
+			Name:        "early-warning-alerts",
+			Description: "Check mentors' alert rules against their students and notify them",
+			Schedule:    "0 * * * *",
+			Run: func(ctx context.Context) error {
+				alertService.EvaluateAll()
+				return nil
+			},
+		},
+		{
+			Name:        "intervention-outcomes",
+			Description: "Measure the outcome of interventions whose outcome window has passed",
+			Schedule:    "30 1 * * *",
+			Run: func(ctx context.Context) error {
+				evaluated, err := interventionService.EvaluateOutcomes()
+				log.Printf("jobs: evaluated %d intervention outcomes", evaluated)
+				return err
+			},
+		},
+		{
+			Name:        "meeting-completion",
+			Description: "Complete meetings that have ended and their meeting interventions",
+			Schedule:    "*/15 * * * *",
+			Run: func(ctx context.Context) error {
+				_, err := meetingService.CompleteHeld()
+				return err
+			},
+		},
+		{
+			Name:         "notification-deliveries",
+			Description:  "Retry failed e-mail and push deliveries whose backoff has passed",
+			Schedule:     "*/5 * * * *",
+			MaxRetries:   frequentJobRetries,
+			RetryBackoff: frequentJobRetryBackoff,
+			Run: func(ctx context.Context) error {
+				retried, err := deliveryService.RetryDue()
+				if retried > 0 {
+					log.Printf("jobs: retried %d notification deliveries", retried)
+				}
+				return err
+			},
+		},
+		{
+			Name:         "study-reminders",
+			Description:  "Remind students who have not met today's goal at their reminder time",
+			Schedule:     "*/5 * * * *",
+			MaxRetries:   frequentJobRetries,
+			RetryBackoff: frequentJobRetryBackoff,
+			Run: func(ctx context.Context) error {
+				sent := 0
+				err := forEachStudent(userRepo, func(userID string) error {
+					ok, err := reminderService.SendDue(userID, time.Now())
+					if ok {
+						sent++
+					}
+					return err
+				})(ctx)
+				if sent > 0 {
+					log.Printf("jobs: sent %d study reminders", sent)
+				}
+				return err
+			},
+		},
+		{
+			Name:        "weekly-digests",
+			Description: "E-mail last week's progress report to students and mentors",
+			Schedule:    "0 7 * * 1",
+			Run: func(ctx context.Context) error {
+				sent, err := digestService.SendWeekly(ctx)
+				log.Printf("jobs: sent %d weekly digests", sent)
+				return err
+			},
+		},
+	}
+
+	for _, job := range jobs {
+		if job.RetryBackoff == 0 {
+			job.MaxRetries = jobRetries
+			job.RetryBackoff = jobRetryBackoff
+		}
+		if err := s.Register(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachStudent runs fn for every student. A failing student does not stop
+// the others; the failures are reported together so the run is retried.
+func forEachStudent(userRepo *repository.UserRepository, fn func(userID string) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var errs []error
+		students := findAllStudents(userRepo)
+		for _, student := range students {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(student.ID); err != nil {
+				errs = append(errs, fmt.Errorf("student %s: %w", student.ID, err))
+			}
+		}
+		if len(errs) > 0 {
+			log.Printf("jobs: %d of %d students failed", len(errs), len(students))
+		}
+		return errors.Join(errs...)
+	}
+}
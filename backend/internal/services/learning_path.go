@@ -0,0 +1,386 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+const (
+	// Days of activity used to measure the student's pace
+	learningPaceWindowDays = 28
+	// Maximum number of suggested topics
+	maxSuggestedTopics = 5
+)
+
+// Course levels in the order they are meant to be taken
+var courseLevels = map[string]int{
+	"Beginner":     1,
+	"Intermediate": 2,
+	"Advanced":     3,
+}
+
+// Learning phases by overall progress, checked in order
+var learningPhases = []struct {
+	maxProgress int
+	name        string
+}{
+	{10, "Getting Started"},
+	{40, "Foundation Building"},
+	{70, "Skill Development"},
+	{99, "Advanced Practice"},
+	{100, "Mastery"},
+}
+
+type LearningPathService struct {
+	activityRepo  *repository.ActivityRepository
+	settingsRepo  *repository.SettingsRepository
+	courseService *CourseService
+}
+
+func NewLearningPathService() *LearningPathService {
+	return &LearningPathService{
+		activityRepo:  repository.NewActivityRepository(),
+		settingsRepo:  repository.NewSettingsRepository(),
+		courseService: NewCourseService(),
+	}
+}
+
+// suggestion is a candidate topic with the score used to rank it
+type suggestion struct {
+	topic models.SuggestedTopic
+	score float64
+}
+
+// Recommend builds the student's learning path from their enrolled courses,
+// quiz results, study pace and the course catalog
+func (s *LearningPathService) Recommend(userID string) *models.LearningPath {
+	enrolled := s.courseService.GetUserCourses(userID)
+	activities := findUserActivities(s.activityRepo, userID)
+
+	path := &models.LearningPath{SuggestedTopics: []models.SuggestedTopic{}}
+	for _, course := range enrolled {
+		for _, module := range course.Modules {
+			path.TotalModules++
+			if module.Status == ModuleCompleted {
+				path.CompletedModules++
+			} else {
+				path.RemainingMinutes += module.Duration
+			}
+		}
+	}
+	if path.TotalModules > 0 {
+		path.Progress = path.CompletedModules * 100 / path.TotalModules
+	}
+	path.CurrentPhase = learningPhase(path.Progress, len(enrolled))
+
+	// Pace: minutes per week over the recent window, used to project completion
+	since := time.Now().AddDate(0, 0, -learningPaceWindowDays)
+	recentMinutes := 0
+	for _, activity := range activities {
+		if activity.Date.After(since) {
+			recentMinutes += activity.Duration
+		}
+	}
+	path.WeeklyPace = recentMinutes * 7 / learningPaceWindowDays
+	if path.RemainingMinutes > 0 && path.WeeklyPace > 0 {
+		weeks := float64(path.RemainingMinutes) / float64(path.WeeklyPace)
+		loc := userLocation(loadUserSettings(s.settingsRepo, userID))
+		path.EstimatedCompletion = startOfDay(time.Now(), loc).AddDate(0, 0, int(math.Ceil(weeks*7))).Format(dateLayout)
+	} else if path.RemainingMinutes == 0 && path.TotalModules > 0 {
+		path.EstimatedCompletion = time.Now().Format(dateLayout)
+	}
+
+	var candidates []suggestion
+	candidates = append(candidates, quizReviewSuggestions(enrolled, activities)...)
+	candidates = append(candidates, nextModuleSuggestions(enrolled)...)
+	candidates = append(candidates, catalogSuggestions(enrolled, s.courseService.GetAll())...)
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		key := fmt.Sprintf("%s/%s/%d", candidate.topic.Kind, candidate.topic.CourseID, candidate.topic.ModuleID)
+		if seen[key] || len(path.SuggestedTopics) == maxSuggestedTopics {
+			continue
+		}
+		seen[key] = true
+		path.SuggestedTopics = append(path.SuggestedTopics, candidate.topic)
+	}
+
+	path.NextMilestone = nextMilestone(enrolled, path.SuggestedTopics)
+	return path
+}
+
+func learningPhase(progress, enrolledCourses int) string {
+	if enrolledCourses == 0 {
+		return learningPhases[0].name
+	}
+	for _, phase := range learningPhases {
+		if progress <= phase.maxProgress {
+			return phase.name
+		}
+	}
+	return learningPhases[len(learningPhases)-1].name
+}
+
+// quizReviewSuggestions proposes revisiting quizzes scored below the passing
+// score, once per quiz: a failed attempt of a quiz module already suggested
+// from the course progress is not suggested again
+func quizReviewSuggestions(enrolled []models.Course, activities []models.ActivityLog) []suggestion {
+	var suggestions []suggestion
+	suggested := make(map[string]bool)
+	for _, course := range enrolled {
+		for _, module := range course.Modules {
+			if module.Type != "quiz" || module.Score == nil || *module.Score >= quizPassingScore {
+				continue
+			}
+			suggested[quizReviewKey(course.ID, module.ID, module.Title)] = true
+			suggestions = append(suggestions, suggestion{
+				topic: models.SuggestedTopic{
+					Title:    "Ulangi " + module.Title,
+					Priority: "high",
+					Reason:   fmt.Sprintf("Skor quiz %d di bawah batas lulus %d", *module.Score, quizPassingScore),
+					Kind:     "review",
+					CourseID: course.ID,
+					ModuleID: module.ID,
+				},
+				score: 100 + float64(quizPassingScore-*module.Score),
+			})
+		}
+	}
+
+	// Latest attempt of each quiz in the activity log
+	latest := make(map[string]models.ActivityLog)
+	for _, activity := range activities {
+		if activity.Type != "quiz" || activity.Score == nil {
+			continue
+		}
+		activity.ModuleID = quizModuleID(enrolled, activity)
+		key := quizReviewKey(activity.CourseID, activity.ModuleID, activity.Title)
+		if previous, ok := latest[key]; !ok || activity.Date.After(previous.Date) {
+			latest[key] = activity
+		}
+	}
+	for key, activity := range latest {
+		if *activity.Score >= quizPassingScore || suggested[key] {
+			continue
+		}
+		suggestions = append(suggestions, suggestion{
+			topic: models.SuggestedTopic{
+				Title:    "Ulangi materi " + activity.Title,
+				Priority: "high",
+				Reason:   fmt.Sprintf("Percobaan terakhir mendapat skor %d, di bawah batas lulus %d", *activity.Score, quizPassingScore),
+				Kind:     "review",
+				CourseID: activity.CourseID,
+				ModuleID: activity.ModuleID,
+			},
+			score: 90 + float64(quizPassingScore-*activity.Score),
+		})
+	}
+	return suggestions
+}
+
+// quizModuleID resolves the quiz module of an attempt logged without one by
+// its title within the attempt's course
+func quizModuleID(enrolled []models.Course, activity models.ActivityLog) int {
+	if activity.ModuleID != 0 {
+		return activity.ModuleID
+	}
+	for _, course := range enrolled {
+		if course.ID != activity.CourseID {
+			continue
+		}
+		for _, module := range course.Modules {
+			if module.Type == "quiz" && module.Title == activity.Title {
+				return module.ID
+			}
+		}
+	}
+	return 0
+}
+
+// quizReviewKey identifies a quiz by its module, or by its title when the
+// module is unknown
+func quizReviewKey(courseID string, moduleID int, title string) string {
+	if moduleID != 0 {
+		return fmt.Sprintf("%s/%d", courseID, moduleID)
+	}
+	return courseID + "/" + title
+}
+
+// nextModuleSuggestions proposes modules in progress and modules whose prerequisites are done
+func nextModuleSuggestions(enrolled []models.Course) []suggestion {
+	var suggestions []suggestion
+	for _, course := range enrolled {
+		completed := make(map[int]bool)
+		for _, module := range course.Modules {
+			if module.Status == ModuleCompleted {
+				completed[module.ID] = true
+			}
+		}
+
+		for i, module := range course.Modules {
+			if module.Status == ModuleCompleted {
+				continue
+			}
+			if module.Status == ModuleInProgress {
+				suggestions = append(suggestions, suggestion{
+					topic: models.SuggestedTopic{
+						Title:    module.Title,
+						Priority: "high",
+						Reason:   fmt.Sprintf("Sedang berjalan di %s (%d%% selesai)", course.Title, course.Progress),
+						Kind:     "module",
+						CourseID: course.ID,
+						ModuleID: module.ID,
+					},
+					// Favour courses that are closest to being finished
+					score: 80 + float64(course.Progress)/10,
+				})
+				continue
+			}
+			if !modulePrerequisitesMet(course.Modules, i, completed) {
+				continue
+			}
+			reason := "Semua prasyarat sudah selesai"
+			if i > 0 {
+				reason = "Kelanjutan natural dari " + course.Modules[i-1].Title
+			}
+			suggestions = append(suggestions, suggestion{
+				topic: models.SuggestedTopic{
+					Title:    module.Title,
+					Priority: "medium",
+					Reason:   reason,
+					Kind:     "module",
+					CourseID: course.ID,
+					ModuleID: module.ID,
+				},
+				score: 60 + float64(course.Progress)/10,
+			})
+		}
+	}
+	return suggestions
+}
+
+func modulePrerequisitesMet(modules []models.Module, index int, completed map[int]bool) bool {
+	prerequisites := modules[index].Prerequisites
+	if len(prerequisites) == 0 && index > 0 {
+		prerequisites = []int{modules[index-1].ID}
+	}
+	for _, id := range prerequisites {
+		if !completed[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// catalogSuggestions proposes courses the student is ready for, preferring
+// categories they already study and the level right after their current one
+func catalogSuggestions(enrolled, catalog []models.Course) []suggestion {
+	enrolledIDs := make(map[string]bool)
+	completedIDs := make(map[string]bool)
+	categoryLevel := make(map[string]int)
+	for _, course := range enrolled {
+		enrolledIDs[course.ID] = true
+		if courseCompleted(course) {
+			completedIDs[course.ID] = true
+		}
+		categoryLevel[course.Category] = max(categoryLevel[course.Category], courseLevels[course.Level])
+	}
+
+	var suggestions []suggestion
+	for _, course := range catalog {
+		if enrolledIDs[course.ID] {
+			continue
+		}
+
+		var missing []string
+		for _, id := range course.Prerequisites {
+			if !completedIDs[id] {
+				missing = append(missing, id)
+			}
+		}
+
+		score := 20.0
+		priority := "low"
+		reason := "Memperluas wawasan ke kategori " + course.Category
+		if level, ok := categoryLevel[course.Category]; ok {
+			score += 15
+			priority = "medium"
+			reason = "Melanjutkan kategori " + course.Category + " yang sedang Anda pelajari"
+			if courseLevels[course.Level] == level+1 {
+				score += 10
+				reason = fmt.Sprintf("Langkah berikutnya ke level %s di %s", course.Level, course.Category)
+			}
+		}
+		if len(missing) > 0 {
+			// Still worth showing as a goal, but below anything available now
+			score -= 15
+			priority = "low"
+			reason = fmt.Sprintf("Selesaikan dulu %s sebelum memulai course ini", coursePrerequisiteTitles(missing, catalog))
+		}
+
+		suggestions = append(suggestions, suggestion{
+			topic: models.SuggestedTopic{
+				Title:    course.Title,
+				Priority: priority,
+				Reason:   reason,
+				Kind:     "course",
+				CourseID: course.ID,
+			},
+			score: score,
+		})
+	}
+	return suggestions
+}
+
+func coursePrerequisiteTitles(ids []string, catalog []models.Course) string {
+	titles := make([]string, 0, len(ids))
+	for _, id := range ids {
+		title := id
+		for _, course := range catalog {
+			if course.ID == id {
+				title = course.Title
+			}
+		}
+		titles = append(titles, title)
+	}
+	if len(titles) == 1 {
+		return titles[0]
+	}
+	return fmt.Sprintf("%s dan %d course lain", titles[0], len(titles)-1)
+}
+
+func nextMilestone(enrolled []models.Course, topics []models.SuggestedTopic) string {
+	// Finishing the in-progress module of the course closest to completion comes first
+	best := -1
+	milestone := ""
+	for _, course := range enrolled {
+		for _, module := range course.Modules {
+			if module.Status == ModuleInProgress && course.Progress > best {
+				best = course.Progress
+				milestone = fmt.Sprintf("Menyelesaikan Modul %s", module.Title)
+			}
+		}
+	}
+	if milestone != "" {
+		return milestone
+	}
+
+	for _, topic := range topics {
+		switch topic.Kind {
+		case "module":
+			return "Memulai Modul " + topic.Title
+		case "course":
+			return "Memulai course " + topic.Title
+		}
+	}
+	if len(enrolled) == 0 {
+		return "Mendaftar ke course pertama Anda"
+	}
+	return "Semua modul telah selesai"
+}
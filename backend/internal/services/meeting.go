@@ -0,0 +1,756 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+	"mentorsphere-api/pkg/ical"
+)
+
+// Slot and meeting statuses
+const (
+	SlotOpen      = "open"
+	SlotBooked    = "booked"
+	SlotCancelled = "cancelled"
+
+	MeetingBooked    = "booked"
+	MeetingCancelled = "cancelled"
+	MeetingCompleted = "completed"
+)
+
+const (
+	slotLocalLayout    = "2006-01-02T15:04"
+	minMeetingDuration = 15 * time.Minute
+	maxMeetingDuration = 4 * time.Hour
+	// How far back the calendar feed lists meetings
+	calendarFeedDays = 90
+)
+
+var (
+	ErrSlotNotFound     = errors.New("slot not found")
+	ErrMeetingNotFound  = errors.New("meeting not found")
+	ErrMeetingForbidden = errors.New("meeting belongs to another user")
+	ErrMeetingConflict  = errors.New("time overlaps another slot or meeting")
+	ErrSlotUnavailable  = errors.New("slot is no longer available")
+)
+
+// Slots, meetings and calendar feeds for development (used as fallback when Firebase is not configured)
+var (
+	mockSlots         []models.AvailabilitySlot
+	mockMeetings      []models.Meeting
+	mockCalendarFeeds = make(map[string]models.CalendarFeed)
+	mockMeetingsMu    sync.Mutex
+)
+
+type MeetingService struct {
+	meetingRepo         *repository.MeetingRepository
+	userRepo            *repository.UserRepository
+	settingsRepo        *repository.SettingsRepository
+	authService         *AuthService
+	interventionService *InterventionService
+	notificationService *NotificationService
+}
+
+func NewMeetingService() *MeetingService {
+	return &MeetingService{
+		meetingRepo:         repository.NewMeetingRepository(),
+		userRepo:            repository.NewUserRepository(),
+		settingsRepo:        repository.NewSettingsRepository(),
+		authService:         NewAuthService(),
+		interventionService: NewInterventionService(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// CreateSlot publishes a future availability slot for the mentor. Times are
+// read in the request's timezone, or the mentor's own when it is empty.
+func (s *MeetingService) CreateSlot(mentorID string, req models.CreateSlotRequest) (*models.AvailabilitySlot, error) {
+	loc := userLocation(loadUserSettings(s.settingsRepo, mentorID))
+	if req.Timezone != "" {
+		requested, err := time.LoadLocation(req.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", req.Timezone)
+		}
+		loc = requested
+	}
+
+	start, err := parseSlotTime(req.Start, loc)
+	if err != nil {
+		return nil, fmt.Errorf("start: %w", err)
+	}
+	end, err := parseSlotTime(req.End, loc)
+	if err != nil {
+		return nil, fmt.Errorf("end: %w", err)
+	}
+	if !start.After(time.Now()) {
+		return nil, fmt.Errorf("start must be in the future")
+	}
+	if duration := end.Sub(start); duration < minMeetingDuration || duration > maxMeetingDuration {
+		return nil, fmt.Errorf("a slot must last between %v and %v", minMeetingDuration, maxMeetingDuration)
+	}
+
+	for _, slot := range s.findSlots(mentorID) {
+		if slot.Status != SlotCancelled && overlaps(start, end, slot.Start, slot.End) {
+			return nil, ErrMeetingConflict
+		}
+	}
+
+	slot := &models.AvailabilitySlot{
+		MentorID:  mentorID,
+		Start:     start.UTC(),
+		End:       end.UTC(),
+		Timezone:  loc.String(),
+		Status:    SlotOpen,
+		CreatedAt: time.Now(),
+	}
+
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		if err := s.meetingRepo.CreateSlot(slot); err == nil {
+			return s.slotIn(slot, loc), nil
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	slot.ID = fmt.Sprintf("%d", len(mockSlots)+1)
+	mockSlots = append(mockSlots, *slot)
+	mockMeetingsMu.Unlock()
+	return s.slotIn(slot, loc), nil
+}
+
+// ListSlots returns the mentor's slots that have not ended, in the mentor's timezone
+func (s *MeetingService) ListSlots(mentorID string) []models.AvailabilitySlot {
+	loc := userLocation(loadUserSettings(s.settingsRepo, mentorID))
+
+	slots := []models.AvailabilitySlot{}
+	for _, slot := range s.findSlots(mentorID) {
+		if slot.Status != SlotCancelled {
+			slots = append(slots, *s.slotIn(&slot, loc))
+		}
+	}
+	return slots
+}
+
+// ListOpenSlots returns the bookable slots of the student's mentors, in the student's timezone
+func (s *MeetingService) ListOpenSlots(studentID string) []models.AvailabilitySlot {
+	loc := userLocation(loadUserSettings(s.settingsRepo, studentID))
+	now := time.Now()
+
+	slots := []models.AvailabilitySlot{}
+	for _, mentor := range findMentorsOfStudent(s.userRepo, studentID) {
+		for _, slot := range s.findSlots(mentor.ID) {
+			if slot.Status == SlotOpen && slot.Start.After(now) {
+				slots = append(slots, *s.slotIn(&slot, loc))
+			}
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+	return slots
+}
+
+// DeleteSlot withdraws one of the mentor's slots, cancelling its meeting if it was booked
+func (s *MeetingService) DeleteSlot(mentorID, slotID string) error {
+	slot, err := s.findSlot(slotID)
+	if err != nil {
+		return err
+	}
+	if slot.MentorID != mentorID {
+		return ErrMeetingForbidden
+	}
+	if slot.Status == SlotCancelled {
+		return nil
+	}
+
+	if slot.Status == SlotBooked && slot.MeetingID != "" {
+		if _, err := s.Cancel(mentorID, slot.MeetingID, "Slot ditarik oleh mentor"); err != nil {
+			return err
+		}
+		// Cancelling reopened the slot; read it again before withdrawing it
+		if slot, err = s.findSlot(slotID); err != nil {
+			return err
+		}
+	}
+
+	slot.Status = SlotCancelled
+	slot.MeetingID = ""
+	return s.saveSlot(slot)
+}
+
+// Book reserves an open slot of one of the student's mentors. The meeting is
+// linked to the given meeting intervention, or to the newest open one.
+func (s *MeetingService) Book(studentID string, req models.BookMeetingRequest) (*models.Meeting, error) {
+	slot, err := s.findSlot(req.SlotID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isAssigned(slot.MentorID, studentID) {
+		return nil, ErrMeetingForbidden
+	}
+	if err := s.checkBookable(slot, studentID, ""); err != nil {
+		return nil, err
+	}
+	intervention, err := s.interventionService.FindMeetingIntervention(req.InterventionID, slot.MentorID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	student, err := s.authService.GetUserByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	mentor, err := s.authService.GetUserByID(slot.MentorID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	meeting := &models.Meeting{
+		SlotID:      slot.ID,
+		MentorID:    slot.MentorID,
+		MentorName:  mentor.Name,
+		StudentID:   studentID,
+		StudentName: student.Name,
+		Topic:       strings.TrimSpace(req.Topic),
+		Start:       slot.Start,
+		End:         slot.End,
+		Status:      MeetingBooked,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if intervention != nil {
+		meeting.InterventionID = intervention.ID
+	}
+	if meeting.Topic == "" {
+		meeting.Topic = "Sesi mentoring"
+	}
+
+	if err := s.bookSlot(slot, "", meeting); err != nil {
+		return nil, err
+	}
+	if meeting.InterventionID != "" {
+		s.interventionService.LinkMeeting(meeting.InterventionID, meeting)
+	}
+
+	s.notify(meeting.MentorID, "info", "Sesi Dipesan",
+		fmt.Sprintf("%s memesan sesi %s", meeting.StudentName, s.formatFor(meeting.MentorID, meeting.Start)))
+	return s.meetingIn(meeting, studentID), nil
+}
+
+// Reschedule moves a booked meeting to another open slot of the same mentor.
+// Either participant may reschedule.
+func (s *MeetingService) Reschedule(userID, meetingID string, req models.RescheduleMeetingRequest) (*models.Meeting, error) {
+	meeting, err := s.getForParticipant(userID, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	if meeting.Status != MeetingBooked {
+		return nil, fmt.Errorf("cannot reschedule a meeting that is %s", meeting.Status)
+	}
+
+	slot, err := s.findSlot(req.SlotID)
+	if err != nil {
+		return nil, err
+	}
+	if slot.MentorID != meeting.MentorID {
+		return nil, fmt.Errorf("the new slot must belong to the same mentor")
+	}
+	if err := s.checkBookable(slot, meeting.StudentID, meeting.ID); err != nil {
+		return nil, err
+	}
+
+	oldSlotID := meeting.SlotID
+	meeting.SlotID = slot.ID
+	meeting.Start = slot.Start
+	meeting.End = slot.End
+	meeting.Sequence++
+	meeting.UpdatedAt = time.Now()
+	if err := s.bookSlot(slot, oldSlotID, meeting); err != nil {
+		return nil, err
+	}
+
+	if meeting.InterventionID != "" {
+		s.interventionService.LinkMeeting(meeting.InterventionID, meeting)
+	}
+
+	recipient := s.otherParticipant(meeting, userID)
+	s.notify(recipient, "info", "Sesi Dijadwal Ulang",
+		fmt.Sprintf("Sesi \"%s\" dipindahkan ke %s", meeting.Topic, s.formatFor(recipient, meeting.Start)))
+	return s.meetingIn(meeting, userID), nil
+}
+
+// Cancel cancels a booked meeting and reopens its slot when it is still in the future
+func (s *MeetingService) Cancel(userID, meetingID, reason string) (*models.Meeting, error) {
+	meeting, err := s.getForParticipant(userID, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	if meeting.Status != MeetingBooked {
+		return nil, fmt.Errorf("cannot cancel a meeting that is %s", meeting.Status)
+	}
+
+	s.releaseBooking(meeting, userID, strings.TrimSpace(reason))
+
+	recipient := s.otherParticipant(meeting, userID)
+	message := fmt.Sprintf("Sesi \"%s\" pada %s dibatalkan", meeting.Topic, s.formatFor(recipient, meeting.Start))
+	if meeting.CancelReason != "" {
+		message += ": " + meeting.CancelReason
+	}
+	s.notify(recipient, "warning", "Sesi Dibatalkan", message)
+	return s.meetingIn(meeting, userID), nil
+}
+
+// ListMeetings returns the user's meetings, soonest first, in the user's timezone
+func (s *MeetingService) ListMeetings(userID string) []models.Meeting {
+	meetings := []models.Meeting{}
+	for _, meeting := range s.findMeetings(userID) {
+		meetings = append(meetings, *s.meetingIn(&meeting, userID))
+	}
+	sort.Slice(meetings, func(i, j int) bool { return meetings[i].Start.Before(meetings[j].Start) })
+	return meetings
+}
+
+// CompleteHeld marks booked meetings that have ended as completed and
+// completes their meeting interventions. It returns the number completed.
+func (s *MeetingService) CompleteHeld() (int, error) {
+	var errs []error
+	completed := 0
+	now := time.Now()
+	for _, meeting := range s.findBookedMeetings() {
+		if meeting.End.After(now) {
+			continue
+		}
+
+		meeting.Status = MeetingCompleted
+		meeting.CompletedAt = &now
+		meeting.UpdatedAt = now
+		if err := s.saveMeeting(&meeting); err != nil {
+			errs = append(errs, fmt.Errorf("meeting %s: %w", meeting.ID, err))
+			continue
+		}
+		if meeting.InterventionID != "" {
+			if err := s.interventionService.CompleteForMeeting(meeting.InterventionID); err != nil {
+				errs = append(errs, fmt.Errorf("intervention %s: %w", meeting.InterventionID, err))
+			}
+		}
+		completed++
+	}
+	return completed, errors.Join(errs...)
+}
+
+// GetFeedToken returns the token of the user's calendar feed, creating one if needed
+func (s *MeetingService) GetFeedToken(userID string) (string, error) {
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		if feed, err := s.meetingRepo.FindFeedByUserID(userID); err == nil {
+			return feed.Token, nil
+		}
+	} else {
+		// Fallback to mock data
+		mockMeetingsMu.Lock()
+		feed, ok := mockCalendarFeeds[userID]
+		mockMeetingsMu.Unlock()
+		if ok {
+			return feed.Token, nil
+		}
+	}
+
+	return s.ResetFeedToken(userID)
+}
+
+// ResetFeedToken replaces the user's calendar feed token, invalidating the old URL
+func (s *MeetingService) ResetFeedToken(userID string) (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate feed token: %w", err)
+	}
+	feed := &models.CalendarFeed{
+		UserID:    userID,
+		Token:     hex.EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		if err := s.meetingRepo.SaveFeed(feed); err == nil {
+			return feed.Token, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	mockCalendarFeeds[userID] = *feed
+	mockMeetingsMu.Unlock()
+	return feed.Token, nil
+}
+
+// RenderFeed returns the iCalendar feed of the user owning the token
+func (s *MeetingService) RenderFeed(token string) ([]byte, error) {
+	userID := ""
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		if feed, err := s.meetingRepo.FindFeedByToken(token); err == nil {
+			userID = feed.UserID
+		}
+	} else {
+		// Fallback to mock data
+		mockMeetingsMu.Lock()
+		for _, feed := range mockCalendarFeeds {
+			if feed.Token == token {
+				userID = feed.UserID
+			}
+		}
+		mockMeetingsMu.Unlock()
+	}
+	if userID == "" || token == "" {
+		return nil, fmt.Errorf("calendar feed not found")
+	}
+
+	calendar := &ical.Calendar{
+		ProductID: "-//MentorSphere//Meetings//ID",
+		Name:      "MentorSphere",
+		Events:    []ical.Event{},
+	}
+	since := time.Now().AddDate(0, 0, -calendarFeedDays)
+	for _, meeting := range s.findMeetings(userID) {
+		if meeting.End.Before(since) {
+			continue
+		}
+
+		with := meeting.StudentName
+		if meeting.StudentID == userID {
+			with = meeting.MentorName
+		}
+		status := "CONFIRMED"
+		if meeting.Status == MeetingCancelled {
+			status = "CANCELLED"
+		}
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         meeting.ID + "@mentorsphere",
+			Start:       meeting.Start,
+			End:         meeting.End,
+			Summary:     fmt.Sprintf("%s dengan %s", meeting.Topic, with),
+			Description: "Sesi mentoring MentorSphere",
+			Status:      status,
+			Sequence:    meeting.Sequence,
+			Updated:     meeting.UpdatedAt,
+		})
+	}
+	return calendar.Render(), nil
+}
+
+// checkBookable verifies the slot is open and in the future and does not
+// overlap the student's other booked meetings
+func (s *MeetingService) checkBookable(slot *models.AvailabilitySlot, studentID, excludeMeetingID string) error {
+	if slot.Status != SlotOpen || !slot.Start.After(time.Now()) {
+		return ErrSlotUnavailable
+	}
+	for _, other := range s.findMeetings(studentID) {
+		if other.ID != excludeMeetingID && other.Status == MeetingBooked && overlaps(slot.Start, slot.End, other.Start, other.End) {
+			return ErrMeetingConflict
+		}
+	}
+	return nil
+}
+
+// bookSlot takes the slot for the meeting, reopening releaseSlotID if set
+func (s *MeetingService) bookSlot(slot *models.AvailabilitySlot, releaseSlotID string, meeting *models.Meeting) error {
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		booked, err := s.meetingRepo.BookSlot(slot.ID, releaseSlotID, meeting)
+		if err == nil {
+			if !booked {
+				return ErrSlotUnavailable
+			}
+			return nil
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	defer mockMeetingsMu.Unlock()
+	var target *models.AvailabilitySlot
+	for i := range mockSlots {
+		if mockSlots[i].ID == slot.ID {
+			target = &mockSlots[i]
+		}
+	}
+	if target == nil || target.Status != SlotOpen {
+		return ErrSlotUnavailable
+	}
+	for i := range mockSlots {
+		if releaseSlotID != "" && mockSlots[i].ID == releaseSlotID {
+			mockSlots[i].Status = SlotOpen
+			mockSlots[i].MeetingID = ""
+		}
+	}
+
+	if meeting.ID == "" {
+		meeting.ID = fmt.Sprintf("%d", len(mockMeetings)+1)
+		mockMeetings = append(mockMeetings, *meeting)
+	} else {
+		for i := range mockMeetings {
+			if mockMeetings[i].ID == meeting.ID {
+				mockMeetings[i] = *meeting
+			}
+		}
+	}
+	target.Status = SlotBooked
+	target.MeetingID = meeting.ID
+	return nil
+}
+
+// releaseBooking cancels the meeting and reopens its slot if it has not started
+func (s *MeetingService) releaseBooking(meeting *models.Meeting, userID, reason string) {
+	now := time.Now()
+	meeting.Status = MeetingCancelled
+	meeting.CancelledAt = &now
+	meeting.CancelledBy = userID
+	meeting.CancelReason = reason
+	meeting.Sequence++
+	meeting.UpdatedAt = now
+	s.saveMeeting(meeting)
+
+	if slot, err := s.findSlot(meeting.SlotID); err == nil && slot.MeetingID == meeting.ID {
+		slot.MeetingID = ""
+		if slot.Start.After(now) {
+			slot.Status = SlotOpen
+		} else {
+			slot.Status = SlotCancelled
+		}
+		s.saveSlot(slot)
+	}
+
+	if meeting.InterventionID != "" {
+		s.interventionService.UnlinkMeeting(meeting.InterventionID)
+	}
+}
+
+func (s *MeetingService) getForParticipant(userID, meetingID string) (*models.Meeting, error) {
+	meeting, err := s.findMeeting(meetingID)
+	if err != nil {
+		return nil, err
+	}
+	if meeting.MentorID != userID && meeting.StudentID != userID {
+		return nil, ErrMeetingForbidden
+	}
+	return meeting, nil
+}
+
+func (s *MeetingService) otherParticipant(meeting *models.Meeting, userID string) string {
+	if userID == meeting.MentorID {
+		return meeting.StudentID
+	}
+	return meeting.MentorID
+}
+
+func (s *MeetingService) isAssigned(mentorID, studentID string) bool {
+	for _, student := range findAssignedStudents(s.userRepo, mentorID) {
+		if student.ID == studentID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MeetingService) notify(userID, notificationType, title, message string) {
+	s.notificationService.Notify(userID, CategoryMentorMessages, notificationType, title, message)
+}
+
+// formatFor formats a meeting time in the recipient's timezone
+func (s *MeetingService) formatFor(userID string, t time.Time) string {
+	return t.In(userLocation(loadUserSettings(s.settingsRepo, userID))).Format("02 Jan 2006 15:04 MST")
+}
+
+// slotIn returns a copy of the slot with its times in loc
+func (s *MeetingService) slotIn(slot *models.AvailabilitySlot, loc *time.Location) *models.AvailabilitySlot {
+	local := *slot
+	local.Start = slot.Start.In(loc)
+	local.End = slot.End.In(loc)
+	return &local
+}
+
+// meetingIn returns a copy of the meeting with its times in the user's timezone
+func (s *MeetingService) meetingIn(meeting *models.Meeting, userID string) *models.Meeting {
+	loc := userLocation(loadUserSettings(s.settingsRepo, userID))
+	local := *meeting
+	local.Start = meeting.Start.In(loc)
+	local.End = meeting.End.In(loc)
+	return &local
+}
+
+// findSlots returns the mentor's slots that have not ended
+func (s *MeetingService) findSlots(mentorID string) []models.AvailabilitySlot {
+	now := time.Now()
+
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		slots, err := s.meetingRepo.FindSlotsByMentorID(mentorID, now)
+		if err == nil {
+			sort.Slice(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+			return slots
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	defer mockMeetingsMu.Unlock()
+	var slots []models.AvailabilitySlot
+	for _, slot := range mockSlots {
+		if slot.MentorID == mentorID && slot.End.After(now) {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+	return slots
+}
+
+func (s *MeetingService) findSlot(slotID string) (*models.AvailabilitySlot, error) {
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		slot, err := s.meetingRepo.FindSlotByID(slotID)
+		if err == nil {
+			return slot, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	defer mockMeetingsMu.Unlock()
+	for _, slot := range mockSlots {
+		if slot.ID == slotID {
+			return &slot, nil
+		}
+	}
+	return nil, ErrSlotNotFound
+}
+
+func (s *MeetingService) saveSlot(slot *models.AvailabilitySlot) error {
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		if err := s.meetingRepo.SaveSlot(slot); err == nil {
+			return nil
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	defer mockMeetingsMu.Unlock()
+	for i := range mockSlots {
+		if mockSlots[i].ID == slot.ID {
+			mockSlots[i] = *slot
+			return nil
+		}
+	}
+	return ErrSlotNotFound
+}
+
+func (s *MeetingService) findMeeting(meetingID string) (*models.Meeting, error) {
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		meeting, err := s.meetingRepo.FindMeetingByID(meetingID)
+		if err == nil {
+			return meeting, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	defer mockMeetingsMu.Unlock()
+	for _, meeting := range mockMeetings {
+		if meeting.ID == meetingID {
+			return &meeting, nil
+		}
+	}
+	return nil, ErrMeetingNotFound
+}
+
+// findMeetings returns the meetings the user takes part in as mentor or student
+func (s *MeetingService) findMeetings(userID string) []models.Meeting {
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		asMentor, err := s.meetingRepo.FindMeetings("mentorId", userID)
+		if err == nil {
+			asStudent, err := s.meetingRepo.FindMeetings("studentId", userID)
+			if err == nil {
+				return append(asMentor, asStudent...)
+			}
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	defer mockMeetingsMu.Unlock()
+	var meetings []models.Meeting
+	for _, meeting := range mockMeetings {
+		if meeting.MentorID == userID || meeting.StudentID == userID {
+			meetings = append(meetings, meeting)
+		}
+	}
+	return meetings
+}
+
+func (s *MeetingService) findBookedMeetings() []models.Meeting {
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		meetings, err := s.meetingRepo.FindMeetingsByStatus(MeetingBooked)
+		if err == nil {
+			return meetings
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	defer mockMeetingsMu.Unlock()
+	var meetings []models.Meeting
+	for _, meeting := range mockMeetings {
+		if meeting.Status == MeetingBooked {
+			meetings = append(meetings, meeting)
+		}
+	}
+	return meetings
+}
+
+func (s *MeetingService) saveMeeting(meeting *models.Meeting) error {
+	// Try Firestore first
+	if s.meetingRepo.IsFirestoreAvailable() {
+		if err := s.meetingRepo.SaveMeeting(meeting); err == nil {
+			return nil
+		}
+	}
+
+	// Fallback to mock data
+	mockMeetingsMu.Lock()
+	defer mockMeetingsMu.Unlock()
+	for i := range mockMeetings {
+		if mockMeetings[i].ID == meeting.ID {
+			mockMeetings[i] = *meeting
+			return nil
+		}
+	}
+	return ErrMeetingNotFound
+}
+
+// parseSlotTime reads an RFC 3339 time, or a local "2006-01-02T15:04" time in loc
+func parseSlotTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(slotLocalLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a time like 2006-01-02T15:04")
+	}
+	return t, nil
+}
+
+func overlaps(startA, endA, startB, endB time.Time) bool {
+	return startA.Before(endB) && startB.Before(endA)
+}
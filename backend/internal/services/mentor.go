@@ -1,7 +1,9 @@
 package services
 
 import (
-	"fmt"
+	"errors"
+	"slices"
+	"sort"
 	"time"
 
 	"mentorsphere-api/internal/models"
@@ -27,6 +29,10 @@ var mockInterventions = []models.Intervention{
 		Message:     "Ahmad, saya perhatikan aktivitas belajar Anda menurun minggu ini. Apakah ada kendala yang bisa saya bantu?",
 		Status:      "sent",
 		CreatedAt:   time.Now().Add(-24 * time.Hour),
+		SentAt:      timePtr(time.Now().Add(-24 * time.Hour)),
+		UpdatedAt:   time.Now().Add(-24 * time.Hour),
+
+		OutcomeWindowDays: 7,
 	},
 	{
 		ID:            "2",
@@ -38,6 +44,10 @@ var mockInterventions = []models.Intervention{
 		Status:        "scheduled",
 		CreatedAt:     time.Now().Add(-48 * time.Hour),
 		ScheduledDate: timePtr(time.Now().Add(24 * time.Hour)),
+		SentAt:        timePtr(time.Now().Add(-48 * time.Hour)),
+		UpdatedAt:     time.Now().Add(-48 * time.Hour),
+
+		OutcomeWindowDays: 7,
 	},
 	{
 		ID:          "3",
@@ -48,277 +58,275 @@ var mockInterventions = []models.Intervention{
 		Message:     "Saya lampirkan materi tambahan untuk membantu pemahaman Anda tentang Decision Trees.",
 		Status:      "sent",
 		CreatedAt:   time.Now().Add(-72 * time.Hour),
+		SentAt:      timePtr(time.Now().Add(-72 * time.Hour)),
+		UpdatedAt:   time.Now().Add(-72 * time.Hour),
 		Attachments: []string{"decision_trees_guide.pdf"},
+
+		OutcomeWindowDays: 7,
 	},
 }
 
 var mockNotifications = []models.Notification{
-	{ID: "1", Type: "danger", Title: "Danger Zone", Message: "Ahmad Wijaya tidak aktif selama 5 hari", Time: "2 jam lalu", Read: false},
-	{ID: "2", Type: "warning", Title: "Perlu Perhatian", Message: "Eko Prasetyo menunjukkan penurunan performa", Time: "5 jam lalu", Read: false},
-	{ID: "3", Type: "info", Title: "Pencapaian Baru", Message: "Dewi Lestari menyelesaikan course Machine Learning", Time: "1 hari lalu", Read: true},
-	{ID: "4", Type: "success", Title: "Intervensi Berhasil", Message: "Siti Rahayu kembali aktif setelah reminder", Time: "2 hari lalu", Read: true},
+	{ID: "1", UserID: "4", Type: "danger", Category: CategoryAlert, Title: "Danger Zone", Message: "Ahmad Wijaya tidak aktif selama 5 hari", Read: false, CreatedAt: time.Now().Add(-2 * time.Hour)},
+	{ID: "2", UserID: "4", Type: "warning", Category: CategoryAlert, Title: "Perlu Perhatian", Message: "Eko Prasetyo menunjukkan penurunan performa", Read: false, CreatedAt: time.Now().Add(-5 * time.Hour)},
+	{ID: "3", UserID: "4", Type: "info", Category: CategoryCourseUpdates, Title: "Pencapaian Baru", Message: "Dewi Lestari menyelesaikan course Machine Learning", Read: true, CreatedAt: time.Now().Add(-24 * time.Hour)},
+	{ID: "4", UserID: "4", Type: "success", Category: CategorySystem, Title: "Intervensi Berhasil", Message: "Siti Rahayu kembali aktif setelah reminder", Read: true, CreatedAt: time.Now().Add(-48 * time.Hour)},
 }
 
+const studentDetailActivityLimit = 20
+
+var (
+	ErrStudentNotFound    = errors.New("student not found")
+	ErrStudentNotAssigned = errors.New("student is not assigned to this mentor")
+)
+
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
 
 type MentorService struct {
-	userRepo         *repository.UserRepository
-	interventionRepo *repository.InterventionRepository
-	notificationRepo *repository.NotificationRepository
+	userRepo            *repository.UserRepository
+	interventionRepo    *repository.InterventionRepository
+	activityRepo        *repository.ActivityRepository
+	settingsRepo        *repository.SettingsRepository
+	courseService       *CourseService
+	authService         *AuthService
+	reflectionService   *ReflectionService
+	notificationService *NotificationService
+	riskService         *RiskService
+	interventionService *InterventionService
 }
 
 func NewMentorService() *MentorService {
 	return &MentorService{
-		userRepo:         repository.NewUserRepository(),
-		interventionRepo: repository.NewInterventionRepository(),
-		notificationRepo: repository.NewNotificationRepository(),
+		userRepo:            repository.NewUserRepository(),
+		interventionRepo:    repository.NewInterventionRepository(),
+		activityRepo:        repository.NewActivityRepository(),
+		settingsRepo:        repository.NewSettingsRepository(),
+		courseService:       NewCourseService(),
+		authService:         NewAuthService(),
+		reflectionService:   NewReflectionService(),
+		notificationService: NewNotificationService(),
+		riskService:         NewRiskService(),
+		interventionService: NewInterventionService(),
 	}
 }
 
-func (s *MentorService) GetDashboard(mentorID string) *models.MentorDashboard {
-	// Filter students at risk
-	var studentsAtRisk []models.StudentRiskData
-	for _, student := range mockStudentRiskData {
-		if student.RiskScore >= 50 {
-			studentsAtRisk = append(studentsAtRisk, student)
+// getRecentInterventions returns the mentor's newest interventions with the
+// given students
+func (s *MentorService) getRecentInterventions(mentorID string, students map[string]bool, limit int) []models.Intervention {
+	interventions := []models.Intervention{}
+	for _, intervention := range s.interventionService.ListByMentor(mentorID) {
+		if !students[intervention.StudentID] {
+			continue
 		}
-	}
-
-	// Global activity (aggregated)
-	var globalActivity []models.WeeklyActivity
-	for _, w := range mockWeeklyActivity {
-		globalActivity = append(globalActivity, models.WeeklyActivity{
-			Day:        w.Day,
-			StudyTime:  w.StudyTime * 3,
-			Activities: w.Activities * 5,
-		})
-	}
-
-	// Get recent interventions
-	recentInterventions := s.getRecentInterventions(mentorID, 3)
-
-	// Get notifications
-	notifications := s.getNotifications(mentorID)
-
-	return &models.MentorDashboard{
-		Stats: models.MentorStats{
-			TotalStudents:         25,
-			ActiveStudents:        22,
-			AverageProgress:       62,
-			StudentsAtRisk:        4,
-			InterventionsThisWeek: 7,
-			CompletionRate:        78,
-		},
-		StudentsAtRisk:      studentsAtRisk,
-		RecentInterventions: recentInterventions,
-		Notifications:       notifications,
-		GlobalActivity:      globalActivity,
-		RiskDistribution: []models.RiskDistribution{
-			{Level: "Low", Count: 15, Color: "hsl(var(--success))"},
-			{Level: "Medium", Count: 6, Color: "hsl(var(--warning))"},
-			{Level: "High", Count: 4, Color: "hsl(var(--destructive))"},
-		},
-	}
-}
-
-func (s *MentorService) getRecentInterventions(mentorID string, limit int) []models.Intervention {
-	// Try Firestore first
-	if s.interventionRepo.IsFirestoreAvailable() {
-		interventions, err := s.interventionRepo.FindByMentorID(mentorID)
-		if err == nil && len(interventions) > 0 {
-			if len(interventions) > limit {
-				return interventions[:limit]
-			}
-			return interventions
+		interventions = append(interventions, intervention)
+		if len(interventions) == limit {
+			break
 		}
 	}
-
-	// Fallback to mock data
-	if len(mockInterventions) > limit {
-		return mockInterventions[:limit]
-	}
-	return mockInterventions
+	return interventions
 }
 
 func (s *MentorService) getNotifications(mentorID string) []models.Notification {
-	// Try Firestore first
-	if s.notificationRepo.IsFirestoreAvailable() {
-		notifications, err := s.notificationRepo.FindByUserID(mentorID)
-		if err == nil && len(notifications) > 0 {
-			return notifications
-		}
+	page, err := s.notificationService.List(models.NotificationQuery{UserID: mentorID, Limit: 5})
+	if err != nil {
+		return []models.Notification{}
 	}
-
-	// Fallback to mock data
-	return mockNotifications
+	return page.Notifications
 }
 
 func (s *MentorService) GetStudents(mentorID string) []models.StudentRiskData {
 	// Try Firestore first
 	if s.userRepo.IsFirestoreAvailable() {
 		students, err := s.userRepo.GetStudentsByMentor(mentorID)
-		if err == nil && len(students) > 0 {
-			var riskData []models.StudentRiskData
-			for _, student := range students {
-				riskData = append(riskData, models.StudentRiskData{
-					ID:         student.ID,
-					Name:       student.Name,
-					RiskScore:  student.RiskScore,
-					LastActive: student.JoinedDate.Format("2006-01-02"),
-					Progress:   student.CompletedModules * 100 / 20, // Estimate
-					Trend:      "stable",
-				})
+		if err == nil {
+			// Progress and last activity come from the student's own module
+			// progress and activity log
+			config := s.riskService.GetConfig()
+			now := time.Now()
+			riskData := []models.StudentRiskData{}
+			for i := range students {
+				riskData = append(riskData, s.summarizeStudent(&students[i], "", now, now, config).data)
 			}
 			return riskData
 		}
 	}
 
-	// Fallback to mock data
-	return mockStudentRiskData
+	// Fallback to mock data of the mentor's students, using recorded
+	// snapshots where there are enough
+	assigned := make(map[string]bool)
+	for _, student := range findAssignedStudents(s.userRepo, mentorID) {
+		assigned[student.ID] = true
+	}
+	riskData := []models.StudentRiskData{}
+	for _, data := range mockStudentRiskData {
+		if !assigned[data.ID] {
+			continue
+		}
+		if trend := s.riskService.GetTrend(data.ID); trend.Samples >= 2 {
+			data.Trend = trend.Direction
+		}
+		riskData = append(riskData, data)
+	}
+	return riskData
 }
 
-func (s *MentorService) GetStudentDetail(studentID string) (*models.StudentDetail, error) {
-	var student *models.StudentRiskData
-	for _, s := range mockStudentRiskData {
-		if s.ID == studentID {
-			student = &s
-			break
-		}
+// GetStudentRiskHistory returns the daily risk scores and trend of a student
+// assigned to the mentor (admins can open any student)
+func (s *MentorService) GetStudentRiskHistory(mentorID, studentID string, days int) (*models.RiskHistory, error) {
+	student, err := s.assignedStudent(mentorID, studentID)
+	if err != nil {
+		return nil, err
 	}
+	return s.riskService.GetHistory(student.ID, days)
+}
 
-	if student == nil {
-		return nil, fmt.Errorf("student not found")
+// assignedStudent loads a student the mentor may open: one assigned to them,
+// or any student when the mentor is an admin
+func (s *MentorService) assignedStudent(mentorID, studentID string) (*models.User, error) {
+	mentor, err := s.authService.GetUserByID(mentorID)
+	if err != nil {
+		return nil, ErrStudentNotAssigned
+	}
+	student, err := s.authService.GetUserByID(studentID)
+	if err != nil || student.Role != "student" {
+		return nil, ErrStudentNotFound
+	}
+	if mentor.Role != "admin" && !slices.ContainsFunc(findMentorsOfStudent(s.userRepo, student.ID), func(m models.User) bool {
+		return m.ID == mentor.ID
+	}) {
+		return nil, ErrStudentNotAssigned
 	}
+	return student, nil
+}
 
-	authService := NewAuthService()
-	user, _ := authService.GetUserByID(studentID)
-	if user == nil {
-		user = &models.User{
-			ID:     studentID,
-			Name:   student.Name,
-			Avatar: fmt.Sprintf("https://api.dicebear.com/7.x/avataaars/svg?seed=%s", student.Name),
-		}
+// GetStudentDetail returns the drill-down of a student assigned to the mentor
+// (admins can open any student): their profile, recent activity, the last 7
+// days of study per weekday, reflection, interventions and performance
+// metrics.
+func (s *MentorService) GetStudentDetail(mentorID, studentID string) (*models.StudentDetail, error) {
+	student, err := s.assignedStudent(mentorID, studentID)
+	if err != nil {
+		return nil, err
 	}
 
-	interventionHistory := s.getStudentInterventions(studentID)
+	// Opening the detail only reads what has been recorded; the summary sees
+	// the score of the latest risk snapshot
+	reflection := s.reflectionService.GetStoredReflection(student.ID)
+	if !reflection.RiskAssessment.ComputedAt.IsZero() {
+		student.RiskScore = reflection.RiskAssessment.Score
+	}
+
+	loc := userLocation(loadUserSettings(s.settingsRepo, student.ID))
+	today := startOfDay(time.Now(), loc)
+	summary := s.summarizeStudent(student, "", today.AddDate(0, 0, -6), today.AddDate(0, 0, 1).Add(-time.Nanosecond), s.riskService.GetConfig())
+
+	activityLog := findUserActivities(s.activityRepo, student.ID)
+	sort.SliceStable(activityLog, func(i, j int) bool { return activityLog[i].Date.After(activityLog[j].Date) })
+	if len(activityLog) > studentDetailActivityLimit {
+		activityLog = activityLog[:studentDetailActivityLimit]
+	}
+	if activityLog == nil {
+		activityLog = []models.ActivityLog{}
+	}
 
-	reflectionService := NewReflectionService()
+	// Drafts are only shown to the mentor who wrote them
+	interventions := []models.Intervention{}
+	for _, intervention := range s.getStudentInterventions(student.ID) {
+		if intervention.Status == InterventionDraft && intervention.MentorID != mentorID {
+			continue
+		}
+		interventions = append(interventions, intervention)
+	}
 
 	return &models.StudentDetail{
-		StudentRiskData:     *student,
-		User:                *user,
-		ActivityLog:         mockActivityLogs[:5],
-		WeeklyActivity:      mockWeeklyActivity,
-		AIInsights:          *reflectionService.GetReflection(studentID),
-		InterventionHistory: interventionHistory,
-		PerformanceMetrics: models.PerformanceMetrics{
-			AverageQuizScore: 82,
-			CompletionRate:   float64(student.Progress),
-			EngagementScore:  75,
-			ConsistencyScore: 68,
-		},
+		StudentRiskData:     summary.data,
+		User:                *student,
+		ActivityLog:         activityLog,
+		WeeklyActivity:      weekdayActivity(summary.activities, loc),
+		AIInsights:          *reflection,
+		InterventionHistory: interventions,
+		PerformanceMetrics:  s.performanceMetrics(student.ID, &reflection.RiskAssessment),
 	}, nil
 }
 
-func (s *MentorService) getStudentInterventions(studentID string) []models.Intervention {
-	// Try Firestore first
-	if s.interventionRepo.IsFirestoreAvailable() {
-		interventions, err := s.interventionRepo.FindByStudentID(studentID)
-		if err == nil {
-			return interventions
+// performanceMetrics computes a student's performance metrics, each 0-100:
+//   - averageQuizScore: mean quiz score of the last 30 days of activity, else
+//     of the scored quiz modules; 0 without any score
+//   - completionRate: completed modules / modules of the enrolled courses
+//   - engagementScore: the engagement risk factor, i.e. study minutes of the
+//     last 7 days against the weekly goal (80%) and forum posts against 3 (20%)
+//   - consistencyScore: the consistency risk factor, i.e. the share of days
+//     with study activity in the risk window
+func (s *MentorService) performanceMetrics(studentID string, assessment *models.RiskAssessment) models.PerformanceMetrics {
+	var metrics models.PerformanceMetrics
+	courses := s.courseService.GetUserCourses(studentID)
+
+	var scores []int
+	quizWindowStart := time.Now().AddDate(0, 0, -riskQuizWindowDays)
+	for _, activity := range findUserActivities(s.activityRepo, studentID) {
+		if activity.Type == "quiz" && activity.Score != nil && activity.Date.After(quizWindowStart) {
+			scores = append(scores, *activity.Score)
 		}
 	}
-
-	// Fallback to mock data
-	var interventionHistory []models.Intervention
-	for _, i := range mockInterventions {
-		if i.StudentID == studentID {
-			interventionHistory = append(interventionHistory, i)
+	completed, total := 0, 0
+	for _, course := range courses {
+		for _, module := range course.Modules {
+			total++
+			if module.Status == ModuleCompleted {
+				completed++
+			}
 		}
 	}
-	return interventionHistory
-}
-
-func (s *MentorService) CreateIntervention(mentorID string, req models.CreateInterventionRequest) (*models.Intervention, error) {
-	intervention := &models.Intervention{
-		StudentID:     req.StudentID,
-		StudentName:   req.StudentName,
-		MentorID:      mentorID,
-		Type:          req.Type,
-		Message:       req.Message,
-		Status:        "sent",
-		CreatedAt:     time.Now(),
-		ScheduledDate: req.ScheduledDate,
-		Attachments:   req.Attachments,
+	if len(scores) == 0 {
+		for _, course := range courses {
+			for _, module := range course.Modules {
+				if module.Type == "quiz" && module.Score != nil {
+					scores = append(scores, *module.Score)
+				}
+			}
+		}
 	}
 
-	// Try Firestore first
-	if s.interventionRepo.IsFirestoreAvailable() {
-		if err := s.interventionRepo.Create(intervention); err == nil {
-			return intervention, nil
+	if len(scores) > 0 {
+		sum := 0
+		for _, score := range scores {
+			sum += score
 		}
+		metrics.AverageQuizScore = round1(float64(sum) / float64(len(scores)))
 	}
-
-	// Fallback to mock data
-	intervention.ID = fmt.Sprintf("%d", len(mockInterventions)+1)
-	mockInterventions = append(mockInterventions, *intervention)
-	return intervention, nil
-}
-
-func (s *MentorService) GetInterventions(mentorID string) []models.Intervention {
-	// Try Firestore first
-	if s.interventionRepo.IsFirestoreAvailable() {
-		interventions, err := s.interventionRepo.FindByMentorID(mentorID)
-		if err == nil {
-			return interventions
+	if total > 0 {
+		metrics.CompletionRate = round1(float64(completed) * 100 / float64(total))
+	}
+	for _, factor := range assessment.Factors {
+		switch factor.Key {
+		case RiskFactorEngagement:
+			metrics.EngagementScore = float64(factor.Value)
+		case RiskFactorConsistency:
+			metrics.ConsistencyScore = float64(factor.Value)
 		}
 	}
 
-	// Fallback to mock data
-	return mockInterventions
+	return metrics
 }
 
-func (s *MentorService) UpdateInterventionStatus(interventionID string, req models.UpdateInterventionRequest) error {
-	// Try Firestore first
-	if s.interventionRepo.IsFirestoreAvailable() {
-		if err := s.interventionRepo.UpdateStatus(interventionID, req.Status, req.Response); err == nil {
-			return nil
-		}
-	}
+func (s *MentorService) getStudentInterventions(studentID string) []models.Intervention {
+	return s.interventionService.ListByStudent(studentID, true)
+}
 
-	// Fallback to mock data
-	for i := range mockInterventions {
-		if mockInterventions[i].ID == interventionID {
-			mockInterventions[i].Status = req.Status
-			if req.Response != nil {
-				mockInterventions[i].Response = req.Response
-			}
-			return nil
-		}
-	}
-	return fmt.Errorf("intervention not found")
+func (s *MentorService) CreateIntervention(mentorID string, req models.CreateInterventionRequest) (*models.Intervention, error) {
+	return s.interventionService.Create(mentorID, req)
 }
 
-func (s *MentorService) GetNotifications(mentorID string) []models.Notification {
-	return s.getNotifications(mentorID)
+func (s *MentorService) GetInterventions(mentorID string) []models.Intervention {
+	return s.interventionService.ListByMentor(mentorID)
 }
 
-func (s *MentorService) MarkNotificationRead(notificationID string) error {
-	// Try Firestore first
-	if s.notificationRepo.IsFirestoreAvailable() {
-		if err := s.notificationRepo.MarkAsRead(notificationID); err == nil {
-			return nil
-		}
-	}
+func (s *MentorService) GetIntervention(mentorID, interventionID string) (*models.Intervention, error) {
+	return s.interventionService.GetForMentor(mentorID, interventionID)
+}
 
-	// Fallback to mock data
-	for i := range mockNotifications {
-		if mockNotifications[i].ID == notificationID {
-			mockNotifications[i].Read = true
-			return nil
-		}
-	}
-	return fmt.Errorf("notification not found")
+func (s *MentorService) UpdateInterventionStatus(mentorID, interventionID string, req models.UpdateInterventionRequest) (*models.Intervention, error) {
+	return s.interventionService.UpdateStatus(mentorID, interventionID, req)
 }
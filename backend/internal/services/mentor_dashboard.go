@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+const (
+	defaultDashboardDays = 7
+	maxDashboardDays     = 365
+)
+
+// Day labels of the dashboard activity chart, Monday first
+var weekdayLabels = []string{"Sen", "Sel", "Rab", "Kam", "Jum", "Sab", "Min"}
+
+// MentorDashboardQuery filters the mentor dashboard. From and To are dates in
+// the mentor's timezone and default to the Days days ending today. CourseID
+// narrows the dashboard to the students enrolled in that course and to their
+// progress and activity in it.
+type MentorDashboardQuery struct {
+	From     string
+	To       string
+	Days     int
+	CourseID string
+}
+
+// studentSummary is what the mentor views need to know about one student
+type studentSummary struct {
+	data       models.StudentRiskData
+	level      string
+	courses    int
+	completed  int
+	activities []models.ActivityLog
+}
+
+// GetDashboard computes the mentor's dashboard from the students assigned to
+// them:
+//   - activeStudents: students with activity in the date range
+//   - averageProgress: mean over students of their average course progress
+//   - studentsAtRisk: students at or above the high risk threshold
+//   - interventionsThisWeek: interventions sent since Monday of the current
+//     ISO week
+//   - completionRate: share of the students' course enrollments completed
+//   - globalActivity: the students' minutes and activities in the date range,
+//     per weekday
+func (s *MentorService) GetDashboard(mentorID string, query MentorDashboardQuery) (*models.MentorDashboard, error) {
+	loc := userLocation(loadUserSettings(s.settingsRepo, mentorID))
+	from, to, err := dashboardRange(query, loc)
+	if err != nil {
+		return nil, err
+	}
+	if query.CourseID != "" && s.courseService.GetByID(query.CourseID) == nil {
+		return nil, ErrCourseNotFound
+	}
+
+	config := s.riskService.GetConfig()
+	dashboard := &models.MentorDashboard{
+		StudentsAtRisk: []models.StudentRiskData{},
+		From:           from.Format(dateLayout),
+		To:             to.Format(dateLayout),
+		CourseID:       query.CourseID,
+	}
+	levels := map[string]int{}
+
+	students := make(map[string]bool)
+	var activities []models.ActivityLog
+	progressTotal, enrollments, completed := 0, 0, 0
+	for _, student := range findAssignedStudents(s.userRepo, mentorID) {
+		if query.CourseID != "" && !slices.Contains(student.EnrolledCourses, query.CourseID) {
+			continue
+		}
+		summary := s.summarizeStudent(&student, query.CourseID, from, to.AddDate(0, 0, 1).Add(-time.Nanosecond), config)
+		students[student.ID] = true
+
+		dashboard.Stats.TotalStudents++
+		if len(summary.activities) > 0 {
+			dashboard.Stats.ActiveStudents++
+		}
+		progressTotal += summary.data.Progress
+		enrollments += summary.courses
+		completed += summary.completed
+
+		levels[summary.level]++
+		if summary.level == "high" {
+			dashboard.StudentsAtRisk = append(dashboard.StudentsAtRisk, summary.data)
+		}
+
+		activities = append(activities, summary.activities...)
+	}
+	dashboard.GlobalActivity = weekdayActivity(activities, loc)
+
+	dashboard.Stats.StudentsAtRisk = len(dashboard.StudentsAtRisk)
+	if dashboard.Stats.TotalStudents > 0 {
+		dashboard.Stats.AverageProgress = round1(float64(progressTotal) / float64(dashboard.Stats.TotalStudents))
+	}
+	if enrollments > 0 {
+		dashboard.Stats.CompletionRate = round1(float64(completed) * 100 / float64(enrollments))
+	}
+
+	weekStart := isoWeekStartOf(time.Now(), loc)
+	for _, intervention := range s.interventionService.ListByMentor(mentorID) {
+		if students[intervention.StudentID] && intervention.SentAt != nil && !intervention.SentAt.Before(weekStart) {
+			dashboard.Stats.InterventionsThisWeek++
+		}
+	}
+
+	dashboard.RecentInterventions = s.getRecentInterventions(mentorID, students, 3)
+	dashboard.Notifications = s.getNotifications(mentorID)
+	dashboard.RiskDistribution = []models.RiskDistribution{
+		{Level: "Low", Count: levels["low"], Color: "hsl(var(--success))"},
+		{Level: "Medium", Count: levels["medium"], Color: "hsl(var(--warning))"},
+		{Level: "High", Count: levels["high"], Color: "hsl(var(--destructive))"},
+	}
+
+	return dashboard, nil
+}
+
+// summarizeStudent gathers a student's risk data, course completion and
+// activities in [start, end], limited to one course when courseID is set
+func (s *MentorService) summarizeStudent(student *models.User, courseID string, start, end time.Time, config models.RiskConfig) studentSummary {
+	summary := studentSummary{
+		data: models.StudentRiskData{
+			ID:        student.ID,
+			Name:      student.Name,
+			RiskScore: student.RiskScore,
+			Trend:     s.riskService.GetTrend(student.ID).Direction,
+		},
+		level: riskLevel(student.RiskScore, config),
+	}
+
+	progressTotal := 0
+	for _, course := range s.courseService.GetUserCourses(student.ID) {
+		if courseID != "" && course.ID != courseID {
+			continue
+		}
+		summary.courses++
+		progressTotal += course.Progress
+		if courseCompleted(course) {
+			summary.completed++
+		}
+	}
+	if summary.courses > 0 {
+		summary.data.Progress = progressTotal / summary.courses
+	}
+
+	lastActive := student.JoinedDate
+	for _, activity := range findUserActivities(s.activityRepo, student.ID) {
+		if activity.Date.After(lastActive) {
+			lastActive = activity.Date
+		}
+		if courseID != "" && activity.CourseID != courseID {
+			continue
+		}
+		if !activity.Date.Before(start) && !activity.Date.After(end) {
+			summary.activities = append(summary.activities, activity)
+		}
+	}
+	summary.data.LastActive = lastActive.Format(dateLayout)
+
+	return summary
+}
+
+// weekdayActivity sums study minutes and activities per local weekday, Monday
+// first
+func weekdayActivity(activities []models.ActivityLog, loc *time.Location) []models.WeeklyActivity {
+	weekly := make([]models.WeeklyActivity, len(weekdayLabels))
+	for i, label := range weekdayLabels {
+		weekly[i].Day = label
+	}
+	for _, activity := range activities {
+		day := &weekly[(int(activity.Date.In(loc).Weekday())+6)%7]
+		day.StudyTime += activity.Duration
+		day.Activities++
+	}
+	return weekly
+}
+
+// dashboardRange resolves the query's date range to local midnights of its
+// first and last day
+func dashboardRange(query MentorDashboardQuery, loc *time.Location) (time.Time, time.Time, error) {
+	if query.Days == 0 {
+		query.Days = defaultDashboardDays
+	}
+	if query.Days < 1 || query.Days > maxDashboardDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("days must be between 1 and %d", maxDashboardDays)
+	}
+
+	to := startOfDay(time.Now(), loc)
+	if query.To != "" {
+		parsed, err := time.ParseInLocation(dateLayout, query.To, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("to must be a date in YYYY-MM-DD format")
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -(query.Days - 1))
+	if query.From != "" {
+		parsed, err := time.ParseInLocation(dateLayout, query.From, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("from must be a date in YYYY-MM-DD format")
+		}
+		from = parsed
+	}
+	if from.After(to) || to.Sub(from) >= maxDashboardDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must not be after to and at most %d days earlier", maxDashboardDays-1)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,536 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/events"
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+const (
+	defaultMessageLimit   = 30
+	maxMessageLimit       = 100
+	maxMessageLength      = 4000
+	maxMessageAttachments = 5
+	// Search looks through this many of the user's newest messages
+	messageSearchScan = 500
+	// Length of the message preview in new-message notifications
+	messagePreviewLength = 100
+)
+
+var (
+	ErrConversationNotFound  = errors.New("conversation not found")
+	ErrConversationForbidden = errors.New("conversation is only open to the assigned mentor and student")
+	ErrInvalidMessage        = errors.New("invalid message")
+)
+
+// Messages for development (used as fallback when Firebase is not configured)
+var (
+	mockMessagesMu sync.Mutex
+	mockMessageSeq = len(mockMessages)
+	mockMessages   = []models.Message{
+		{
+			ID:             "1",
+			ConversationID: "4_1",
+			Participants:   []string{"4", "1"},
+			SenderID:       "4",
+			RecipientID:    "1",
+			Kind:           models.MessageText,
+			Body:           "Halo Budi, bagaimana progres modul Decision Trees?",
+			Read:           true,
+			ReadAt:         timePtr(time.Now().Add(-26 * time.Hour)),
+			CreatedAt:      time.Now().Add(-27 * time.Hour),
+		},
+		{
+			ID:             "2",
+			ConversationID: "4_1",
+			Participants:   []string{"4", "1"},
+			SenderID:       "1",
+			RecipientID:    "4",
+			Kind:           models.MessageText,
+			Body:           "Sudah setengah jalan, Pak. Saya masih bingung dengan pruning.",
+			Read:           true,
+			ReadAt:         timePtr(time.Now().Add(-25 * time.Hour)),
+			CreatedAt:      time.Now().Add(-26 * time.Hour),
+		},
+		{
+			ID:             "3",
+			ConversationID: "4_1",
+			Participants:   []string{"4", "1"},
+			SenderID:       "4",
+			RecipientID:    "1",
+			Kind:           models.MessageText,
+			Body:           "Saya lampirkan panduan singkatnya, coba baca bagian 3.",
+			Attachments: []models.MessageAttachment{
+				{Name: "decision_trees_guide.pdf", URL: "https://mentorsphere.com/files/decision_trees_guide.pdf", ContentType: "application/pdf", Size: 482113},
+			},
+			CreatedAt: time.Now().Add(-3 * time.Hour),
+		},
+	}
+)
+
+type MessageService struct {
+	messageRepo         *repository.MessageRepository
+	userRepo            *repository.UserRepository
+	authService         *AuthService
+	notificationService *NotificationService
+}
+
+func NewMessageService() *MessageService {
+	return &MessageService{
+		messageRepo:         repository.NewMessageRepository(),
+		userRepo:            repository.NewUserRepository(),
+		authService:         NewAuthService(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// ListConversations returns a conversation for each of the user's current
+// mentor–student assignments, most recently active first
+func (s *MessageService) ListConversations(userID string) ([]models.Conversation, error) {
+	user, err := s.authService.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var mentors, students []models.User
+	switch user.Role {
+	case "mentor":
+		mentors = []models.User{*user}
+		students = findAssignedStudents(s.userRepo, userID)
+	case "student":
+		mentors = findMentorsOfStudent(s.userRepo, userID)
+		students = []models.User{*user}
+	}
+
+	conversations := []models.Conversation{}
+	for _, mentor := range mentors {
+		for _, student := range students {
+			id := conversationID(mentor.ID, student.ID)
+			conversation := models.Conversation{
+				ID:          id,
+				Mentor:      conversationUser(mentor),
+				Student:     conversationUser(student),
+				UnreadCount: len(s.findUnread(id, userID)),
+			}
+			if latest := s.findBefore(id, time.Time{}, 1); len(latest) > 0 {
+				conversation.LastMessage = &latest[0]
+			}
+			conversations = append(conversations, conversation)
+		}
+	}
+
+	sort.SliceStable(conversations, func(i, j int) bool {
+		return lastActivity(conversations[i]).After(lastActivity(conversations[j]))
+	})
+	return conversations, nil
+}
+
+// List returns a page of a conversation in chronological order. With Before
+// it pages back from that message; with After it returns the messages newer
+// than it, which is how clients without the event stream poll.
+func (s *MessageService) List(userID string, query models.MessageQuery) (*models.MessagePage, error) {
+	if _, _, err := s.authorize(userID, query.ConversationID); err != nil {
+		return nil, err
+	}
+	if query.Limit <= 0 || query.Limit > maxMessageLimit {
+		query.Limit = defaultMessageLimit
+	}
+
+	page := &models.MessagePage{Messages: []models.Message{}}
+
+	if query.After != "" {
+		cursor, err := s.findCursor(query.ConversationID, query.After)
+		if err != nil {
+			return nil, err
+		}
+		page.Messages = append(page.Messages, s.findAfter(query.ConversationID, cursor.CreatedAt, query.Limit)...)
+		return page, nil
+	}
+
+	var before time.Time
+	if query.Before != "" {
+		cursor, err := s.findCursor(query.ConversationID, query.Before)
+		if err != nil {
+			return nil, err
+		}
+		before = cursor.CreatedAt
+	}
+
+	messages := s.findBefore(query.ConversationID, before, query.Limit+1)
+	if len(messages) > query.Limit {
+		messages = messages[:query.Limit]
+		page.NextCursor = messages[len(messages)-1].ID
+	}
+	slices.Reverse(messages)
+	page.Messages = append(page.Messages, messages...)
+	return page, nil
+}
+
+// Send posts a message from the user to the other side of the conversation
+func (s *MessageService) Send(userID, id string, req models.SendMessageRequest) (*models.Message, error) {
+	mentorID, studentID, err := s.authorize(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if err := validateMessage(body, req.Attachments); err != nil {
+		return nil, err
+	}
+
+	recipientID := studentID
+	if userID == studentID {
+		recipientID = mentorID
+	}
+
+	message := &models.Message{
+		ConversationID: conversationID(mentorID, studentID),
+		Participants:   []string{mentorID, studentID},
+		SenderID:       userID,
+		RecipientID:    recipientID,
+		Kind:           models.MessageText,
+		Body:           body,
+		Attachments:    req.Attachments,
+		CreatedAt:      time.Now(),
+	}
+	s.create(message)
+
+	if sender, err := s.authService.GetUserByID(userID); err == nil {
+		preview := body
+		if preview == "" {
+			preview = fmt.Sprintf("Mengirim %d lampiran", len(req.Attachments))
+		}
+		if len([]rune(preview)) > messagePreviewLength {
+			preview = string([]rune(preview)[:messagePreviewLength]) + "…"
+		}
+		s.notificationService.Notify(recipientID, CategoryMentorMessages, "info", "Pesan baru dari "+sender.Name, preview)
+	}
+	return message, nil
+}
+
+// MarkRead marks the messages the user received in the conversation as read
+// and tells the sender through a read receipt
+func (s *MessageService) MarkRead(userID, id string) (*models.MessageReadEvent, error) {
+	mentorID, studentID, err := s.authorize(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &models.MessageReadEvent{
+		ConversationID: conversationID(mentorID, studentID),
+		ReaderID:       userID,
+		MessageIDs:     []string{},
+		ReadAt:         time.Now(),
+	}
+	for _, message := range s.findUnread(receipt.ConversationID, userID) {
+		if s.markRead(message.ID, receipt.ReadAt) {
+			receipt.MessageIDs = append(receipt.MessageIDs, message.ID)
+		}
+	}
+
+	if len(receipt.MessageIDs) > 0 {
+		events.Publish(events.TypeMessageRead, receipt, mentorID, studentID)
+	}
+	return receipt, nil
+}
+
+// Search finds the user's messages whose text or attachment names contain
+// the query, newest first, in the conversations open to the user
+func (s *MessageService) Search(userID, query string, limit int) ([]models.Message, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, fmt.Errorf("%w: search query is required", ErrInvalidMessage)
+	}
+	if limit <= 0 || limit > maxMessageLimit {
+		limit = defaultMessageLimit
+	}
+
+	conversations, err := s.ListConversations(userID)
+	if err != nil {
+		return nil, err
+	}
+	open := make(map[string]bool)
+	for _, conversation := range conversations {
+		open[conversation.ID] = true
+	}
+
+	results := []models.Message{}
+	for _, message := range s.findByParticipant(userID) {
+		if !open[message.ConversationID] || !messageMatches(message, query) {
+			continue
+		}
+		results = append(results, message)
+		if len(results) == limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// PostIntervention adds a delivered intervention to the conversation of its
+// mentor and student
+func (s *MessageService) PostIntervention(intervention *models.Intervention) {
+	attachments := make([]models.MessageAttachment, 0, len(intervention.Attachments))
+	for _, name := range intervention.Attachments {
+		attachments = append(attachments, models.MessageAttachment{Name: name})
+	}
+
+	s.create(&models.Message{
+		ConversationID: conversationID(intervention.MentorID, intervention.StudentID),
+		Participants:   []string{intervention.MentorID, intervention.StudentID},
+		SenderID:       intervention.MentorID,
+		RecipientID:    intervention.StudentID,
+		Kind:           models.MessageIntervention,
+		Body:           intervention.Message,
+		Attachments:    attachments,
+		InterventionID: intervention.ID,
+		CreatedAt:      time.Now(),
+	})
+}
+
+// PostInterventionResponse adds the student's response to an intervention to
+// the conversation
+func (s *MessageService) PostInterventionResponse(intervention *models.Intervention, response string) {
+	s.create(&models.Message{
+		ConversationID: conversationID(intervention.MentorID, intervention.StudentID),
+		Participants:   []string{intervention.MentorID, intervention.StudentID},
+		SenderID:       intervention.StudentID,
+		RecipientID:    intervention.MentorID,
+		Kind:           models.MessageInterventionResponse,
+		Body:           response,
+		InterventionID: intervention.ID,
+		CreatedAt:      time.Now(),
+	})
+}
+
+// authorize checks that the user is the mentor or student of the conversation
+// and that they are still assigned to each other. The IDs are copied: the
+// conversation ID usually comes from a route parameter whose memory Fiber
+// reuses after the request, and they end up stored in messages.
+func (s *MessageService) authorize(userID, id string) (string, string, error) {
+	mentorID, studentID, ok := strings.Cut(strings.Clone(id), "_")
+	if !ok || mentorID == "" || studentID == "" {
+		return "", "", ErrConversationNotFound
+	}
+	if userID != mentorID && userID != studentID {
+		return "", "", ErrConversationForbidden
+	}
+	for _, student := range findAssignedStudents(s.userRepo, mentorID) {
+		if student.ID == studentID {
+			return mentorID, studentID, nil
+		}
+	}
+	return "", "", ErrConversationForbidden
+}
+
+func validateMessage(body string, attachments []models.MessageAttachment) error {
+	if body == "" && len(attachments) == 0 {
+		return fmt.Errorf("%w: body or attachments are required", ErrInvalidMessage)
+	}
+	if len([]rune(body)) > maxMessageLength {
+		return fmt.Errorf("%w: body is longer than %d characters", ErrInvalidMessage, maxMessageLength)
+	}
+	if len(attachments) > maxMessageAttachments {
+		return fmt.Errorf("%w: at most %d attachments are allowed", ErrInvalidMessage, maxMessageAttachments)
+	}
+	for _, attachment := range attachments {
+		if strings.TrimSpace(attachment.Name) == "" {
+			return fmt.Errorf("%w: attachment name is required", ErrInvalidMessage)
+		}
+		u, err := url.Parse(attachment.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: attachment %q needs an http(s) URL", ErrInvalidMessage, attachment.Name)
+		}
+	}
+	return nil
+}
+
+func messageMatches(message models.Message, query string) bool {
+	if strings.Contains(strings.ToLower(message.Body), query) {
+		return true
+	}
+	for _, attachment := range message.Attachments {
+		if strings.Contains(strings.ToLower(attachment.Name), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func conversationID(mentorID, studentID string) string {
+	return mentorID + "_" + studentID
+}
+
+func conversationUser(user models.User) models.ConversationUser {
+	return models.ConversationUser{ID: user.ID, Name: user.Name, Avatar: user.Avatar}
+}
+
+func lastActivity(conversation models.Conversation) time.Time {
+	if conversation.LastMessage == nil {
+		return time.Time{}
+	}
+	return conversation.LastMessage.CreatedAt
+}
+
+// create stores a message and streams it to both participants
+func (s *MessageService) create(message *models.Message) {
+	stored := false
+
+	// Try Firestore first
+	if s.messageRepo.IsFirestoreAvailable() {
+		stored = s.messageRepo.Create(message) == nil
+	}
+
+	// Fallback to mock data
+	if !stored {
+		mockMessagesMu.Lock()
+		mockMessageSeq++
+		message.ID = fmt.Sprintf("%d", mockMessageSeq)
+		mockMessages = append(mockMessages, *message)
+		mockMessagesMu.Unlock()
+	}
+
+	events.Publish(events.TypeMessage, message, message.Participants...)
+}
+
+// findCursor returns the message a cursor refers to, which must belong to the
+// conversation
+func (s *MessageService) findCursor(conversationID, messageID string) (*models.Message, error) {
+	var message *models.Message
+
+	// Try Firestore first
+	if s.messageRepo.IsFirestoreAvailable() {
+		if found, err := s.messageRepo.FindByID(messageID); err == nil {
+			message = found
+		}
+	}
+
+	// Fallback to mock data
+	if message == nil {
+		mockMessagesMu.Lock()
+		for _, m := range mockMessages {
+			if m.ID == messageID {
+				message = &m
+				break
+			}
+		}
+		mockMessagesMu.Unlock()
+	}
+
+	if message == nil || message.ConversationID != conversationID {
+		return nil, fmt.Errorf("%w: unknown cursor %q", ErrInvalidMessage, messageID)
+	}
+	return message, nil
+}
+
+func (s *MessageService) findBefore(conversationID string, before time.Time, limit int) []models.Message {
+	// Try Firestore first
+	if s.messageRepo.IsFirestoreAvailable() {
+		messages, err := s.messageRepo.FindBefore(conversationID, before, limit)
+		if err == nil {
+			return messages
+		}
+	}
+
+	// Fallback to mock data
+	messages := filterMockMessages(func(m models.Message) bool {
+		return m.ConversationID == conversationID && (before.IsZero() || m.CreatedAt.Before(before))
+	})
+	slices.Reverse(messages)
+	return messages[:min(limit, len(messages))]
+}
+
+func (s *MessageService) findAfter(conversationID string, after time.Time, limit int) []models.Message {
+	// Try Firestore first
+	if s.messageRepo.IsFirestoreAvailable() {
+		messages, err := s.messageRepo.FindAfter(conversationID, after, limit)
+		if err == nil {
+			return messages
+		}
+	}
+
+	// Fallback to mock data
+	messages := filterMockMessages(func(m models.Message) bool {
+		return m.ConversationID == conversationID && m.CreatedAt.After(after)
+	})
+	return messages[:min(limit, len(messages))]
+}
+
+func (s *MessageService) findUnread(conversationID, recipientID string) []models.Message {
+	// Try Firestore first
+	if s.messageRepo.IsFirestoreAvailable() {
+		messages, err := s.messageRepo.FindUnread(conversationID, recipientID)
+		if err == nil {
+			return messages
+		}
+	}
+
+	// Fallback to mock data
+	return filterMockMessages(func(m models.Message) bool {
+		return m.ConversationID == conversationID && m.RecipientID == recipientID && !m.Read
+	})
+}
+
+func (s *MessageService) findByParticipant(userID string) []models.Message {
+	// Try Firestore first
+	if s.messageRepo.IsFirestoreAvailable() {
+		messages, err := s.messageRepo.FindByParticipant(userID, messageSearchScan)
+		if err == nil {
+			return messages
+		}
+	}
+
+	// Fallback to mock data
+	messages := filterMockMessages(func(m models.Message) bool {
+		return slices.Contains(m.Participants, userID)
+	})
+	slices.Reverse(messages)
+	return messages
+}
+
+func (s *MessageService) markRead(messageID string, at time.Time) bool {
+	// Try Firestore first
+	if s.messageRepo.IsFirestoreAvailable() {
+		if err := s.messageRepo.MarkAsRead(messageID, at); err == nil {
+			return true
+		}
+	}
+
+	// Fallback to mock data
+	mockMessagesMu.Lock()
+	defer mockMessagesMu.Unlock()
+	for i := range mockMessages {
+		if mockMessages[i].ID == messageID {
+			mockMessages[i].Read = true
+			mockMessages[i].ReadAt = &at
+			return true
+		}
+	}
+	return false
+}
+
+// filterMockMessages returns the matching mock messages, oldest first
+func filterMockMessages(match func(models.Message) bool) []models.Message {
+	mockMessagesMu.Lock()
+	defer mockMessagesMu.Unlock()
+
+	messages := []models.Message{}
+	for _, message := range mockMessages {
+		if match(message) {
+			messages = append(messages, message)
+		}
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+	return messages
+}
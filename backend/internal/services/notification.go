@@ -0,0 +1,377 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/events"
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+const (
+	defaultNotificationPageSize = 20
+	maxNotificationPageSize     = 100
+)
+
+var notificationTypes = []string{"info", "success", "warning", "danger"}
+
+var (
+	ErrNotificationNotFound  = errors.New("notification not found")
+	ErrNotificationForbidden = errors.New("notification belongs to another user")
+	ErrInvalidCursor         = errors.New("invalid cursor")
+)
+
+var mockNotificationsMu sync.Mutex
+
+// mockNotificationSeq numbers mock notifications, so IDs stay unique after deletes
+var mockNotificationSeq = len(mockNotifications)
+
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepository
+	deliveryService  *DeliveryService
+}
+
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		notificationRepo: repository.NewNotificationRepository(),
+		deliveryService:  NewDeliveryService(),
+	}
+}
+
+// Notify creates an in-app notification for a user and hands it to the
+// delivery pipeline, which also sends it by e-mail and Web Push when the
+// user's settings allow it for the category
+func (s *NotificationService) Notify(userID, category, notificationType, title, message string) (*models.Notification, error) {
+	notification := &models.Notification{
+		UserID:    userID,
+		Type:      notificationType,
+		Category:  category,
+		Title:     title,
+		Message:   message,
+		Read:      false,
+		CreatedAt: time.Now(),
+	}
+
+	// Try Firestore first
+	if s.notificationRepo.IsFirestoreAvailable() {
+		if err := s.notificationRepo.Create(notification); err == nil {
+			events.Publish(events.TypeNotification, notification, userID)
+			s.deliveryService.Deliver(notification)
+			return notification, nil
+		}
+	}
+
+	// Fallback to mock data
+	mockNotificationsMu.Lock()
+	mockNotificationSeq++
+	notification.ID = fmt.Sprintf("%d", mockNotificationSeq)
+	mockNotifications = append([]models.Notification{*notification}, mockNotifications...)
+	mockNotificationsMu.Unlock()
+
+	events.Publish(events.TypeNotification, notification, userID)
+	s.deliveryService.Deliver(notification)
+	return notification, nil
+}
+
+// List returns one page of the user's notifications, newest first, together
+// with the unread count
+func (s *NotificationService) List(query models.NotificationQuery) (*models.NotificationPage, error) {
+	if query.Limit <= 0 {
+		query.Limit = defaultNotificationPageSize
+	}
+	if query.Limit > maxNotificationPageSize {
+		query.Limit = maxNotificationPageSize
+	}
+	for _, notificationType := range query.Types {
+		if !slices.Contains(notificationTypes, notificationType) {
+			return nil, fmt.Errorf("unknown notification type %q", notificationType)
+		}
+	}
+
+	notifications, err := s.findPage(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.NotificationPage{Notifications: notifications}
+	if len(notifications) > query.Limit {
+		page.Notifications = notifications[:query.Limit]
+		page.NextCursor = page.Notifications[query.Limit-1].ID
+	}
+	if page.Notifications == nil {
+		page.Notifications = []models.Notification{}
+	}
+	page.UnreadCount = s.UnreadCount(query.UserID).Total
+
+	return page, nil
+}
+
+// UnreadCount counts the user's unread, unarchived notifications per type
+func (s *NotificationService) UnreadCount(userID string) *models.UnreadCount {
+	count := &models.UnreadCount{ByType: make(map[string]int)}
+
+	// Try Firestore first
+	if s.notificationRepo.IsFirestoreAvailable() {
+		if byType, err := s.notificationRepo.CountUnread(userID); err == nil {
+			count.ByType = byType
+		}
+	} else {
+		// Fallback to mock data
+		mockNotificationsMu.Lock()
+		for _, notification := range mockNotifications {
+			if notification.UserID == userID && !notification.Read && !notification.Archived {
+				count.ByType[notification.Type]++
+			}
+		}
+		mockNotificationsMu.Unlock()
+	}
+
+	for _, n := range count.ByType {
+		count.Total += n
+	}
+	return count
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *NotificationService) MarkRead(userID, notificationID string) (*models.Notification, error) {
+	notification, err := s.findOwned(userID, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	if notification.Read {
+		return notification, nil
+	}
+
+	now := time.Now()
+	notification.Read = true
+	notification.ReadAt = &now
+
+	// Try Firestore first
+	if s.notificationRepo.IsFirestoreAvailable() {
+		if err := s.notificationRepo.MarkAsRead(notificationID); err != nil {
+			return nil, err
+		}
+	} else {
+		// Fallback to mock data
+		s.updateMock(notificationID, func(n *models.Notification) {
+			n.Read = true
+			n.ReadAt = &now
+		})
+	}
+
+	s.publishRead(userID, notificationID)
+	return notification, nil
+}
+
+// MarkAllRead marks all of the user's unread notifications as read, or only
+// those of the given types, and returns how many were changed
+func (s *NotificationService) MarkAllRead(userID string, types []string) (int, error) {
+	for _, notificationType := range types {
+		if !slices.Contains(notificationTypes, notificationType) {
+			return 0, fmt.Errorf("unknown notification type %q", notificationType)
+		}
+	}
+
+	count, err := s.markAllRead(userID, types)
+	if count > 0 {
+		s.publishRead(userID, "")
+	}
+	return count, err
+}
+
+func (s *NotificationService) markAllRead(userID string, types []string) (int, error) {
+	// Try Firestore first
+	if s.notificationRepo.IsFirestoreAvailable() {
+		return s.notificationRepo.MarkAllAsRead(userID, types...)
+	}
+
+	// Fallback to mock data
+	mockNotificationsMu.Lock()
+	defer mockNotificationsMu.Unlock()
+	now := time.Now()
+	count := 0
+	for i := range mockNotifications {
+		n := &mockNotifications[i]
+		if n.UserID != userID || n.Read {
+			continue
+		}
+		if len(types) > 0 && !slices.Contains(types, n.Type) {
+			continue
+		}
+		n.Read = true
+		n.ReadAt = &now
+		count++
+	}
+	return count, nil
+}
+
+// publishRead tells the user's other connections about the new unread count
+func (s *NotificationService) publishRead(userID, notificationID string) {
+	events.Publish(events.TypeNotificationRead, models.NotificationReadEvent{
+		ID:          notificationID,
+		UnreadCount: s.UnreadCount(userID).Total,
+	}, userID)
+}
+
+// SetArchived moves one of the user's notifications to or out of the archive
+func (s *NotificationService) SetArchived(userID, notificationID string, archived bool) (*models.Notification, error) {
+	notification, err := s.findOwned(userID, notificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var archivedAt *time.Time
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+	}
+	notification.Archived = archived
+	notification.ArchivedAt = archivedAt
+
+	// Try Firestore first
+	if s.notificationRepo.IsFirestoreAvailable() {
+		if err := s.notificationRepo.SetArchived(notificationID, archived); err != nil {
+			return nil, err
+		}
+		return notification, nil
+	}
+
+	// Fallback to mock data
+	s.updateMock(notificationID, func(n *models.Notification) {
+		n.Archived = archived
+		n.ArchivedAt = archivedAt
+	})
+	return notification, nil
+}
+
+// Delete removes one of the user's notifications
+func (s *NotificationService) Delete(userID, notificationID string) error {
+	if _, err := s.findOwned(userID, notificationID); err != nil {
+		return err
+	}
+
+	// Try Firestore first
+	if s.notificationRepo.IsFirestoreAvailable() {
+		return s.notificationRepo.Delete(notificationID)
+	}
+
+	// Fallback to mock data
+	mockNotificationsMu.Lock()
+	defer mockNotificationsMu.Unlock()
+	mockNotifications = slices.DeleteFunc(mockNotifications, func(n models.Notification) bool {
+		return n.ID == notificationID
+	})
+	return nil
+}
+
+// Deliveries returns the delivery records of one of the user's notifications
+func (s *NotificationService) Deliveries(userID, notificationID string) ([]models.NotificationDelivery, error) {
+	if _, err := s.findOwned(userID, notificationID); err != nil {
+		return nil, err
+	}
+	return s.deliveryService.ListByNotification(notificationID), nil
+}
+
+// BackfillArchived marks notifications stored before archiving existed as not
+// archived, so they keep showing up in lists and unread counts. Mock
+// notifications always carry the field.
+func (s *NotificationService) BackfillArchived() (int, error) {
+	if !s.notificationRepo.IsFirestoreAvailable() {
+		return 0, nil
+	}
+	return s.notificationRepo.BackfillArchived()
+}
+
+func (s *NotificationService) findPage(query models.NotificationQuery) ([]models.Notification, error) {
+	// Try Firestore first
+	if s.notificationRepo.IsFirestoreAvailable() {
+		notifications, err := s.notificationRepo.FindPage(query)
+		if err != nil && query.Cursor != "" {
+			return nil, ErrInvalidCursor
+		}
+		return notifications, err
+	}
+
+	// Fallback to mock data
+	mockNotificationsMu.Lock()
+	defer mockNotificationsMu.Unlock()
+
+	var matching []models.Notification
+	for _, n := range mockNotifications {
+		if n.UserID != query.UserID || n.Archived != query.Archived {
+			continue
+		}
+		if len(query.Types) > 0 && !slices.Contains(query.Types, n.Type) {
+			continue
+		}
+		if query.UnreadOnly && n.Read {
+			continue
+		}
+		matching = append(matching, n)
+	}
+	slices.SortStableFunc(matching, func(a, b models.Notification) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	if query.Cursor != "" {
+		index := slices.IndexFunc(matching, func(n models.Notification) bool {
+			return n.ID == query.Cursor
+		})
+		if index < 0 {
+			return nil, ErrInvalidCursor
+		}
+		matching = matching[index+1:]
+	}
+	if len(matching) > query.Limit+1 {
+		matching = matching[:query.Limit+1]
+	}
+	return matching, nil
+}
+
+// findOwned loads a notification and checks that it belongs to the user
+func (s *NotificationService) findOwned(userID, notificationID string) (*models.Notification, error) {
+	var notification *models.Notification
+
+	// Try Firestore first
+	if s.notificationRepo.IsFirestoreAvailable() {
+		found, err := s.notificationRepo.FindByID(notificationID)
+		if err != nil {
+			return nil, ErrNotificationNotFound
+		}
+		notification = found
+	} else {
+		// Fallback to mock data
+		mockNotificationsMu.Lock()
+		for _, n := range mockNotifications {
+			if n.ID == notificationID {
+				found := n
+				notification = &found
+				break
+			}
+		}
+		mockNotificationsMu.Unlock()
+	}
+
+	if notification == nil {
+		return nil, ErrNotificationNotFound
+	}
+	if notification.UserID != userID {
+		return nil, ErrNotificationForbidden
+	}
+	return notification, nil
+}
+
+func (s *NotificationService) updateMock(notificationID string, update func(*models.Notification)) {
+	mockNotificationsMu.Lock()
+	defer mockNotificationsMu.Unlock()
+	for i := range mockNotifications {
+		if mockNotifications[i].ID == notificationID {
+			update(&mockNotifications[i])
+			return
+		}
+	}
+}
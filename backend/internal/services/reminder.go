@@ -0,0 +1,177 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+const (
+	clockLayout = "15:04"
+	// A reminder missed at its time (e.g. during a restart) is still sent
+	// within this window
+	reminderCatchUp = 3 * time.Hour
+)
+
+// Study reminders for development (used as fallback when Firebase is not configured)
+var (
+	mockStudyReminders   = make(map[string]models.StudyReminder)
+	mockStudyRemindersMu sync.Mutex
+)
+
+type ReminderService struct {
+	reminderRepo        *repository.ReminderRepository
+	settingsRepo        *repository.SettingsRepository
+	streakService       *StreakService
+	courseService       *CourseService
+	notificationService *NotificationService
+}
+
+func NewReminderService() *ReminderService {
+	return &ReminderService{
+		reminderRepo:        repository.NewReminderRepository(),
+		settingsRepo:        repository.NewSettingsRepository(),
+		streakService:       NewStreakService(),
+		courseService:       NewCourseService(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// SendDue sends the user's study reminder when it is due at now: study
+// reminders are enabled, the local reminder time has passed (moved to the end
+// of quiet hours when it falls inside them), it is not quiet hours now and
+// today's goal is not met yet. It reports whether a reminder was sent.
+func (s *ReminderService) SendDue(userID string, now time.Time) (bool, error) {
+	settings := loadUserSettings(s.settingsRepo, userID)
+	if !settings.Notifications.StudyReminder {
+		return false, nil
+	}
+
+	loc := userLocation(settings)
+	local := now.In(loc)
+	today := startOfDay(local, loc)
+
+	due, ok := reminderDueAt(settings, today)
+	if !ok || local.Before(due) || local.Sub(due) > reminderCatchUp {
+		return false, nil
+	}
+	if inQuietHours(settings.Notifications, local) {
+		return false, nil
+	}
+
+	streak := s.streakService.GetSummary(userID)
+	if streak.DailyGoal <= 0 || streak.GoalMet {
+		return false, nil
+	}
+
+	module, course := nextInProgressModule(s.courseService.GetUserCourses(userID))
+	reminder := &models.StudyReminder{
+		UserID:       userID,
+		Date:         today.Format(dateLayout),
+		ModuleTitle:  module,
+		CourseTitle:  course,
+		TodayMinutes: streak.TodayMinutes,
+		DailyGoal:    streak.DailyGoal,
+		SentAt:       now,
+	}
+	// Claiming the day first keeps restarts and retries from sending twice
+	claimed, err := s.claim(reminder)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	if _, err := s.notificationService.Notify(userID, CategoryStudyReminder, "info", "Waktunya Belajar", reminderMessage(reminder)); err != nil {
+		s.release(reminder)
+		return false, err
+	}
+	return true, nil
+}
+
+// claim records today's reminder, reporting false when it was already sent
+func (s *ReminderService) claim(reminder *models.StudyReminder) (bool, error) {
+	// Try Firestore first
+	if s.reminderRepo.IsFirestoreAvailable() {
+		return s.reminderRepo.Create(reminder)
+	}
+
+	// Fallback to mock data
+	mockStudyRemindersMu.Lock()
+	defer mockStudyRemindersMu.Unlock()
+	reminder.ID = reminder.UserID + "_" + reminder.Date
+	if _, ok := mockStudyReminders[reminder.ID]; ok {
+		return false, nil
+	}
+	mockStudyReminders[reminder.ID] = *reminder
+	return true, nil
+}
+
+// release forgets a claimed reminder whose notification failed, so the next
+// run sends it again
+func (s *ReminderService) release(reminder *models.StudyReminder) {
+	// Try Firestore first
+	if s.reminderRepo.IsFirestoreAvailable() {
+		if err := s.reminderRepo.Delete(reminder.ID); err == nil {
+			return
+		}
+	}
+
+	// Fallback to mock data
+	mockStudyRemindersMu.Lock()
+	delete(mockStudyReminders, reminder.ID)
+	mockStudyRemindersMu.Unlock()
+}
+
+// reminderDueAt returns when today's reminder is due: the reminder time, or
+// the end of quiet hours when the reminder time falls inside them. A reminder
+// pushed past midnight by quiet hours is not sent.
+func reminderDueAt(settings *models.UserSettings, today time.Time) (time.Time, bool) {
+	due, ok := clockOn(today, settings.Learning.ReminderTime)
+	if !ok {
+		return time.Time{}, false
+	}
+	if !inQuietHours(settings.Notifications, due) {
+		return due, true
+	}
+
+	end, ok := clockOn(today, settings.Notifications.QuietHoursEnd)
+	if !ok || !end.After(due) {
+		return time.Time{}, false
+	}
+	return end, true
+}
+
+// inQuietHours reports whether t falls within the quiet hours, which may wrap
+// past midnight
+func inQuietHours(prefs models.NotificationSettings, t time.Time) bool {
+	day := startOfDay(t, t.Location())
+	start, okStart := clockOn(day, prefs.QuietHoursStart)
+	end, okEnd := clockOn(day, prefs.QuietHoursEnd)
+	if !okStart || !okEnd || start.Equal(end) {
+		return false
+	}
+
+	if start.Before(end) {
+		return !t.Before(start) && t.Before(end)
+	}
+	return !t.Before(start) || t.Before(end)
+}
+
+// clockOn returns the "HH:MM" time of day on the given local day
+func clockOn(day time.Time, clock string) (time.Time, bool) {
+	parsed, err := time.Parse(clockLayout, clock)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), parsed.Hour(), parsed.Minute(), 0, 0, day.Location()), true
+}
+
+func reminderMessage(reminder *models.StudyReminder) string {
+	message := fmt.Sprintf("Anda baru belajar %d dari %d menit hari ini.", reminder.TodayMinutes, reminder.DailyGoal)
+	if reminder.ModuleTitle != "" {
+		return message + fmt.Sprintf(" Lanjutkan modul %s di %s.", reminder.ModuleTitle, reminder.CourseTitle)
+	}
+	return message + " Luangkan waktu sejenak untuk belajar hari ini."
+}
@@ -0,0 +1,633 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+// Risk factor keys, also used as keys of RiskConfig.Weights
+const (
+	RiskFactorConsistency    = "consistency"
+	RiskFactorTaskCompletion = "taskCompletion"
+	RiskFactorEngagement     = "engagement"
+	RiskFactorQuiz           = "quizPerformance"
+	RiskFactorInactivity     = "inactivity"
+)
+
+const (
+	riskConfigKey = "risk"
+	// Value used for a factor that has no data yet, so missing data neither
+	// hides nor inflates risk
+	riskNeutralValue = 50
+	// Window for quiz scores taken from the activity log
+	riskQuizWindowDays = 30
+	// Factors below this value are called out with a recommendation
+	riskWeakFactorValue = 70
+	// Forum posts per 7 days that count as full forum participation, and the
+	// share of the engagement factor that forum participation makes up
+	riskForumWeeklyPosts = 3
+	riskForumShare       = 0.2
+)
+
+var riskFactorNames = map[string]string{
+	RiskFactorConsistency:    "Konsistensi Belajar",
+	RiskFactorTaskCompletion: "Penyelesaian Tugas",
+	RiskFactorEngagement:     "Engagement",
+	RiskFactorQuiz:           "Quiz Performance",
+	RiskFactorInactivity:     "Hari Tidak Aktif",
+}
+
+var riskFactorRecommendations = map[string]string{
+	RiskFactorConsistency:    "Buat jadwal belajar rutin agar aktif lebih banyak hari dalam seminggu",
+	RiskFactorTaskCompletion: "Selesaikan modul yang sedang berjalan sebelum memulai materi baru",
+	RiskFactorEngagement:     "Tingkatkan waktu belajar mingguan mendekati target harian Anda dan aktif berdiskusi di forum course",
+	RiskFactorQuiz:           "Ulangi materi dengan skor quiz rendah sebelum mengerjakan quiz berikutnya",
+	RiskFactorInactivity:     "Kembali belajar hari ini, walau hanya sesi singkat 15 menit",
+}
+
+var riskLevelNames = map[string]string{
+	"low":    "rendah",
+	"medium": "sedang",
+	"high":   "tinggi",
+}
+
+func defaultRiskConfig() models.RiskConfig {
+	return models.RiskConfig{
+		Weights: map[string]float64{
+			RiskFactorConsistency:    0.25,
+			RiskFactorTaskCompletion: 0.2,
+			RiskFactorEngagement:     0.2,
+			RiskFactorQuiz:           0.15,
+			RiskFactorInactivity:     0.2,
+		},
+		MediumThreshold: 40,
+		HighThreshold:   60,
+		WindowDays:      14,
+		MaxInactiveDays: 14,
+		TrendWindowDays: 7,
+		TrendThreshold:  0.5,
+	}
+}
+
+// Risk configuration for development (used as fallback when Firebase is not configured)
+var (
+	mockRiskConfig    = defaultRiskConfig()
+	mockRiskSnapshots = make(map[string][]models.RiskSnapshot)
+	mockRiskMu        sync.Mutex
+)
+
+type RiskService struct {
+	configRepo    *repository.ConfigRepository
+	snapshotRepo  *repository.RiskSnapshotRepository
+	userRepo      *repository.UserRepository
+	activityRepo  *repository.ActivityRepository
+	settingsRepo  *repository.SettingsRepository
+	forumRepo     *repository.ForumRepository
+	authService   *AuthService
+	courseService *CourseService
+}
+
+func NewRiskService() *RiskService {
+	return &RiskService{
+		configRepo:    repository.NewConfigRepository(),
+		snapshotRepo:  repository.NewRiskSnapshotRepository(),
+		userRepo:      repository.NewUserRepository(),
+		activityRepo:  repository.NewActivityRepository(),
+		settingsRepo:  repository.NewSettingsRepository(),
+		forumRepo:     repository.NewForumRepository(),
+		authService:   NewAuthService(),
+		courseService: NewCourseService(),
+	}
+}
+
+// GetConfig returns the active risk model configuration
+func (s *RiskService) GetConfig() models.RiskConfig {
+	// Try Firestore first
+	if s.configRepo.IsFirestoreAvailable() {
+		var config models.RiskConfig
+		if err := s.configRepo.Find(riskConfigKey, &config); err == nil {
+			return withRiskConfigDefaults(config)
+		}
+		return defaultRiskConfig()
+	}
+
+	// Fallback to mock data
+	mockRiskMu.Lock()
+	defer mockRiskMu.Unlock()
+	return mockRiskConfig
+}
+
+// UpdateConfig validates and stores a new risk model configuration
+func (s *RiskService) UpdateConfig(config models.RiskConfig) (*models.RiskConfig, error) {
+	if err := validateRiskConfig(config); err != nil {
+		return nil, err
+	}
+	config.UpdatedAt = time.Now()
+
+	// Try Firestore first
+	if s.configRepo.IsFirestoreAvailable() {
+		if err := s.configRepo.Save(riskConfigKey, config); err != nil {
+			return nil, err
+		}
+		return &config, nil
+	}
+
+	// Fallback to mock data
+	mockRiskMu.Lock()
+	mockRiskConfig = config
+	mockRiskMu.Unlock()
+	return &config, nil
+}
+
+// Assess computes the student's risk score from their data, stores it on the
+// user and records it as today's snapshot
+func (s *RiskService) Assess(userID string) (*models.RiskAssessment, error) {
+	user, err := s.authService.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	config := s.GetConfig()
+	factors, snapshot := s.computeFactors(user, config)
+
+	weighted, score := weighRiskFactors(factors, config)
+	assessment := &models.RiskAssessment{
+		Score:      score,
+		Factors:    weighted,
+		ComputedAt: time.Now(),
+	}
+	assessment.Level = riskLevel(assessment.Score, config)
+	assessment.Explanation = riskExplanation(assessment)
+	assessment.Recommendations = riskRecommendations(assessment)
+
+	s.saveScore(userID, assessment.Score)
+
+	snapshot.Score = assessment.Score
+	snapshot.Level = assessment.Level
+	for _, factor := range assessment.Factors {
+		snapshot.Factors[factor.Key] = factor.Value
+	}
+	s.saveSnapshot(snapshot)
+
+	return assessment, nil
+}
+
+// GetHistory returns the student's daily risk snapshots of the last n days and their trend
+func (s *RiskService) GetHistory(userID string, days int) (*models.RiskHistory, error) {
+	if _, err := s.authService.GetUserByID(userID); err != nil {
+		return nil, err
+	}
+
+	config := s.GetConfig()
+	snapshots := s.getSnapshots(userID, max(days, config.TrendWindowDays))
+
+	history := &models.RiskHistory{
+		UserID:    userID,
+		Snapshots: []models.RiskSnapshot{},
+		Trend:     riskTrend(snapshots, config),
+	}
+	from := time.Now().AddDate(0, 0, -(days - 1)).Format(dateLayout)
+	for _, snapshot := range snapshots {
+		if snapshot.Date >= from {
+			history.Snapshots = append(history.Snapshots, snapshot)
+		}
+	}
+	return history, nil
+}
+
+// GetTrend returns the direction of the student's risk over the trend window
+func (s *RiskService) GetTrend(userID string) models.RiskTrend {
+	config := s.GetConfig()
+	return riskTrend(s.getSnapshots(userID, config.TrendWindowDays), config)
+}
+
+// LatestSnapshot returns the student's most recent snapshot within the trend
+// window, or nil when none has been recorded
+func (s *RiskService) LatestSnapshot(userID string) *models.RiskSnapshot {
+	snapshots := s.getSnapshots(userID, s.GetConfig().TrendWindowDays)
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return &snapshots[len(snapshots)-1]
+}
+
+// LatestAssessment rebuilds the assessment recorded by the student's latest
+// snapshot without recomputing it, or returns nil when none has been recorded.
+// Snapshots keep only factor values, so the factors carry no detail text.
+func (s *RiskService) LatestAssessment(userID string) *models.RiskAssessment {
+	snapshot := s.LatestSnapshot(userID)
+	if snapshot == nil {
+		return nil
+	}
+
+	factors := make(map[string]models.RiskFactor)
+	for key, value := range snapshot.Factors {
+		factors[key] = newRiskFactor(key, value, "")
+	}
+	weighted, _ := weighRiskFactors(factors, s.GetConfig())
+
+	assessment := &models.RiskAssessment{
+		Score:      snapshot.Score,
+		Level:      snapshot.Level,
+		Factors:    weighted,
+		ComputedAt: snapshot.CreatedAt,
+	}
+	assessment.Explanation = riskExplanation(assessment)
+	assessment.Recommendations = riskRecommendations(assessment)
+	return assessment
+}
+
+// getSnapshots returns the snapshots of the last n days, oldest first
+func (s *RiskService) getSnapshots(userID string, days int) []models.RiskSnapshot {
+	from := time.Now().AddDate(0, 0, -days).Format(dateLayout)
+
+	// Try Firestore first
+	if s.snapshotRepo.IsFirestoreAvailable() {
+		snapshots, err := s.snapshotRepo.FindByUserID(userID, from)
+		if err == nil {
+			return snapshots
+		}
+	}
+
+	// Fallback to mock data
+	mockRiskMu.Lock()
+	defer mockRiskMu.Unlock()
+	var snapshots []models.RiskSnapshot
+	for _, snapshot := range mockRiskSnapshots[userID] {
+		if snapshot.Date >= from {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots
+}
+
+func (s *RiskService) saveSnapshot(snapshot *models.RiskSnapshot) {
+	snapshot.CreatedAt = time.Now()
+
+	// Try Firestore first
+	if s.snapshotRepo.IsFirestoreAvailable() {
+		if err := s.snapshotRepo.Save(snapshot); err == nil {
+			return
+		}
+	}
+
+	// Fallback to mock data
+	mockRiskMu.Lock()
+	defer mockRiskMu.Unlock()
+	snapshots := mockRiskSnapshots[snapshot.UserID]
+	for i := range snapshots {
+		if snapshots[i].Date == snapshot.Date {
+			snapshots[i] = *snapshot
+			return
+		}
+	}
+	snapshots = append(snapshots, *snapshot)
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Date < snapshots[j].Date })
+	mockRiskSnapshots[snapshot.UserID] = snapshots
+}
+
+// computeFactors scores every risk factor and returns them together with a
+// snapshot holding the day's raw figures
+func (s *RiskService) computeFactors(user *models.User, config models.RiskConfig) (map[string]models.RiskFactor, *models.RiskSnapshot) {
+	settings := loadUserSettings(s.settingsRepo, user.ID)
+	loc := userLocation(settings)
+	now := time.Now()
+	today := startOfDay(now, loc)
+	windowStart := today.AddDate(0, 0, -(config.WindowDays - 1))
+
+	activities := findUserActivities(s.activityRepo, user.ID)
+	minutesByDay := dailyMinutes(activities, loc)
+
+	factors := make(map[string]models.RiskFactor)
+	snapshot := &models.RiskSnapshot{
+		UserID:  user.ID,
+		Date:    today.Format(dateLayout),
+		Factors: make(map[string]int),
+	}
+
+	// Consistency: share of days in the window with any study activity
+	activeDays := 0
+	for day := windowStart; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if minutesByDay[day.Format(dateLayout)] > 0 {
+			activeDays++
+		}
+	}
+	factors[RiskFactorConsistency] = newRiskFactor(RiskFactorConsistency, percent(activeDays, config.WindowDays),
+		fmt.Sprintf("Aktif %d dari %d hari terakhir", activeDays, config.WindowDays))
+
+	// Task completion: completed modules across enrolled courses
+	completed, total := 0, 0
+	for _, course := range s.courseService.GetUserCourses(user.ID) {
+		for _, module := range course.Modules {
+			total++
+			if module.Status == ModuleCompleted {
+				completed++
+			}
+		}
+	}
+	if total > 0 {
+		factors[RiskFactorTaskCompletion] = newRiskFactor(RiskFactorTaskCompletion, percent(completed, total),
+			fmt.Sprintf("%d dari %d modul selesai", completed, total))
+	} else {
+		factors[RiskFactorTaskCompletion] = newRiskFactor(RiskFactorTaskCompletion, riskNeutralValue, "Belum ada modul yang diikuti")
+	}
+
+	// Engagement: study minutes of the last 7 days against the weekly goal,
+	// blended with forum posts of the same days against riskForumWeeklyPosts
+	weekMinutes := 0
+	for day := today.AddDate(0, 0, -6); !day.After(today); day = day.AddDate(0, 0, 1) {
+		weekMinutes += minutesByDay[day.Format(dateLayout)]
+	}
+	forumPosts := countForumPostsSince(s.forumRepo, user.ID, today.AddDate(0, 0, -6))
+	forumValue := min(percent(forumPosts, riskForumWeeklyPosts), 100)
+	studyValue := riskNeutralValue
+	studyDetail := "Target harian belum diatur"
+	if weeklyGoal := settings.Learning.DailyGoal * 7; weeklyGoal > 0 {
+		studyValue = min(percent(weekMinutes, weeklyGoal), 100)
+		studyDetail = fmt.Sprintf("%d dari target %d menit", weekMinutes, weeklyGoal)
+	}
+	engagement := int(math.Round(float64(studyValue)*(1-riskForumShare) + float64(forumValue)*riskForumShare))
+	factors[RiskFactorEngagement] = newRiskFactor(RiskFactorEngagement, engagement,
+		fmt.Sprintf("%s dan %d kontribusi forum dalam 7 hari terakhir", studyDetail, forumPosts))
+
+	// Quiz performance: recent quiz scores, else scored quiz modules
+	var scores []int
+	quizWindowStart := now.AddDate(0, 0, -riskQuizWindowDays)
+	for _, activity := range activities {
+		if activity.Type == "quiz" && activity.Score != nil && activity.Date.After(quizWindowStart) {
+			scores = append(scores, *activity.Score)
+		}
+	}
+	if len(scores) == 0 {
+		for _, course := range s.courseService.GetUserCourses(user.ID) {
+			for _, module := range course.Modules {
+				if module.Type == "quiz" && module.Score != nil {
+					scores = append(scores, *module.Score)
+				}
+			}
+		}
+	}
+	if len(scores) > 0 {
+		sum := 0
+		for _, score := range scores {
+			sum += score
+		}
+		factors[RiskFactorQuiz] = newRiskFactor(RiskFactorQuiz, sum/len(scores),
+			fmt.Sprintf("Rata-rata skor %d dari %d quiz", sum/len(scores), len(scores)))
+	} else {
+		factors[RiskFactorQuiz] = newRiskFactor(RiskFactorQuiz, riskNeutralValue, "Belum ada skor quiz")
+	}
+
+	// Inactivity: days since the last activity (or since joining)
+	inactiveDays := daysInactive(user, activities, loc)
+	inactivityValue := 100
+	if config.MaxInactiveDays > 0 {
+		inactivityValue = max(100-percent(inactiveDays, config.MaxInactiveDays), 0)
+	}
+	factors[RiskFactorInactivity] = newRiskFactor(RiskFactorInactivity, inactivityValue,
+		fmt.Sprintf("Tidak aktif selama %d hari", inactiveDays))
+
+	snapshot.CompletedModules = completed
+	snapshot.WeeklyMinutes = weekMinutes
+	snapshot.ForumPosts = forumPosts
+	return factors, snapshot
+}
+
+func (s *RiskService) saveScore(userID string, score int) {
+	// Try Firestore first
+	if s.userRepo.IsFirestoreAvailable() {
+		if err := s.userRepo.UpdateFields(userID, map[string]interface{}{"riskScore": score}); err == nil {
+			return
+		}
+	}
+
+	// Fallback to mock data
+	users := GetMockUsers()
+	for i := range users {
+		if users[i].ID == userID {
+			users[i].RiskScore = score
+			return
+		}
+	}
+}
+
+func newRiskFactor(key string, value int, detail string) models.RiskFactor {
+	return models.RiskFactor{
+		Key:    key,
+		Name:   riskFactorNames[key],
+		Value:  value,
+		Status: riskFactorStatus(value),
+		Detail: detail,
+	}
+}
+
+// weighRiskFactors normalizes the configured weights over the given factors
+// and returns the factors in display order with their weight and
+// contribution, together with the risk score: the weighted shortfall of the
+// factor values from 100
+func weighRiskFactors(factors map[string]models.RiskFactor, config models.RiskConfig) ([]models.RiskFactor, int) {
+	totalWeight := 0.0
+	for key := range factors {
+		totalWeight += config.Weights[key]
+	}
+
+	weighted := []models.RiskFactor{}
+	score := 0.0
+	for _, key := range riskFactorOrder() {
+		factor, ok := factors[key]
+		if !ok {
+			continue
+		}
+		if totalWeight > 0 {
+			factor.Weight = config.Weights[key] / totalWeight
+		}
+		factor.Contribution = math.Round(factor.Weight*float64(100-factor.Value)*10) / 10
+		score += factor.Weight * float64(100-factor.Value)
+		weighted = append(weighted, factor)
+	}
+	return weighted, int(math.Round(score))
+}
+
+func riskFactorOrder() []string {
+	return []string{
+		RiskFactorConsistency,
+		RiskFactorTaskCompletion,
+		RiskFactorEngagement,
+		RiskFactorQuiz,
+		RiskFactorInactivity,
+	}
+}
+
+func riskFactorStatus(value int) string {
+	switch {
+	case value >= 85:
+		return "excellent"
+	case value >= 70:
+		return "good"
+	case value >= 50:
+		return "moderate"
+	default:
+		return "poor"
+	}
+}
+
+func riskLevel(score int, config models.RiskConfig) string {
+	switch {
+	case score >= config.HighThreshold:
+		return "high"
+	case score >= config.MediumThreshold:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// riskExplanation names the factors that added the most risk points and the strongest factor
+func riskExplanation(assessment *models.RiskAssessment) string {
+	ranked := append([]models.RiskFactor(nil), assessment.Factors...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Contribution > ranked[j].Contribution })
+
+	explanation := fmt.Sprintf("Skor risiko Anda %s (%d dari 100).", riskLevelNames[assessment.Level], assessment.Score)
+
+	var drivers []string
+	for _, factor := range ranked {
+		if len(drivers) == 3 || factor.Contribution < 1 {
+			break
+		}
+		if factor.Detail == "" {
+			drivers = append(drivers, fmt.Sprintf("%s (+%.1f poin)", factor.Name, factor.Contribution))
+			continue
+		}
+		drivers = append(drivers, fmt.Sprintf("%s (%s, +%.1f poin)", factor.Name, strings.ToLower(factor.Detail), factor.Contribution))
+	}
+	if len(drivers) > 0 {
+		explanation += " Faktor yang paling menaikkan risiko: " + strings.Join(drivers, "; ") + "."
+	}
+
+	if len(ranked) > 0 {
+		best := ranked[len(ranked)-1]
+		if best.Value >= riskWeakFactorValue {
+			explanation += fmt.Sprintf(" Faktor terkuat Anda adalah %s dengan nilai %d.", best.Name, best.Value)
+		}
+	}
+	return explanation
+}
+
+func riskRecommendations(assessment *models.RiskAssessment) []string {
+	ranked := append([]models.RiskFactor(nil), assessment.Factors...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Contribution > ranked[j].Contribution })
+
+	recommendations := []string{}
+	for _, factor := range ranked {
+		if factor.Value < riskWeakFactorValue {
+			recommendations = append(recommendations, riskFactorRecommendations[factor.Key])
+		}
+	}
+	if len(recommendations) == 0 {
+		recommendations = append(recommendations, "Pertahankan jadwal belajar rutin Anda")
+	}
+	return recommendations
+}
+
+// riskTrend fits a least-squares line through the scores of the trend window.
+// A score rising faster than the threshold means the student is declining.
+func riskTrend(snapshots []models.RiskSnapshot, config models.RiskConfig) models.RiskTrend {
+	trend := models.RiskTrend{Direction: "stable", WindowDays: config.TrendWindowDays}
+
+	from := time.Now().AddDate(0, 0, -(config.TrendWindowDays - 1)).Format(dateLayout)
+	var xs, ys []float64
+	for _, snapshot := range snapshots {
+		day, err := time.Parse(dateLayout, snapshot.Date)
+		if err != nil || snapshot.Date < from {
+			continue
+		}
+		xs = append(xs, day.Sub(time.Unix(0, 0)).Hours()/24)
+		ys = append(ys, float64(snapshot.Score))
+	}
+	trend.Samples = len(ys)
+	if len(ys) == 0 {
+		return trend
+	}
+
+	n := float64(len(ys))
+	var sumX, sumY float64
+	for i := range ys {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+	trend.MovingAverage = math.Round(meanY*10) / 10
+	if len(ys) < 2 {
+		return trend
+	}
+
+	var num, den float64
+	for i := range ys {
+		num += (xs[i] - meanX) * (ys[i] - meanY)
+		den += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+	if den == 0 {
+		return trend
+	}
+	trend.Slope = math.Round(num/den*100) / 100
+
+	switch {
+	case trend.Slope > config.TrendThreshold:
+		trend.Direction = "declining"
+	case trend.Slope < -config.TrendThreshold:
+		trend.Direction = "improving"
+	}
+	return trend
+}
+
+// withRiskConfigDefaults fills settings missing from configs stored by older versions
+func withRiskConfigDefaults(config models.RiskConfig) models.RiskConfig {
+	defaults := defaultRiskConfig()
+	if config.TrendWindowDays == 0 {
+		config.TrendWindowDays = defaults.TrendWindowDays
+	}
+	if config.TrendThreshold == 0 {
+		config.TrendThreshold = defaults.TrendThreshold
+	}
+	return config
+}
+
+func validateRiskConfig(config models.RiskConfig) error {
+	totalWeight := 0.0
+	for key, weight := range config.Weights {
+		if _, ok := riskFactorNames[key]; !ok {
+			return fmt.Errorf("unknown risk factor %q", key)
+		}
+		if weight < 0 {
+			return fmt.Errorf("weight of %q must not be negative", key)
+		}
+		totalWeight += weight
+	}
+	if totalWeight <= 0 {
+		return fmt.Errorf("at least one factor needs a positive weight")
+	}
+	if config.MediumThreshold <= 0 || config.HighThreshold <= config.MediumThreshold || config.HighThreshold > 100 {
+		return fmt.Errorf("thresholds must satisfy 0 < mediumThreshold < highThreshold <= 100")
+	}
+	if config.WindowDays < 1 || config.MaxInactiveDays < 1 {
+		return fmt.Errorf("windowDays and maxInactiveDays must be at least 1")
+	}
+	if config.TrendWindowDays < 2 || config.TrendThreshold < 0 {
+		return fmt.Errorf("trendWindowDays must be at least 2 and trendThreshold not negative")
+	}
+	return nil
+}
+
+func percent(part, whole int) int {
+	if whole <= 0 {
+		return 0
+	}
+	return part * 100 / whole
+}
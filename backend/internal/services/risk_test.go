@@ -0,0 +1,343 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+func riskFactors(values map[string]int) map[string]models.RiskFactor {
+	factors := make(map[string]models.RiskFactor)
+	for key, value := range values {
+		factors[key] = newRiskFactor(key, value, "")
+	}
+	return factors
+}
+
+func TestWeighRiskFactors(t *testing.T) {
+	config := defaultRiskConfig()
+	allFactors := func(value int) map[string]int {
+		values := make(map[string]int)
+		for _, key := range riskFactorOrder() {
+			values[key] = value
+		}
+		return values
+	}
+
+	tests := []struct {
+		name              string
+		values            map[string]int
+		weights           map[string]float64
+		wantScore         int
+		wantContributions map[string]float64
+	}{
+		{
+			name:      "all factors perfect",
+			values:    allFactors(100),
+			wantScore: 0,
+		},
+		{
+			name:      "all factors zero",
+			values:    allFactors(0),
+			wantScore: 100,
+		},
+		{
+			name:      "all factors neutral",
+			values:    allFactors(riskNeutralValue),
+			wantScore: 50,
+		},
+		{
+			name: "mixed factors",
+			values: map[string]int{
+				RiskFactorConsistency:    40,
+				RiskFactorTaskCompletion: 80,
+				RiskFactorEngagement:     60,
+				RiskFactorQuiz:           90,
+				RiskFactorInactivity:     100,
+			},
+			// 0.25*60 + 0.2*20 + 0.2*40 + 0.15*10 + 0.2*0 = 28.5
+			wantScore: 29,
+			wantContributions: map[string]float64{
+				RiskFactorConsistency:    15,
+				RiskFactorTaskCompletion: 4,
+				RiskFactorEngagement:     8,
+				RiskFactorQuiz:           1.5,
+				RiskFactorInactivity:     0,
+			},
+		},
+		{
+			name:   "weights are normalized over the present factors",
+			values: map[string]int{RiskFactorConsistency: 0, RiskFactorQuiz: 100},
+			// 0.25/0.4 of 100 points
+			wantScore: 63,
+			wantContributions: map[string]float64{
+				RiskFactorConsistency: 62.5,
+				RiskFactorQuiz:        0,
+			},
+		},
+		{
+			name:    "zero weights",
+			values:  allFactors(0),
+			weights: map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := config
+			if tt.weights != nil {
+				config.Weights = tt.weights
+			}
+
+			factors, score := weighRiskFactors(riskFactors(tt.values), config)
+			if score != tt.wantScore {
+				t.Errorf("score = %d, want %d", score, tt.wantScore)
+			}
+			if len(factors) != len(tt.values) {
+				t.Fatalf("got %d factors, want %d", len(factors), len(tt.values))
+			}
+
+			order := make(map[string]int)
+			for i, key := range riskFactorOrder() {
+				order[key] = i
+			}
+			last := -1
+			for _, factor := range factors {
+				index := order[factor.Key]
+				if index < last {
+					t.Errorf("factor %s is out of display order", factor.Key)
+				}
+				last = index
+				if want, ok := tt.wantContributions[factor.Key]; ok && factor.Contribution != want {
+					t.Errorf("contribution of %s = %v, want %v", factor.Key, factor.Contribution, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRiskLevel(t *testing.T) {
+	config := defaultRiskConfig()
+	tests := []struct {
+		score int
+		want  string
+	}{
+		{0, "low"},
+		{config.MediumThreshold - 1, "low"},
+		{config.MediumThreshold, "medium"},
+		{config.HighThreshold - 1, "medium"},
+		{config.HighThreshold, "high"},
+		{100, "high"},
+	}
+	for _, tt := range tests {
+		if got := riskLevel(tt.score, config); got != tt.want {
+			t.Errorf("riskLevel(%d) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestRiskFactorStatus(t *testing.T) {
+	tests := []struct {
+		value int
+		want  string
+	}{
+		{100, "excellent"},
+		{85, "excellent"},
+		{84, "good"},
+		{70, "good"},
+		{69, "moderate"},
+		{50, "moderate"},
+		{49, "poor"},
+		{0, "poor"},
+	}
+	for _, tt := range tests {
+		if got := riskFactorStatus(tt.value); got != tt.want {
+			t.Errorf("riskFactorStatus(%d) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		part, whole, want int
+	}{
+		{0, 10, 0},
+		{5, 10, 50},
+		{1, 3, 33},
+		{10, 10, 100},
+		{15, 10, 150},
+		{3, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := percent(tt.part, tt.whole); got != tt.want {
+			t.Errorf("percent(%d, %d) = %d, want %d", tt.part, tt.whole, got, tt.want)
+		}
+	}
+}
+
+func TestRiskTrend(t *testing.T) {
+	config := defaultRiskConfig()
+	// snapshots builds one snapshot per score, the last one today
+	snapshots := func(scores ...int) []models.RiskSnapshot {
+		var result []models.RiskSnapshot
+		for i, score := range scores {
+			date := time.Now().AddDate(0, 0, i-len(scores)+1).Format(dateLayout)
+			result = append(result, models.RiskSnapshot{Date: date, Score: score})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name          string
+		snapshots     []models.RiskSnapshot
+		wantDirection string
+		wantSlope     float64
+		wantAverage   float64
+		wantSamples   int
+	}{
+		{
+			name:          "no snapshots",
+			wantDirection: "stable",
+		},
+		{
+			name:          "single snapshot",
+			snapshots:     snapshots(40),
+			wantDirection: "stable",
+			wantAverage:   40,
+			wantSamples:   1,
+		},
+		{
+			name:          "flat",
+			snapshots:     snapshots(30, 30, 30, 30),
+			wantDirection: "stable",
+			wantAverage:   30,
+			wantSamples:   4,
+		},
+		{
+			name:          "rising risk is declining",
+			snapshots:     snapshots(20, 30, 40, 50),
+			wantDirection: "declining",
+			wantSlope:     10,
+			wantAverage:   35,
+			wantSamples:   4,
+		},
+		{
+			name:          "falling risk is improving",
+			snapshots:     snapshots(60, 58, 56),
+			wantDirection: "improving",
+			wantSlope:     -2,
+			wantAverage:   58,
+			wantSamples:   3,
+		},
+		{
+			name:          "slope within the threshold",
+			snapshots:     snapshots(40, 40, 41),
+			wantDirection: "stable",
+			wantSlope:     0.5,
+			wantAverage:   40.3,
+			wantSamples:   3,
+		},
+		{
+			name:          "snapshots before the window are ignored",
+			snapshots:     snapshots(90, 10, 10, 10, 10, 10, 10, 10),
+			wantDirection: "stable",
+			wantAverage:   10,
+			wantSamples:   config.TrendWindowDays,
+		},
+		{
+			name:          "unparsable dates are ignored",
+			snapshots:     append(snapshots(10, 10), models.RiskSnapshot{Date: "yesterday", Score: 90}),
+			wantDirection: "stable",
+			wantAverage:   10,
+			wantSamples:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trend := riskTrend(tt.snapshots, config)
+			if trend.Direction != tt.wantDirection {
+				t.Errorf("direction = %q, want %q", trend.Direction, tt.wantDirection)
+			}
+			if trend.Slope != tt.wantSlope {
+				t.Errorf("slope = %v, want %v", trend.Slope, tt.wantSlope)
+			}
+			if trend.MovingAverage != tt.wantAverage {
+				t.Errorf("moving average = %v, want %v", trend.MovingAverage, tt.wantAverage)
+			}
+			if trend.Samples != tt.wantSamples {
+				t.Errorf("samples = %d, want %d", trend.Samples, tt.wantSamples)
+			}
+			if trend.WindowDays != config.TrendWindowDays {
+				t.Errorf("window days = %d, want %d", trend.WindowDays, config.TrendWindowDays)
+			}
+		})
+	}
+}
+
+func TestValidateRiskConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*models.RiskConfig)
+		wantErr bool
+	}{
+		{"default", func(*models.RiskConfig) {}, false},
+		{"unknown factor", func(c *models.RiskConfig) { c.Weights["mood"] = 0.1 }, true},
+		{"negative weight", func(c *models.RiskConfig) { c.Weights[RiskFactorQuiz] = -0.1 }, true},
+		{"no positive weight", func(c *models.RiskConfig) { c.Weights = map[string]float64{RiskFactorQuiz: 0} }, true},
+		{"single factor", func(c *models.RiskConfig) { c.Weights = map[string]float64{RiskFactorQuiz: 1} }, false},
+		{"zero medium threshold", func(c *models.RiskConfig) { c.MediumThreshold = 0 }, true},
+		{"high not above medium", func(c *models.RiskConfig) { c.HighThreshold = c.MediumThreshold }, true},
+		{"high above 100", func(c *models.RiskConfig) { c.HighThreshold = 101 }, true},
+		{"zero window", func(c *models.RiskConfig) { c.WindowDays = 0 }, true},
+		{"zero max inactive days", func(c *models.RiskConfig) { c.MaxInactiveDays = 0 }, true},
+		{"one day trend window", func(c *models.RiskConfig) { c.TrendWindowDays = 1 }, true},
+		{"negative trend threshold", func(c *models.RiskConfig) { c.TrendThreshold = -1 }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultRiskConfig()
+			tt.modify(&config)
+			if err := validateRiskConfig(config); (err != nil) != tt.wantErr {
+				t.Errorf("validateRiskConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRiskRecommendations(t *testing.T) {
+	config := defaultRiskConfig()
+	tests := []struct {
+		name   string
+		values map[string]int
+		want   []string
+	}{
+		{
+			name:   "no weak factors",
+			values: map[string]int{RiskFactorConsistency: 90, RiskFactorQuiz: riskWeakFactorValue},
+			want:   []string{"Pertahankan jadwal belajar rutin Anda"},
+		},
+		{
+			name:   "weak factors, largest contribution first",
+			values: map[string]int{RiskFactorConsistency: 60, RiskFactorQuiz: 10, RiskFactorInactivity: 100},
+			want: []string{
+				riskFactorRecommendations[RiskFactorQuiz],
+				riskFactorRecommendations[RiskFactorConsistency],
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factors, score := weighRiskFactors(riskFactors(tt.values), config)
+			assessment := &models.RiskAssessment{Score: score, Level: riskLevel(score, config), Factors: factors}
+
+			got := riskRecommendations(assessment)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("riskRecommendations() = %q, want %q", got, tt.want)
+			}
+			if explanation := riskExplanation(assessment); !strings.Contains(explanation, riskLevelNames[assessment.Level]) {
+				t.Errorf("riskExplanation() = %q, want it to name the level", explanation)
+			}
+		})
+	}
+}
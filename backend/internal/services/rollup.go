@@ -0,0 +1,116 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+// Activity rollups for development (used as fallback when Firebase is not configured)
+var (
+	mockActivityRollups   = make(map[string]map[string]models.ActivityRollup)
+	mockActivityRollupsMu sync.Mutex
+)
+
+type RollupService struct {
+	rollupRepo   *repository.ActivityRollupRepository
+	activityRepo *repository.ActivityRepository
+	settingsRepo *repository.SettingsRepository
+}
+
+func NewRollupService() *RollupService {
+	return &RollupService{
+		rollupRepo:   repository.NewActivityRollupRepository(),
+		activityRepo: repository.NewActivityRepository(),
+		settingsRepo: repository.NewSettingsRepository(),
+	}
+}
+
+// Refresh recomputes the user's rollups for the last n local days, today included
+func (s *RollupService) Refresh(userID string, days int) ([]models.ActivityRollup, error) {
+	settings := loadUserSettings(s.settingsRepo, userID)
+	loc := userLocation(settings)
+	today := startOfDay(time.Now(), loc)
+	start := today.AddDate(0, 0, -(days - 1))
+
+	rollups := make(map[string]*models.ActivityRollup)
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		rollups[date] = &models.ActivityRollup{
+			UserID:          userID,
+			Date:            date,
+			MinutesByType:   make(map[string]int),
+			MinutesByCourse: make(map[string]int),
+		}
+	}
+
+	for _, activity := range findUserActivitiesBetween(s.activityRepo, userID, start, today.AddDate(0, 0, 1).Add(-time.Nanosecond)) {
+		rollup, ok := rollups[activity.Date.In(loc).Format(dateLayout)]
+		if !ok {
+			continue
+		}
+		rollup.Minutes += activity.Duration
+		rollup.Activities++
+		rollup.MinutesByType[activity.Type] += activity.Duration
+		if activity.CourseID != "" {
+			rollup.MinutesByCourse[activity.CourseID] += activity.Duration
+		}
+		if activity.Type == "quiz" && activity.Score != nil {
+			rollup.QuizCount++
+			rollup.QuizScoreSum += *activity.Score
+		}
+	}
+
+	result := make([]models.ActivityRollup, 0, len(rollups))
+	for _, rollup := range rollups {
+		rollup.UpdatedAt = time.Now()
+		if err := s.save(rollup); err != nil {
+			return nil, err
+		}
+		result = append(result, *rollup)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date < result[j].Date })
+	return result, nil
+}
+
+// GetRollups returns the stored rollups between two dates (YYYY-MM-DD, inclusive)
+func (s *RollupService) GetRollups(userID, fromDate, toDate string) []models.ActivityRollup {
+	// Try Firestore first
+	if s.rollupRepo.IsFirestoreAvailable() {
+		rollups, err := s.rollupRepo.FindByUserID(userID, fromDate, toDate)
+		if err == nil {
+			return rollups
+		}
+	}
+
+	// Fallback to mock data
+	mockActivityRollupsMu.Lock()
+	defer mockActivityRollupsMu.Unlock()
+	rollups := []models.ActivityRollup{}
+	for date, rollup := range mockActivityRollups[userID] {
+		if date >= fromDate && date <= toDate {
+			rollups = append(rollups, rollup)
+		}
+	}
+	sort.Slice(rollups, func(i, j int) bool { return rollups[i].Date < rollups[j].Date })
+	return rollups
+}
+
+func (s *RollupService) save(rollup *models.ActivityRollup) error {
+	// Try Firestore first
+	if s.rollupRepo.IsFirestoreAvailable() {
+		return s.rollupRepo.Save(rollup)
+	}
+
+	// Fallback to mock data
+	mockActivityRollupsMu.Lock()
+	defer mockActivityRollupsMu.Unlock()
+	if mockActivityRollups[rollup.UserID] == nil {
+		mockActivityRollups[rollup.UserID] = make(map[string]models.ActivityRollup)
+	}
+	mockActivityRollups[rollup.UserID][rollup.Date] = *rollup
+	return nil
+}
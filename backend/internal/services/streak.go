@@ -0,0 +1,306 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+	"mentorsphere-api/internal/repository"
+)
+
+const (
+	// Each run of this many consecutive goal-met days earns a freeze token
+	freezeEarnInterval = 7
+	maxFreezeTokens    = 3
+	// How far back a missed day can still be covered by a freeze
+	freezeWindowDays = 7
+)
+
+// Streak state for development (used as fallback when Firebase is not configured)
+var (
+	streakStates   = make(map[string]models.StreakState)
+	streakStatesMu sync.Mutex
+)
+
+type StreakService struct {
+	activityRepo *repository.ActivityRepository
+	settingsRepo *repository.SettingsRepository
+	streakRepo   *repository.StreakRepository
+}
+
+func NewStreakService() *StreakService {
+	return &StreakService{
+		activityRepo: repository.NewActivityRepository(),
+		settingsRepo: repository.NewSettingsRepository(),
+		streakRepo:   repository.NewStreakRepository(),
+	}
+}
+
+// streakInput is everything needed to derive streak figures for a user
+type streakInput struct {
+	loc       *time.Location
+	dailyGoal int
+	minutes   map[string]int
+	frozen    map[string]bool
+	// Number of freeze tokens spent on each local date
+	spent map[string]int
+	today time.Time
+}
+
+func (s *StreakService) load(userID string) *streakInput {
+	settings := loadUserSettings(s.settingsRepo, userID)
+	loc := userLocation(settings)
+
+	state := s.getState(userID)
+	frozen := make(map[string]bool)
+	spent := make(map[string]int)
+	for _, date := range state.FrozenDates {
+		frozen[date] = true
+		spent[freezeSpentOn(state, date, loc)]++
+	}
+
+	return &streakInput{
+		loc:       loc,
+		dailyGoal: settings.Learning.DailyGoal,
+		minutes:   dailyMinutes(findUserActivities(s.activityRepo, userID), loc),
+		frozen:    frozen,
+		spent:     spent,
+		today:     startOfDay(time.Now(), loc),
+	}
+}
+
+func (s *StreakService) getState(userID string) models.StreakState {
+	// Try Firestore first
+	if s.streakRepo.IsFirestoreAvailable() {
+		state, err := s.streakRepo.FindByUserID(userID)
+		if err == nil {
+			return *state
+		}
+	}
+
+	// Fallback to mock data
+	streakStatesMu.Lock()
+	defer streakStatesMu.Unlock()
+	if state, ok := streakStates[userID]; ok {
+		return state
+	}
+	return models.StreakState{UserID: userID}
+}
+
+func (s *StreakService) saveState(state models.StreakState) error {
+	state.UpdatedAt = time.Now()
+
+	// Try Firestore first
+	if s.streakRepo.IsFirestoreAvailable() {
+		if err := s.streakRepo.Save(&state); err == nil {
+			return nil
+		}
+	}
+
+	// Fallback to mock data
+	streakStatesMu.Lock()
+	streakStates[state.UserID] = state
+	streakStatesMu.Unlock()
+	return nil
+}
+
+// GetSummary returns the user's current streak and today's goal attainment
+func (s *StreakService) GetSummary(userID string) *models.StreakSummary {
+	return s.summarize(s.load(userID))
+}
+
+func (s *StreakService) summarize(in *streakInput) *models.StreakSummary {
+	todayKey := in.today.Format(dateLayout)
+	todayMinutes := in.minutes[todayKey]
+
+	summary := &models.StreakSummary{
+		CurrentStreak: currentStreak(in),
+		LongestStreak: longestStreak(in),
+		FreezeTokens:  availableFreezeTokens(in),
+		DailyGoal:     in.dailyGoal,
+		TodayMinutes:  todayMinutes,
+		GoalMet:       in.dailyGoal > 0 && todayMinutes >= in.dailyGoal,
+		Timezone:      in.loc.String(),
+	}
+	if in.dailyGoal > 0 {
+		summary.GoalProgress = min(todayMinutes*100/in.dailyGoal, 100)
+	}
+
+	var lastActive string
+	for date := range in.minutes {
+		if date > lastActive && date <= todayKey {
+			lastActive = date
+		}
+	}
+	summary.LastActiveDate = lastActive
+
+	return summary
+}
+
+// GetHistory returns per-day activity and goal attainment for the last n days
+func (s *StreakService) GetHistory(userID string, days int) *models.StreakHistory {
+	in := s.load(userID)
+
+	history := &models.StreakHistory{
+		Summary: *s.summarize(in),
+		Days:    make([]models.StreakDay, 0, days),
+	}
+	for i := days - 1; i >= 0; i-- {
+		date := in.today.AddDate(0, 0, -i).Format(dateLayout)
+		minutes := in.minutes[date]
+		history.Days = append(history.Days, models.StreakDay{
+			Date:    date,
+			Minutes: minutes,
+			Active:  minutes > 0,
+			GoalMet: in.dailyGoal > 0 && minutes >= in.dailyGoal,
+			Frozen:  in.frozen[date],
+		})
+	}
+
+	return history
+}
+
+// UseFreeze spends a freeze token to keep the streak alive over a missed day
+func (s *StreakService) UseFreeze(userID, date string) (*models.StreakSummary, error) {
+	in := s.load(userID)
+
+	day, err := time.ParseInLocation(dateLayout, date, in.loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date, expected YYYY-MM-DD")
+	}
+	if !day.Before(in.today) {
+		return nil, fmt.Errorf("only past days can be frozen")
+	}
+	if day.Before(in.today.AddDate(0, 0, -freezeWindowDays)) {
+		return nil, fmt.Errorf("days older than %d days cannot be frozen", freezeWindowDays)
+	}
+	if in.minutes[date] > 0 {
+		return nil, fmt.Errorf("day already has activity")
+	}
+	if in.frozen[date] {
+		return nil, fmt.Errorf("day is already frozen")
+	}
+	if availableFreezeTokens(in) == 0 {
+		return nil, fmt.Errorf("no freeze tokens available")
+	}
+
+	state := s.getState(userID)
+	state.FrozenDates = append(state.FrozenDates, date)
+	sort.Strings(state.FrozenDates)
+	if state.FrozenOn == nil {
+		state.FrozenOn = make(map[string]string)
+	}
+	state.FrozenOn[date] = in.today.Format(dateLayout)
+	if err := s.saveState(state); err != nil {
+		return nil, err
+	}
+
+	in.frozen[date] = true
+	in.spent[in.today.Format(dateLayout)]++
+	return s.summarize(in), nil
+}
+
+// currentStreak counts active days ending today, or yesterday if the user
+// has not studied yet today. Frozen days bridge a gap without adding to it.
+func currentStreak(in *streakInput) int {
+	day := in.today
+	if !isStreakDay(in, day) {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for isStreakDay(in, day) {
+		if in.minutes[day.Format(dateLayout)] > 0 {
+			streak++
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+func longestStreak(in *streakInput) int {
+	first, ok := firstActiveDay(in)
+	if !ok {
+		return 0
+	}
+
+	longest, run := 0, 0
+	for day := first; !day.After(in.today); day = day.AddDate(0, 0, 1) {
+		if !isStreakDay(in, day) {
+			run = 0
+			continue
+		}
+		if in.minutes[day.Format(dateLayout)] > 0 {
+			run++
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// availableFreezeTokens derives the token balance from goal-met runs so it
+// never depends on the streak it protects. Tokens accrue day by day net of the
+// freezes spent so far and are capped while they accrue, so a run completed
+// while holding the maximum earns nothing.
+func availableFreezeTokens(in *streakInput) int {
+	first, ok := firstActiveDay(in)
+	if !ok || in.dailyGoal <= 0 {
+		return 0
+	}
+
+	balance, run := 0, 0
+	for day := first; !day.After(in.today); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		if in.minutes[date] >= in.dailyGoal {
+			run++
+		} else {
+			run = 0
+		}
+		if run == freezeEarnInterval {
+			balance = min(balance+1, maxFreezeTokens)
+			run = 0
+		}
+		balance = max(balance-in.spent[date], 0)
+	}
+
+	return balance
+}
+
+// freezeSpentOn returns the local date a frozen date's token was spent on.
+// Freezes recorded before spend dates were kept count as spent the day after
+// the frozen date, the earliest day they could have been.
+func freezeSpentOn(state models.StreakState, date string, loc *time.Location) string {
+	if spentOn, ok := state.FrozenOn[date]; ok {
+		return spentOn
+	}
+	day, err := time.ParseInLocation(dateLayout, date, loc)
+	if err != nil {
+		return date
+	}
+	return day.AddDate(0, 0, 1).Format(dateLayout)
+}
+
+func isStreakDay(in *streakInput, day time.Time) bool {
+	date := day.Format(dateLayout)
+	return in.minutes[date] > 0 || in.frozen[date]
+}
+
+func firstActiveDay(in *streakInput) (time.Time, bool) {
+	var first string
+	for date := range in.minutes {
+		if first == "" || date < first {
+			first = date
+		}
+	}
+	if first == "" {
+		return time.Time{}, false
+	}
+
+	day, err := time.ParseInLocation(dateLayout, first, in.loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return day, true
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+// streakToday is the "today" of the streak tests, a Monday
+var streakToday = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// newStreakInput builds a streak input from minutes studied and frozen days,
+// given as days before streakToday, and freeze tokens spent per day before it
+func newStreakInput(dailyGoal int, minutes map[int]int, frozen []int, spent map[int]int) *streakInput {
+	in := &streakInput{
+		loc:       time.UTC,
+		dailyGoal: dailyGoal,
+		minutes:   make(map[string]int),
+		frozen:    make(map[string]bool),
+		spent:     make(map[string]int),
+		today:     streakToday,
+	}
+	date := func(daysAgo int) string {
+		return streakToday.AddDate(0, 0, -daysAgo).Format(dateLayout)
+	}
+	for daysAgo, m := range minutes {
+		in.minutes[date(daysAgo)] = m
+	}
+	for _, daysAgo := range frozen {
+		in.frozen[date(daysAgo)] = true
+	}
+	for daysAgo, n := range spent {
+		in.spent[date(daysAgo)] = n
+	}
+	return in
+}
+
+// studied returns minutes studied on each day from first to last days ago
+func studied(first, last, minutes int) map[int]int {
+	days := make(map[int]int)
+	for daysAgo := first; daysAgo >= last; daysAgo-- {
+		days[daysAgo] = minutes
+	}
+	return days
+}
+
+func merge(days ...map[int]int) map[int]int {
+	merged := make(map[int]int)
+	for _, d := range days {
+		for daysAgo, minutes := range d {
+			merged[daysAgo] = minutes
+		}
+	}
+	return merged
+}
+
+func TestCurrentStreak(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes map[int]int
+		frozen  []int
+		want    int
+	}{
+		{"no activity", nil, nil, 0},
+		{"today only", studied(0, 0, 30), nil, 1},
+		{"ending today", studied(4, 0, 30), nil, 5},
+		{"ending yesterday, nothing yet today", studied(3, 1, 30), nil, 3},
+		{"gap yesterday", merge(studied(0, 0, 30), studied(5, 2, 30)), nil, 1},
+		{"last activity two days ago", studied(5, 2, 30), nil, 0},
+		{"frozen day bridges the gap", merge(studied(0, 0, 30), studied(3, 2, 30)), []int{1}, 3},
+		{"frozen day does not count", studied(3, 2, 30), []int{1}, 2},
+		{"frozen days alone", nil, []int{1, 2}, 0},
+		{"any minutes count, not only the goal", studied(1, 0, 1), nil, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := newStreakInput(30, tt.minutes, tt.frozen, nil)
+			if got := currentStreak(in); got != tt.want {
+				t.Errorf("currentStreak() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLongestStreak(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes map[int]int
+		frozen  []int
+		want    int
+	}{
+		{"no activity", nil, nil, 0},
+		{"single day", studied(10, 10, 30), nil, 1},
+		{"earlier run is longer", merge(studied(20, 16, 30), studied(1, 0, 30)), nil, 5},
+		{"current run is longer", merge(studied(20, 19, 30), studied(6, 0, 30)), nil, 7},
+		{"frozen day joins two runs", merge(studied(8, 6, 30), studied(4, 3, 30)), []int{5}, 5},
+		{"frozen day before the first activity is ignored", studied(3, 2, 30), []int{4}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := newStreakInput(30, tt.minutes, tt.frozen, nil)
+			if got := longestStreak(in); got != tt.want {
+				t.Errorf("longestStreak() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailableFreezeTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		dailyGoal int
+		minutes   map[int]int
+		spent     map[int]int
+		want      int
+	}{
+		{"no activity", 30, nil, nil, 0},
+		{"no daily goal", 0, studied(13, 0, 30), nil, 0},
+		{"six goal-met days", 30, studied(5, 0, 30), nil, 0},
+		{"seven goal-met days", 30, studied(6, 0, 30), nil, 1},
+		{"fourteen goal-met days", 30, studied(13, 0, 30), nil, 2},
+		{"active below the goal earns nothing", 30, studied(13, 0, 29), nil, 0},
+		{"a missed goal restarts the run", 30, merge(studied(12, 7, 30), studied(5, 0, 30)), nil, 0},
+		{"capped", 30, studied(34, 0, 30), nil, maxFreezeTokens},
+		{"spent token", 30, studied(13, 0, 30), map[int]int{3: 1}, 1},
+		{"spending never goes below zero", 30, studied(6, 0, 30), map[int]int{6: 2}, 1},
+		// The fourth run ends on the day a token is spent: the cap was
+		// reached before it, so it earns nothing
+		{"run completed at the cap earns nothing", 30, studied(27, 0, 30), map[int]int{0: 1}, maxFreezeTokens - 1},
+		// A token spent mid-run frees room for the run's token
+		{"run completed after a spend earns", 30, studied(27, 0, 30), map[int]int{3: 1}, maxFreezeTokens},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := newStreakInput(tt.dailyGoal, tt.minutes, nil, tt.spent)
+			if got := availableFreezeTokens(in); got != tt.want {
+				t.Errorf("availableFreezeTokens() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFreezeSpentOn(t *testing.T) {
+	state := models.StreakState{FrozenOn: map[string]string{"2026-10-15": "2026-10-18"}}
+
+	tests := []struct {
+		name string
+		date string
+		want string
+	}{
+		{"recorded spend date", "2026-10-15", "2026-10-18"},
+		{"legacy freeze counts the day after", "2026-10-16", "2026-10-17"},
+		{"legacy freeze at the end of a month", "2026-10-31", "2026-11-01"},
+		{"unparsable date", "someday", "someday"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := freezeSpentOn(state, tt.date, time.UTC); got != tt.want {
+				t.Errorf("freezeSpentOn(%q) = %q, want %q", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDailyMinutesUsesLocalDays(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	activities := []models.ActivityLog{
+		{Duration: 20, Date: time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)},
+		// 01:00 on 20 October in Jakarta
+		{Duration: 30, Date: time.Date(2026, time.October, 19, 18, 0, 0, 0, time.UTC)},
+		{Duration: 15, Date: time.Date(2026, time.October, 19, 16, 59, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want map[string]int
+	}{
+		{"UTC", time.UTC, map[string]int{"2026-10-19": 65}},
+		{"Jakarta", jakarta, map[string]int{"2026-10-19": 35, "2026-10-20": 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dailyMinutes(activities, tt.loc)
+			if len(got) != len(tt.want) {
+				t.Fatalf("dailyMinutes() = %v, want %v", got, tt.want)
+			}
+			for date, minutes := range tt.want {
+				if got[date] != minutes {
+					t.Errorf("minutes on %s = %d, want %d", date, got[date], minutes)
+				}
+			}
+		})
+	}
+}
+
+func TestStreakSummary(t *testing.T) {
+	tests := []struct {
+		name         string
+		dailyGoal    int
+		minutes      map[int]int
+		wantGoalMet  bool
+		wantProgress int
+		wantLast     string
+	}{
+		{"goal met today", 30, studied(0, 0, 45), true, 100, "2026-10-19"},
+		{"halfway today", 30, studied(0, 0, 15), false, 50, "2026-10-19"},
+		{"not studied today", 30, studied(3, 1, 30), false, 0, "2026-10-18"},
+		{"no daily goal", 0, studied(0, 0, 15), false, 0, "2026-10-19"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := newStreakInput(tt.dailyGoal, tt.minutes, nil, nil)
+			// Activity logged ahead of the user's today is not the last active day
+			in.minutes["2026-10-20"] = 10
+
+			summary := (&StreakService{}).summarize(in)
+			if summary.GoalMet != tt.wantGoalMet {
+				t.Errorf("GoalMet = %v, want %v", summary.GoalMet, tt.wantGoalMet)
+			}
+			if summary.GoalProgress != tt.wantProgress {
+				t.Errorf("GoalProgress = %d, want %d", summary.GoalProgress, tt.wantProgress)
+			}
+			if summary.LastActiveDate != tt.wantLast {
+				t.Errorf("LastActiveDate = %q, want %q", summary.LastActiveDate, tt.wantLast)
+			}
+		})
+	}
+}
@@ -29,13 +29,17 @@ var mockWeeklyActivity = []models.WeeklyActivity{
 
 type StudentService struct {
 	courseService *CourseService
+	streakService *StreakService
 	activityRepo  *repository.ActivityRepository
+	settingsRepo  *repository.SettingsRepository
 }
 
 func NewStudentService() *StudentService {
 	return &StudentService{
 		courseService: NewCourseService(),
+		streakService: NewStreakService(),
 		activityRepo:  repository.NewActivityRepository(),
+		settingsRepo:  repository.NewSettingsRepository(),
 	}
 }
 
@@ -43,6 +47,7 @@ type StudentDashboard struct {
 	User              StudentDashboardUser    `json:"user"`
 	RecentActivity    []models.ActivityLog    `json:"recentActivity"`
 	WeeklyActivity    []models.WeeklyActivity `json:"weeklyActivity"`
+	Streak            models.StreakSummary    `json:"streak"`
 	AIInsight         AIInsight               `json:"aiInsight"`
 	UpcomingDeadlines []Deadline              `json:"upcomingDeadlines"`
 }
@@ -60,6 +65,7 @@ type AIInsight struct {
 	Title   string `json:"title"`
 	Message string `json:"message"`
 	Type    string `json:"type"`
+	Source  string `json:"source"`
 }
 
 type Deadline struct {
@@ -94,6 +100,8 @@ func (s *StudentService) GetDashboard(userID string) (*StudentDashboard, error)
 	// Get weekly activity
 	weeklyActivity := s.getWeeklyActivity(userID)
 
+	streak := s.streakService.GetSummary(userID)
+
 	return &StudentDashboard{
 		User: StudentDashboardUser{
 			Name:             user.Name,
@@ -105,11 +113,8 @@ func (s *StudentService) GetDashboard(userID string) (*StudentDashboard, error)
 		},
 		RecentActivity: recentActivity,
 		WeeklyActivity: weeklyActivity,
-		AIInsight: AIInsight{
-			Title:   "Insight Hari Ini",
-			Message: "Produktivitas Anda meningkat 15% dibanding minggu lalu. Pertahankan momentum dengan fokus pada modul Decision Trees hari ini.",
-			Type:    "positive",
-		},
+		Streak:         *streak,
+		AIInsight:      s.buildInsight(userID, enrolledCourses, streak),
 		UpcomingDeadlines: []Deadline{
 			{ID: "1", Title: "Quiz: Decision Trees", Course: "Machine Learning", DueDate: time.Now().Add(72 * time.Hour).Format("2006-01-02")},
 			{ID: "2", Title: "Project: React App", Course: "Web Development", DueDate: time.Now().Add(120 * time.Hour).Format("2006-01-02")},
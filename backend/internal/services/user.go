@@ -10,16 +10,22 @@ import (
 )
 
 type UserService struct {
-	authService  *AuthService
-	userRepo     *repository.UserRepository
-	settingsRepo *repository.SettingsRepository
+	authService        *AuthService
+	streakService      *StreakService
+	achievementService *AchievementService
+	userRepo           *repository.UserRepository
+	settingsRepo       *repository.SettingsRepository
+	certificateRepo    *repository.CertificateRepository
 }
 
 func NewUserService() *UserService {
 	return &UserService{
-		authService:  NewAuthService(),
-		userRepo:     repository.NewUserRepository(),
-		settingsRepo: repository.NewSettingsRepository(),
+		authService:        NewAuthService(),
+		streakService:      NewStreakService(),
+		achievementService: NewAchievementService(),
+		userRepo:           repository.NewUserRepository(),
+		settingsRepo:       repository.NewSettingsRepository(),
+		certificateRepo:    repository.NewCertificateRepository(),
 	}
 }
 
@@ -36,22 +42,25 @@ func (s *UserService) GetProfile(userID string) (*ProfileResponse, error) {
 		return nil, err
 	}
 
+	streak := s.streakService.GetSummary(userID)
+	badges := s.achievementService.GetUserBadges(userID)
+
 	return &ProfileResponse{
 		User: *user,
 		Stats: models.UserStats{
 			TotalStudyTime:   user.TotalStudyTime,
 			ModulesCompleted: user.CompletedModules,
 			CoursesEnrolled:  len(user.EnrolledCourses),
-			Certificates:     3,
-			Badges:           12,
-			Streak:           15,
-		},
-		Badges: []models.Badge{
-			{ID: 1, Name: "Early Bird", Icon: "🌅", Description: "Belajar sebelum jam 6 pagi"},
-			{ID: 2, Name: "Consistent Learner", Icon: "🔥", Description: "Streak 7 hari berturut-turut"},
-			{ID: 3, Name: "Quiz Master", Icon: "🏆", Description: "Skor 100% di 5 quiz"},
-			{ID: 4, Name: "Fast Learner", Icon: "⚡", Description: "Selesaikan 10 modul dalam sehari"},
+			Certificates:     len(findUserCertificates(s.certificateRepo, userID)),
+			Badges:           len(badges),
+			Streak:           streak.CurrentStreak,
+			LongestStreak:    streak.LongestStreak,
+			FreezeTokens:     streak.FreezeTokens,
+			DailyGoal:        streak.DailyGoal,
+			TodayMinutes:     streak.TodayMinutes,
+			DailyGoalMet:     streak.GoalMet,
 		},
+		Badges:         badges,
 		RecentActivity: mockActivityLogs[:3],
 	}, nil
 }
@@ -233,3 +242,89 @@ func (s *UserService) DeleteAccount(userID, password string) error {
 	}
 	return fmt.Errorf("user not found")
 }
+
+// findAllStudents returns every user with the student role
+func findAllStudents(userRepo *repository.UserRepository) []models.User {
+	// Try Firestore first
+	if userRepo.IsFirestoreAvailable() {
+		students, err := userRepo.GetAllStudents()
+		if err == nil {
+			return students
+		}
+	}
+
+	// Fallback to mock data
+	var students []models.User
+	for _, user := range GetMockUsers() {
+		if user.Role == "student" {
+			students = append(students, user)
+		}
+	}
+	return students
+}
+
+// findUsersByRole returns every user with the given role
+func findUsersByRole(userRepo *repository.UserRepository, role string) []models.User {
+	// Try Firestore first
+	if userRepo.IsFirestoreAvailable() {
+		users, err := userRepo.FindByRole(role)
+		if err == nil {
+			return users
+		}
+	}
+
+	// Fallback to mock data
+	var users []models.User
+	for _, user := range GetMockUsers() {
+		if user.Role == role {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// findAssignedStudents returns the students assigned to a mentor
+func findAssignedStudents(userRepo *repository.UserRepository, mentorID string) []models.User {
+	// Try Firestore first
+	if userRepo.IsFirestoreAvailable() {
+		students, err := userRepo.GetStudentsByMentor(mentorID)
+		if err == nil {
+			return students
+		}
+	}
+
+	// Fallback to mock data
+	users := GetMockUsers()
+	var students []models.User
+	for _, assignment := range findMockAssignments(models.AssignmentQuery{MentorID: mentorID, ActiveOnly: true}) {
+		for _, user := range users {
+			if user.ID == assignment.StudentID {
+				students = append(students, user)
+			}
+		}
+	}
+	return students
+}
+
+// findMentorsOfStudent returns the mentors a student is assigned to
+func findMentorsOfStudent(userRepo *repository.UserRepository, studentID string) []models.User {
+	// Try Firestore first
+	if userRepo.IsFirestoreAvailable() {
+		mentors, err := userRepo.GetMentorsOfStudent(studentID)
+		if err == nil {
+			return mentors
+		}
+	}
+
+	// Fallback to mock data
+	users := GetMockUsers()
+	var mentors []models.User
+	for _, assignment := range findMockAssignments(models.AssignmentQuery{StudentID: studentID, ActiveOnly: true}) {
+		for _, user := range users {
+			if user.ID == assignment.MentorID {
+				mentors = append(mentors, user)
+			}
+		}
+	}
+	return mentors
+}
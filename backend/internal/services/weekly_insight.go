@@ -0,0 +1,332 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mentorsphere-api/internal/models"
+)
+
+const (
+	// Number of categories reported as top subjects
+	weeklyTopSubjects = 3
+	// Days with activity from which a week counts as consistent
+	weeklyConsistentDays = 5
+	// Subject used for activity that is not linked to a course
+	weeklyOtherSubject = "Lainnya"
+)
+
+var indonesianWeekdays = [...]string{"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"}
+
+// Time-of-day buckets by local start hour, with their Indonesian names
+var timeOfDayNames = map[string]string{
+	"morning":   "pagi",
+	"afternoon": "siang",
+	"evening":   "sore",
+	"night":     "malam",
+}
+
+func timeOfDay(hour int) string {
+	switch {
+	case hour >= 5 && hour < 11:
+		return "morning"
+	case hour >= 11 && hour < 15:
+		return "afternoon"
+	case hour >= 15 && hour < 19:
+		return "evening"
+	default:
+		return "night"
+	}
+}
+
+// Weekly insights for development (used as fallback when Firebase is not configured)
+var (
+	mockWeeklyInsights   = make(map[string]map[string]models.WeeklyInsight)
+	mockWeeklyInsightsMu sync.Mutex
+)
+
+// GetWeeklyInsight returns the insight for an ISO week (year and week 0 mean
+// the current week). Past weeks are served from storage and computed once if
+// missing; the current week is recomputed because it is still in progress.
+func (s *ReflectionService) GetWeeklyInsight(userID string, year, week int) (*models.WeeklyInsight, error) {
+	loc := userLocation(loadUserSettings(s.settingsRepo, userID))
+	currentStart := isoWeekStartOf(time.Now(), loc)
+
+	start := currentStart
+	if year != 0 || week != 0 {
+		if year == 0 {
+			year, _ = time.Now().In(loc).ISOWeek()
+		}
+		var err error
+		if start, err = isoWeekStart(year, week, loc); err != nil {
+			return nil, err
+		}
+	}
+	if start.After(currentStart) {
+		return nil, fmt.Errorf("cannot compute insights for a future week")
+	}
+
+	if start.Before(currentStart) {
+		year, week := start.ISOWeek()
+		if insight := s.findWeeklyInsight(userID, year, week); insight != nil {
+			return insight, nil
+		}
+	}
+
+	return s.GenerateWeeklyInsight(userID, start), nil
+}
+
+// ListWeeklyInsights returns the user's stored weekly insights, newest first
+func (s *ReflectionService) ListWeeklyInsights(userID string, limit int) []models.WeeklyInsight {
+	// Try Firestore first
+	if s.weeklyRepo.IsFirestoreAvailable() {
+		insights, err := s.weeklyRepo.FindByUserID(userID, limit)
+		if err == nil {
+			return insights
+		}
+	}
+
+	// Fallback to mock data
+	mockWeeklyInsightsMu.Lock()
+	defer mockWeeklyInsightsMu.Unlock()
+	insights := []models.WeeklyInsight{}
+	for _, insight := range mockWeeklyInsights[userID] {
+		insights = append(insights, insight)
+	}
+	sort.Slice(insights, func(i, j int) bool { return insights[i].WeekStart > insights[j].WeekStart })
+	if limit > 0 && len(insights) > limit {
+		insights = insights[:limit]
+	}
+	return insights
+}
+
+// GenerateWeeklyInsight computes the insight for the ISO week containing day
+// from the user's activity and stores it
+func (s *ReflectionService) GenerateWeeklyInsight(userID string, day time.Time) *models.WeeklyInsight {
+	settings := loadUserSettings(s.settingsRepo, userID)
+	loc := userLocation(settings)
+	start := isoWeekStartOf(day, loc)
+	end := start.AddDate(0, 0, 7).Add(-time.Nanosecond)
+
+	activities := findUserActivitiesBetween(s.activityRepo, userID, start, end)
+	previousTotal := s.previousWeekMinutes(userID, start)
+
+	// Average over the days that have passed, so a week in progress is not diluted
+	elapsedDays := 7
+	if today := startOfDay(time.Now(), loc); today.Before(start.AddDate(0, 0, 7)) {
+		elapsedDays = int(today.Sub(start).Hours()/24) + 1
+	}
+
+	insight := s.buildWeeklyInsight(activities, previousTotal, start, loc, elapsedDays, settings.Learning.DailyGoal)
+	insight.UserID = userID
+	insight.GeneratedAt = time.Now()
+
+	s.saveWeeklyInsight(insight)
+	return insight
+}
+
+// previousWeekMinutes returns the study minutes of the week before start. The
+// nightly rollups cover a past week in full; the activity log is read only
+// when some of its days have not been rolled up yet.
+func (s *ReflectionService) previousWeekMinutes(userID string, start time.Time) int {
+	previousStart := start.AddDate(0, 0, -7)
+	rollups := s.rollupService.GetRollups(userID, previousStart.Format(dateLayout), start.AddDate(0, 0, -1).Format(dateLayout))
+	if len(rollups) == 7 {
+		total := 0
+		for _, rollup := range rollups {
+			total += rollup.Minutes
+		}
+		return total
+	}
+	return sumMinutes(findUserActivitiesBetween(s.activityRepo, userID, previousStart, start.Add(-time.Nanosecond)))
+}
+
+func (s *ReflectionService) buildWeeklyInsight(activities []models.ActivityLog, previousTotal int, start time.Time, loc *time.Location, elapsedDays, dailyGoal int) *models.WeeklyInsight {
+	year, week := start.ISOWeek()
+	insight := &models.WeeklyInsight{
+		Year:               year,
+		WeekNumber:         week,
+		WeekStart:          start.Format(dateLayout),
+		WeekEnd:            start.AddDate(0, 0, 6).Format(dateLayout),
+		TopSubjects:        []string{},
+		SubjectMinutes:     []models.SubjectMinutes{},
+		DailyMinutes:       make([]models.DayMinutes, 0, 7),
+		MinutesByTimeOfDay: make(map[string]int),
+		Insights:           []string{},
+	}
+
+	perDay := dailyMinutes(activities, loc)
+	bySubject := make(map[string]int)
+	quizCount, quizTotal := 0, 0
+	for _, activity := range activities {
+		insight.TotalStudyTime += activity.Duration
+		insight.MinutesByTimeOfDay[timeOfDay(activity.Date.In(loc).Hour())] += activity.Duration
+		bySubject[s.courseCategory(activity.CourseID)] += activity.Duration
+		if activity.Type == "quiz" && activity.Score != nil {
+			quizCount++
+			quizTotal += *activity.Score
+		}
+	}
+	insight.AverageDaily = insight.TotalStudyTime / max(elapsedDays, 1)
+
+	for i := 0; i < 7; i++ {
+		day := start.AddDate(0, 0, i)
+		minutes := perDay[day.Format(dateLayout)]
+		insight.DailyMinutes = append(insight.DailyMinutes, models.DayMinutes{
+			Date:    day.Format(dateLayout),
+			Day:     indonesianWeekdays[day.Weekday()],
+			Minutes: minutes,
+		})
+		if minutes > 0 {
+			insight.ActiveDays++
+		}
+		if minutes > insight.BestDayMinutes {
+			insight.BestDay = indonesianWeekdays[day.Weekday()]
+			insight.BestDayMinutes = minutes
+		}
+	}
+
+	for subject, minutes := range bySubject {
+		insight.SubjectMinutes = append(insight.SubjectMinutes, models.SubjectMinutes{Subject: subject, Minutes: minutes})
+	}
+	sort.Slice(insight.SubjectMinutes, func(i, j int) bool {
+		if insight.SubjectMinutes[i].Minutes != insight.SubjectMinutes[j].Minutes {
+			return insight.SubjectMinutes[i].Minutes > insight.SubjectMinutes[j].Minutes
+		}
+		return insight.SubjectMinutes[i].Subject < insight.SubjectMinutes[j].Subject
+	})
+	for i := 0; i < len(insight.SubjectMinutes) && i < weeklyTopSubjects; i++ {
+		insight.TopSubjects = append(insight.TopSubjects, insight.SubjectMinutes[i].Subject)
+	}
+
+	bestSlot := 0
+	for _, slot := range []string{"morning", "afternoon", "evening", "night"} {
+		if insight.MinutesByTimeOfDay[slot] > bestSlot {
+			insight.TimeOfDay = slot
+			bestSlot = insight.MinutesByTimeOfDay[slot]
+		}
+	}
+
+	insight.PreviousWeekTotal = previousTotal
+	if insight.PreviousWeekTotal > 0 {
+		insight.ChangePercent = (insight.TotalStudyTime - insight.PreviousWeekTotal) * 100 / insight.PreviousWeekTotal
+	}
+
+	insight.Insights = weeklyInsightLines(insight, elapsedDays, quizCount, quizTotal)
+	insight.Recommendation = weeklyRecommendation(insight, elapsedDays, dailyGoal)
+	return insight
+}
+
+func (s *ReflectionService) courseCategory(courseID string) string {
+	if course := s.courseService.GetByID(courseID); course != nil && course.Category != "" {
+		return course.Category
+	}
+	return weeklyOtherSubject
+}
+
+func weeklyInsightLines(insight *models.WeeklyInsight, elapsedDays, quizCount, quizTotal int) []string {
+	if insight.TotalStudyTime == 0 {
+		lines := []string{"Belum ada aktivitas belajar yang tercatat pada minggu ini"}
+		if insight.PreviousWeekTotal > 0 {
+			lines = append(lines, fmt.Sprintf("Minggu lalu Anda belajar selama %d menit", insight.PreviousWeekTotal))
+		}
+		return lines
+	}
+
+	lines := []string{
+		fmt.Sprintf("Produktivitas tertinggi pada hari %s (%d menit)", insight.BestDay, insight.BestDayMinutes),
+		fmt.Sprintf("Aktif belajar %d dari %d hari", insight.ActiveDays, elapsedDays),
+	}
+	if insight.TimeOfDay != "" {
+		lines = append(lines, fmt.Sprintf("Pola belajar lebih aktif di %s hari", timeOfDayNames[insight.TimeOfDay]))
+	}
+	if quizCount > 0 {
+		lines = append(lines, fmt.Sprintf("Rata-rata skor quiz %d%% dari %d quiz", quizTotal/quizCount, quizCount))
+	}
+	switch {
+	case insight.PreviousWeekTotal == 0:
+		lines = append(lines, "Tidak ada aktivitas pada minggu sebelumnya untuk dibandingkan")
+	case insight.ChangePercent >= insightChangeThreshold:
+		lines = append(lines, fmt.Sprintf("Waktu belajar naik %d%% dibanding minggu lalu", insight.ChangePercent))
+	case insight.ChangePercent <= -insightChangeThreshold:
+		lines = append(lines, fmt.Sprintf("Waktu belajar turun %d%% dibanding minggu lalu", -insight.ChangePercent))
+	default:
+		lines = append(lines, "Waktu belajar stabil dibanding minggu lalu")
+	}
+	return lines
+}
+
+func weeklyRecommendation(insight *models.WeeklyInsight, elapsedDays, dailyGoal int) string {
+	switch {
+	case insight.TotalStudyTime == 0:
+		return "Mulai dengan sesi singkat hari ini untuk membangun kembali ritme belajar Anda."
+	case dailyGoal > 0 && insight.AverageDaily < dailyGoal:
+		return fmt.Sprintf("Tambahkan sekitar %d menit per hari untuk mencapai target harian %d menit.",
+			dailyGoal-insight.AverageDaily, dailyGoal)
+	case elapsedDays >= weeklyConsistentDays && insight.ActiveDays < weeklyConsistentDays:
+		return "Sebarkan waktu belajar ke lebih banyak hari agar ritme belajar tetap terjaga."
+	case len(insight.TopSubjects) > 0:
+		return fmt.Sprintf("Pertahankan momentum belajar Anda. Fokus pada %s sebelum melanjutkan ke topik baru.", insight.TopSubjects[0])
+	default:
+		return "Pertahankan momentum belajar Anda."
+	}
+}
+
+func (s *ReflectionService) findWeeklyInsight(userID string, year, week int) *models.WeeklyInsight {
+	// Try Firestore first
+	if s.weeklyRepo.IsFirestoreAvailable() {
+		insight, err := s.weeklyRepo.FindByUserAndWeek(userID, year, week)
+		if err == nil {
+			return insight
+		}
+	}
+
+	// Fallback to mock data
+	mockWeeklyInsightsMu.Lock()
+	defer mockWeeklyInsightsMu.Unlock()
+	if insight, ok := mockWeeklyInsights[userID][isoWeekKey(year, week)]; ok {
+		return &insight
+	}
+	return nil
+}
+
+func (s *ReflectionService) saveWeeklyInsight(insight *models.WeeklyInsight) {
+	// Try Firestore first
+	if s.weeklyRepo.IsFirestoreAvailable() {
+		if err := s.weeklyRepo.Save(insight); err == nil {
+			return
+		}
+	}
+
+	// Fallback to mock data
+	mockWeeklyInsightsMu.Lock()
+	defer mockWeeklyInsightsMu.Unlock()
+	if mockWeeklyInsights[insight.UserID] == nil {
+		mockWeeklyInsights[insight.UserID] = make(map[string]models.WeeklyInsight)
+	}
+	mockWeeklyInsights[insight.UserID][isoWeekKey(insight.Year, insight.WeekNumber)] = *insight
+}
+
+// isoWeekStartOf returns local midnight of the Monday starting t's ISO week
+func isoWeekStartOf(t time.Time, loc *time.Location) time.Time {
+	day := startOfDay(t, loc)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// isoWeekStart returns local midnight of the Monday of ISO week week in year
+func isoWeekStart(year, week int, loc *time.Location) (time.Time, error) {
+	// 4 January is always in week 1, and 28 December in the last week
+	_, lastWeek := time.Date(year, time.December, 28, 0, 0, 0, 0, loc).ISOWeek()
+	if year < 1970 || week < 1 || week > lastWeek {
+		return time.Time{}, fmt.Errorf("invalid ISO week %d-W%02d", year, week)
+	}
+	firstMonday := isoWeekStartOf(time.Date(year, time.January, 4, 0, 0, 0, 0, loc), loc)
+	return firstMonday.AddDate(0, 0, (week-1)*7), nil
+}
+
+func isoWeekKey(year, week int) string {
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
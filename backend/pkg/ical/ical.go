@@ -0,0 +1,125 @@
+// Package ical renders iCalendar (RFC 5545) feeds with VEVENT entries,
+// without any external dependencies.
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	timeLayout = "20060102T150405Z"
+	// Content lines longer than this many octets are folded
+	maxLineOctets = 75
+)
+
+// Event is one calendar entry. Times are written in UTC.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	// Status is TENTATIVE, CONFIRMED or CANCELLED
+	Status string
+	// Sequence must grow whenever the event changes so clients replace it
+	Sequence int
+	Updated  time.Time
+}
+
+// Calendar is a feed of events
+type Calendar struct {
+	ProductID string
+	Name      string
+	Events    []Event
+}
+
+// Render returns the calendar as text/calendar content
+func (c *Calendar) Render() []byte {
+	var buf bytes.Buffer
+	now := time.Now()
+
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:"+escapeText(c.ProductID))
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&buf, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		stamp := event.Updated
+		if stamp.IsZero() {
+			stamp = now
+		}
+
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+escapeText(event.UID))
+		writeLine(&buf, "DTSTAMP:"+formatTime(stamp))
+		writeLine(&buf, "DTSTART:"+formatTime(event.Start))
+		writeLine(&buf, "DTEND:"+formatTime(event.End))
+		writeLine(&buf, "SUMMARY:"+escapeText(event.Summary))
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(&buf, "LOCATION:"+escapeText(event.Location))
+		}
+		if event.URL != "" {
+			writeLine(&buf, "URL:"+event.URL)
+		}
+		if event.Status != "" {
+			writeLine(&buf, "STATUS:"+event.Status)
+		}
+		writeLine(&buf, "SEQUENCE:"+strconv.Itoa(event.Sequence))
+		writeLine(&buf, "LAST-MODIFIED:"+formatTime(stamp))
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// escapeText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeLine writes a content line folded at 75 octets, never splitting a
+// UTF-8 sequence, and terminated by CRLF
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,124 @@
+// Package pdf renders simple single-page PDF documents using the standard
+// Helvetica fonts, without any external dependencies.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 landscape page size in points
+const (
+	A4LandscapeWidth  = 842.0
+	A4LandscapeHeight = 595.0
+)
+
+// Approximate average glyph widths (per point of font size) used to
+// center text; exact metrics are not needed for the layouts we render.
+const (
+	regularCharWidth = 0.5
+	boldCharWidth    = 0.55
+)
+
+// Document is a single-page PDF under construction
+type Document struct {
+	width   float64
+	height  float64
+	title   string
+	content bytes.Buffer
+}
+
+// New creates an empty page of the given size in points
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// SetTitle sets the document title shown by PDF viewers
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// Width returns the page width in points
+func (d *Document) Width() float64 {
+	return d.width
+}
+
+// Text draws text with its baseline starting at (x, y), measured from the bottom-left corner
+func (d *Document) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(&d.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(text))
+}
+
+// TextCentered draws text horizontally centered on the page
+func (d *Document) TextCentered(y, size float64, bold bool, text string) {
+	charWidth := regularCharWidth
+	if bold {
+		charWidth = boldCharWidth
+	}
+	textWidth := float64(len([]rune(text))) * size * charWidth
+	d.Text((d.width-textWidth)/2, y, size, bold, text)
+}
+
+// Rect strokes a rectangle outline
+func (d *Document) Rect(x, y, w, h, lineWidth float64) {
+	fmt.Fprintf(&d.content, "%.2f w %.2f %.2f %.2f %.2f re S\n", lineWidth, x, y, w, h)
+}
+
+// Line strokes a straight line
+func (d *Document) Line(x1, y1, x2, y2, lineWidth float64) {
+	fmt.Fprintf(&d.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", lineWidth, x1, y1, x2, y2)
+}
+
+// Bytes serializes the document
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+		"/Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> /Contents 4 0 R >>", d.width, d.height))
+	object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", d.content.Len(), d.content.String()))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (MentorSphere) >>", escape(d.title)))
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, len(offsets), xref)
+
+	return out.Bytes()
+}
+
+// escape converts text to a WinAnsi PDF string literal body
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,269 @@
+// Package webpush sends Web Push messages: payloads are encrypted with
+// aes128gcm (RFC 8291) and requests are signed with VAPID (RFC 8292).
+package webpush
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// Record size announced in the content coding header; payloads must fit
+	// into a single record
+	recordSize = 4096
+	// Push services accept 4096 body bytes, which also hold the 86-byte
+	// header, the padding delimiter and the GCM tag
+	maxPayload = 4096 - 86 - 1 - 16
+	// VAPID tokens are valid for at most 24 hours; stay well below
+	vapidTokenTTL = 12 * time.Hour
+)
+
+var (
+	// ErrGone is returned when the push service reports the subscription as
+	// expired or unsubscribed; it should be deleted
+	ErrGone = errors.New("push subscription is no longer valid")
+	// ErrPayloadTooLarge is returned for payloads above the record size
+	ErrPayloadTooLarge = errors.New("push payload too large")
+)
+
+// Subscription is a browser PushSubscription as returned by toJSON()
+type Subscription struct {
+	Endpoint string
+	// P256DH is the browser's public key, base64url encoded
+	P256DH string
+	// Auth is the authentication secret, base64url encoded
+	Auth string
+}
+
+// Options of a single message
+type Options struct {
+	// TTL is how long the push service keeps an undelivered message
+	TTL time.Duration
+	// Urgency is very-low, low, normal or high
+	Urgency string
+	// Topic replaces an undelivered message with the same topic
+	Topic string
+}
+
+// Client sends push messages signed with one VAPID key pair
+type Client struct {
+	privateKey *ecdsa.PrivateKey
+	publicKey  string
+	subject    string
+	httpClient *http.Client
+}
+
+// NewClient creates a client from a base64url encoded VAPID private key (the
+// raw 32-byte scalar). subject is a mailto: or https: contact URL.
+func NewClient(privateKey, subject string) (*Client, error) {
+	raw, err := decodeBase64(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	public, err := key.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		privateKey: key,
+		publicKey:  base64.RawURLEncoding.EncodeToString(public),
+		subject:    subject,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+// GenerateVAPIDKeys returns a new base64url encoded key pair
+func GenerateVAPIDKeys() (privateKey, publicKey string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	private, err := key.Bytes()
+	if err != nil {
+		return "", "", err
+	}
+	public, err := key.PublicKey.Bytes()
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(private), base64.RawURLEncoding.EncodeToString(public), nil
+}
+
+// PublicKey returns the application server key browsers subscribe with
+func (c *Client) PublicKey() string {
+	return c.publicKey
+}
+
+// Send encrypts payload for the subscription and posts it to the push service
+func (c *Client) Send(sub Subscription, payload []byte, opts Options) error {
+	body, err := Encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := url.Parse(sub.Endpoint)
+	if err != nil || endpoint.Scheme != "https" {
+		return fmt.Errorf("invalid push endpoint %q", sub.Endpoint)
+	}
+	token, err := c.vapidToken(endpoint.Scheme + "://" + endpoint.Host)
+	if err != nil {
+		return err
+	}
+
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	req.Header.Set("Authorization", fmt.Sprintf("vapid t=%s, k=%s", token, c.publicKey))
+	if opts.Urgency != "" {
+		req.Header.Set("Urgency", opts.Urgency)
+	}
+	if opts.Topic != "" {
+		req.Header.Set("Topic", opts.Topic)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("push request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrGone
+	case resp.StatusCode >= 300:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("push service returned %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+func (c *Client) vapidToken(audience string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": audience,
+		"exp": time.Now().Add(vapidTokenTTL).Unix(),
+		"sub": c.subject,
+	})
+	return token.SignedString(c.privateKey)
+}
+
+// Encrypt returns the aes128gcm encoded body for the subscription
+func Encrypt(sub Subscription, payload []byte) ([]byte, error) {
+	if len(payload) > maxPayload {
+		return nil, ErrPayloadTooLarge
+	}
+
+	uaPublicBytes, err := decodeBase64(sub.P256DH)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	authSecret, err := decodeBase64(sub.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth secret: %w", err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+
+	// Ephemeral key pair of the application server
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, string(keyInfo), 32)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// A single record ends with the 0x02 padding delimiter
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	// Header: salt (16) || record size (4) || key id length (1) || key id
+	var body bytes.Buffer
+	body.Write(salt)
+	binary.Write(&body, binary.BigEndian, uint32(recordSize))
+	body.WriteByte(byte(len(asPublic)))
+	body.Write(asPublic)
+	body.Write(ciphertext)
+
+	return body.Bytes(), nil
+}
+
+// decodeBase64 accepts base64url and standard encodings, padded or not
+func decodeBase64(value string) ([]byte, error) {
+	for _, encoding := range []*base64.Encoding{
+		base64.RawURLEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.StdEncoding,
+	} {
+		if decoded, err := encoding.DecodeString(value); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, errors.New("not base64 encoded")
+}
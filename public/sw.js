@@ -0,0 +1,35 @@
+// Service worker for Web Push notifications sent by the MentorSphere API
+
+self.addEventListener('push', (event) => {
+  let payload = {};
+  try {
+    payload = event.data ? event.data.json() : {};
+  } catch (error) {
+    payload = { title: 'MentorSphere', body: event.data ? event.data.text() : '' };
+  }
+
+  event.waitUntil(
+    self.registration.showNotification(payload.title || 'MentorSphere', {
+      body: payload.body,
+      icon: '/favicon.ico',
+      tag: payload.notificationId,
+      data: { url: payload.url || '/' },
+    })
+  );
+});
+
+self.addEventListener('notificationclick', (event) => {
+  event.notification.close();
+  const url = event.notification.data?.url || '/';
+
+  event.waitUntil(
+    self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then((clients) => {
+      const client = clients.find((c) => 'focus' in c);
+      if (client) {
+        client.navigate(url);
+        return client.focus();
+      }
+      return self.clients.openWindow(url);
+    })
+  );
+});
@@ -0,0 +1,36 @@
+import apiClient from './client';
+
+export const assignmentsAPI = {
+  getAssignments: async ({ mentorId, studentId, active } = {}) => {
+    const response = await apiClient.get('/assignments', {
+      params: {
+        mentorId: mentorId || undefined,
+        studentId: studentId || undefined,
+        active: active || undefined,
+      },
+    });
+    return response.data.data;
+  },
+
+  assign: async ({ mentorId, studentId, note = '' }) => {
+    const response = await apiClient.post('/assignments', { mentorId, studentId, note });
+    return response.data.data;
+  },
+
+  reassign: async ({ studentId, fromMentorId, toMentorId, note = '' }) => {
+    const response = await apiClient.post('/assignments/reassign', {
+      studentId,
+      fromMentorId,
+      toMentorId,
+      note,
+    });
+    return response.data.data;
+  },
+
+  unassign: async (assignmentId) => {
+    const response = await apiClient.delete(`/assignments/${assignmentId}`);
+    return response.data.data;
+  },
+};
+
+export default assignmentsAPI;
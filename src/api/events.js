@@ -0,0 +1,32 @@
+const API_BASE_URL = import.meta.env.VITE_API_URL || 'http://localhost:3001/api';
+
+const EVENT_TYPES = ['notification', 'notification.read', 'intervention', 'progress', 'message', 'message.read', 'resync'];
+
+// subscribeToEvents opens the real-time event stream and calls
+// handlers[type](data) for every event. The browser reconnects on its own and
+// sends Last-Event-ID, so missed events are delivered after a reconnect.
+// Returns a function that closes the stream.
+export const subscribeToEvents = (handlers) => {
+  const token = localStorage.getItem('token');
+  if (!token || typeof EventSource === 'undefined') {
+    return () => {};
+  }
+
+  const source = new EventSource(`${API_BASE_URL}/events?token=${encodeURIComponent(token)}`);
+
+  EVENT_TYPES.forEach((type) => {
+    source.addEventListener(type, (message) => {
+      const handler = handlers[type];
+      if (!handler) return;
+      try {
+        handler(JSON.parse(message.data).data);
+      } catch (error) {
+        console.error(`Failed to handle ${type} event:`, error);
+      }
+    });
+  });
+
+  return () => source.close();
+};
+
+export default { subscribeToEvents };
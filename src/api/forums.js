@@ -0,0 +1,91 @@
+import apiClient from './client';
+
+export const forumsAPI = {
+  getThreads: async (courseId, { moduleId, sort = 'activity', limit = 20, offset = 0 } = {}) => {
+    const response = await apiClient.get(`/forums/courses/${courseId}/threads`, {
+      params: {
+        moduleId: moduleId || undefined,
+        sort,
+        limit,
+        offset,
+      },
+    });
+    return response.data.data;
+  },
+
+  createThread: async (courseId, { moduleId, title, body, mentions = [] }) => {
+    const response = await apiClient.post(`/forums/courses/${courseId}/threads`, {
+      moduleId: moduleId || 0,
+      title,
+      body,
+      mentions,
+    });
+    return response.data.data;
+  },
+
+  getThread: async (threadId) => {
+    const response = await apiClient.get(`/forums/threads/${threadId}`);
+    return response.data.data;
+  },
+
+  reply: async (threadId, { body, mentions = [] }) => {
+    const response = await apiClient.post(`/forums/threads/${threadId}/replies`, { body, mentions });
+    return response.data.data;
+  },
+
+  upvoteThread: async (threadId, upvote = true) => {
+    const response = upvote
+      ? await apiClient.post(`/forums/threads/${threadId}/upvote`)
+      : await apiClient.delete(`/forums/threads/${threadId}/upvote`);
+    return response.data.data;
+  },
+
+  upvoteReply: async (replyId, upvote = true) => {
+    const response = upvote
+      ? await apiClient.post(`/forums/replies/${replyId}/upvote`)
+      : await apiClient.delete(`/forums/replies/${replyId}/upvote`);
+    return response.data.data;
+  },
+
+  endorseReply: async (replyId, endorse = true) => {
+    const response = endorse
+      ? await apiClient.post(`/forums/replies/${replyId}/endorse`)
+      : await apiClient.delete(`/forums/replies/${replyId}/endorse`);
+    return response.data.data;
+  },
+
+  reportThread: async (threadId, reason) => {
+    const response = await apiClient.post(`/forums/threads/${threadId}/report`, { reason });
+    return response.data.data;
+  },
+
+  reportReply: async (replyId, reason) => {
+    const response = await apiClient.post(`/forums/replies/${replyId}/report`, { reason });
+    return response.data.data;
+  },
+
+  // flags: { pinned, locked, hidden }; omitted flags stay as they are
+  moderateThread: async (threadId, flags) => {
+    const response = await apiClient.put(`/forums/threads/${threadId}/moderation`, flags);
+    return response.data.data;
+  },
+
+  moderateReply: async (replyId, hidden) => {
+    const response = await apiClient.put(`/forums/replies/${replyId}/moderation`, { hidden });
+    return response.data.data;
+  },
+
+  getReports: async (courseId, status = 'open') => {
+    const response = await apiClient.get(`/forums/courses/${courseId}/reports`, {
+      params: { status: status || undefined },
+    });
+    return response.data.data;
+  },
+
+  resolveReport: async (reportId, status) => {
+    const response = await apiClient.put(`/forums/reports/${reportId}`, { status });
+    return response.data.data;
+  },
+};
+
+export default forumsAPI;
@@ -0,0 +1,55 @@
+import apiClient from './client';
+
+export const meetingsAPI = {
+  getMeetings: async () => {
+    const response = await apiClient.get('/meetings');
+    return response.data.data;
+  },
+
+  getOpenSlots: async () => {
+    const response = await apiClient.get('/meetings/availability');
+    return response.data.data;
+  },
+
+  book: async (slotId, topic, interventionId = null) => {
+    const response = await apiClient.post('/meetings', { slotId, topic, interventionId });
+    return response.data.data;
+  },
+
+  reschedule: async (meetingId, slotId) => {
+    const response = await apiClient.put(`/meetings/${meetingId}/reschedule`, { slotId });
+    return response.data.data;
+  },
+
+  cancel: async (meetingId, reason = '') => {
+    const response = await apiClient.post(`/meetings/${meetingId}/cancel`, { reason });
+    return response.data.data;
+  },
+
+  getSlots: async () => {
+    const response = await apiClient.get('/mentor/availability');
+    return response.data.data;
+  },
+
+  createSlot: async (start, end, timezone) => {
+    const response = await apiClient.post('/mentor/availability', { start, end, timezone });
+    return response.data.data;
+  },
+
+  deleteSlot: async (slotId) => {
+    const response = await apiClient.delete(`/mentor/availability/${slotId}`);
+    return response.data.data;
+  },
+
+  getCalendarFeed: async () => {
+    const response = await apiClient.get('/user/calendar');
+    return response.data.data;
+  },
+
+  resetCalendarFeed: async () => {
+    const response = await apiClient.post('/user/calendar/reset');
+    return response.data.data;
+  },
+};
+
+export default meetingsAPI;
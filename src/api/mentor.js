@@ -1,8 +1,9 @@
 import apiClient from './client';
 
 export const mentorAPI = {
-  getDashboard: async () => {
-    const response = await apiClient.get('/mentor/dashboard');
+  // params: { days, from, to, courseId }
+  getDashboard: async (params = {}) => {
+    const response = await apiClient.get('/mentor/dashboard', { params });
     return response.data.data;
   },
 
@@ -26,6 +27,46 @@ export const mentorAPI = {
     return response.data.data;
   },
 
+  previewBulkInterventions: async (data) => {
+    const response = await apiClient.post('/mentor/interventions/bulk/preview', data);
+    return response.data.data;
+  },
+
+  createBulkInterventions: async (data) => {
+    const response = await apiClient.post('/mentor/interventions/bulk', data);
+    return response.data.data;
+  },
+
+  getInterventionTemplates: async () => {
+    const response = await apiClient.get('/mentor/intervention-templates');
+    return response.data.data;
+  },
+
+  createInterventionTemplate: async (data) => {
+    const response = await apiClient.post('/mentor/intervention-templates', data);
+    return response.data.data;
+  },
+
+  updateInterventionTemplate: async (templateId, data) => {
+    const response = await apiClient.put(`/mentor/intervention-templates/${templateId}`, data);
+    return response.data.data;
+  },
+
+  deleteInterventionTemplate: async (templateId) => {
+    const response = await apiClient.delete(`/mentor/intervention-templates/${templateId}`);
+    return response.data.data;
+  },
+
+  getInterventionEffectiveness: async (params = {}) => {
+    const response = await apiClient.get('/mentor/interventions/effectiveness', { params });
+    return response.data.data;
+  },
+
+  getIntervention: async (interventionId) => {
+    const response = await apiClient.get(`/mentor/interventions/${interventionId}`);
+    return response.data.data;
+  },
+
   updateInterventionStatus: async (interventionId, status, response = null) => {
     const res = await apiClient.put(`/mentor/interventions/${interventionId}`, {
       status,
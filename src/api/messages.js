@@ -0,0 +1,43 @@
+import apiClient from './client';
+
+export const messagesAPI = {
+  getConversations: async () => {
+    const response = await apiClient.get('/messages/conversations');
+    return response.data.data;
+  },
+
+  // Pass before to page back through older messages, or after to poll for
+  // newer ones when the event stream is not available
+  getMessages: async (conversationId, { before, after, limit = 30 } = {}) => {
+    const response = await apiClient.get(`/messages/conversations/${conversationId}/messages`, {
+      params: {
+        before: before || undefined,
+        after: after || undefined,
+        limit,
+      },
+    });
+    return response.data.data;
+  },
+
+  sendMessage: async (conversationId, { body, attachments = [] }) => {
+    const response = await apiClient.post(`/messages/conversations/${conversationId}/messages`, {
+      body,
+      attachments,
+    });
+    return response.data.data;
+  },
+
+  markRead: async (conversationId) => {
+    const response = await apiClient.put(`/messages/conversations/${conversationId}/read`);
+    return response.data.data;
+  },
+
+  search: async (query, limit = 30) => {
+    const response = await apiClient.get('/messages/search', {
+      params: { q: query, limit },
+    });
+    return response.data.data;
+  },
+};
+
+export default messagesAPI;
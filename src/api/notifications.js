@@ -0,0 +1,72 @@
+import apiClient from './client';
+
+export const notificationsAPI = {
+  getNotifications: async ({ cursor, limit = 20, types = [], unread = false, archived = false } = {}) => {
+    const response = await apiClient.get('/notifications', {
+      params: {
+        cursor: cursor || undefined,
+        limit,
+        type: types.length ? types.join(',') : undefined,
+        unread: unread || undefined,
+        archived: archived || undefined,
+      },
+    });
+    return response.data.data;
+  },
+
+  getUnreadCount: async () => {
+    const response = await apiClient.get('/notifications/unread-count');
+    return response.data.data;
+  },
+
+  markRead: async (notificationId) => {
+    const response = await apiClient.put(`/notifications/${notificationId}/read`);
+    return response.data.data;
+  },
+
+  markAllRead: async (types = []) => {
+    const response = await apiClient.put('/notifications/read-all', null, {
+      params: { type: types.length ? types.join(',') : undefined },
+    });
+    return response.data.data;
+  },
+
+  archive: async (notificationId) => {
+    const response = await apiClient.put(`/notifications/${notificationId}/archive`);
+    return response.data.data;
+  },
+
+  unarchive: async (notificationId) => {
+    const response = await apiClient.put(`/notifications/${notificationId}/unarchive`);
+    return response.data.data;
+  },
+
+  remove: async (notificationId) => {
+    const response = await apiClient.delete(`/notifications/${notificationId}`);
+    return response.data.data;
+  },
+
+  getDeliveries: async (notificationId) => {
+    const response = await apiClient.get(`/notifications/${notificationId}/deliveries`);
+    return response.data.data;
+  },
+
+  getPushPublicKey: async () => {
+    const response = await apiClient.get('/notifications/push/public-key');
+    return response.data.data;
+  },
+
+  subscribePush: async (subscription) => {
+    const response = await apiClient.post('/notifications/push/subscriptions', subscription);
+    return response.data.data;
+  },
+
+  unsubscribePush: async (endpoint) => {
+    const response = await apiClient.delete('/notifications/push/subscriptions', {
+      data: { endpoint },
+    });
+    return response.data.data;
+  },
+};
+
+export default notificationsAPI;
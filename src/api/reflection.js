@@ -18,9 +18,16 @@ export const reflectionAPI = {
     return response.data.data;
   },
 
-  getWeeklyInsight: async (weekNumber) => {
+  getWeeklyInsight: async (weekNumber, year) => {
     const response = await apiClient.get('/reflections/weekly', {
-      params: { week: weekNumber }
+      params: { week: weekNumber, year }
+    });
+    return response.data.data;
+  },
+
+  getWeeklyInsightHistory: async (limit = 12) => {
+    const response = await apiClient.get('/reflections/weekly/history', {
+      params: { limit }
     });
     return response.data.data;
   },
@@ -15,6 +15,23 @@ export const studentAPI = {
     const response = await apiClient.get('/student/activity');
     return response.data.data;
   },
+
+  getInterventions: async () => {
+    const response = await apiClient.get('/student/interventions');
+    return response.data.data;
+  },
+
+  acknowledgeIntervention: async (interventionId) => {
+    const response = await apiClient.post(`/student/interventions/${interventionId}/acknowledge`);
+    return response.data.data;
+  },
+
+  respondToIntervention: async (interventionId, text) => {
+    const response = await apiClient.post(`/student/interventions/${interventionId}/respond`, {
+      response: text
+    });
+    return response.data.data;
+  },
 };
 
 export default studentAPI;
@@ -40,6 +40,40 @@ const userAPI = {
         });
         return response.data;
     },
+
+    getStreak: async () => {
+        const response = await apiClient.get('/user/streak');
+        return response.data.data;
+    },
+
+    getStreakHistory: async (days = 30) => {
+        const response = await apiClient.get('/user/streak/history', {
+            params: { days }
+        });
+        return response.data.data;
+    },
+
+    useStreakFreeze: async (date) => {
+        const response = await apiClient.post('/user/streak/freeze', { date });
+        return response.data.data;
+    },
+
+    getBadges: async () => {
+        const response = await apiClient.get('/user/badges');
+        return response.data.data;
+    },
+
+    getCertificates: async () => {
+        const response = await apiClient.get('/user/certificates');
+        return response.data.data;
+    },
+
+    downloadCertificate: async (serial) => {
+        const response = await apiClient.get(`/user/certificates/${serial}/download`, {
+            responseType: 'blob'
+        });
+        return response.data;
+    },
 };
 
 export default userAPI;
@@ -1,23 +1,68 @@
-import { useState } from 'react';
+import { useState, useEffect } from 'react';
 import { Link } from 'react-router-dom';
 import { Bell, Search, LogOut, User, Settings, ChevronDown } from 'lucide-react';
 import { cn } from '@/lib/utils';
 import useAuthStore from '@/store/authStore';
 import Button from '@/components/ui/Button';
 import Input from '@/components/ui/Input';
+import notificationsAPI from '@/api/notifications';
+import { subscribeToEvents } from '@/api/events';
+import { getRelativeTime } from '@/utils/formatDate';
 
 const Navbar = () => {
   const [showUserMenu, setShowUserMenu] = useState(false);
   const [showNotifications, setShowNotifications] = useState(false);
   const { user, logout } = useAuthStore();
 
-  const notifications = [
-    { id: 1, title: 'Quiz baru tersedia', message: 'Machine Learning - Decision Trees', time: '5 menit lalu', unread: true },
-    { id: 2, title: 'Pengingat belajar', message: 'Anda belum belajar hari ini', time: '1 jam lalu', unread: true },
-    { id: 3, title: 'Achievement!', message: 'Selamat! Anda menyelesaikan 10 modul', time: '2 hari lalu', unread: false },
-  ];
+  const [notifications, setNotifications] = useState([]);
+  const [unreadCount, setUnreadCount] = useState(0);
 
-  const unreadCount = notifications.filter(n => n.unread).length;
+  useEffect(() => {
+    if (!user) return undefined;
+
+    const loadNotifications = () => {
+      notificationsAPI.getNotifications({ limit: 10 })
+        .then((page) => {
+          setNotifications(page.notifications);
+          setUnreadCount(page.unreadCount);
+        })
+        .catch((error) => console.error('Failed to load notifications:', error));
+    };
+    loadNotifications();
+
+    return subscribeToEvents({
+      notification: (notif) => {
+        setNotifications((prev) => [notif, ...prev.filter((n) => n.id !== notif.id)].slice(0, 10));
+        setUnreadCount((count) => count + 1);
+      },
+      'notification.read': ({ id, unreadCount: count }) => {
+        setNotifications((prev) => prev.map((n) => (!id || n.id === id ? { ...n, read: true } : n)));
+        setUnreadCount(count);
+      },
+      resync: loadNotifications,
+    });
+  }, [user]);
+
+  const handleRead = async (notif) => {
+    if (notif.read) return;
+    try {
+      await notificationsAPI.markRead(notif.id);
+      setNotifications((prev) => prev.map((n) => (n.id === notif.id ? { ...n, read: true } : n)));
+      setUnreadCount((count) => Math.max(count - 1, 0));
+    } catch (error) {
+      console.error('Failed to mark notification as read:', error);
+    }
+  };
+
+  const handleReadAll = async () => {
+    try {
+      await notificationsAPI.markAllRead();
+      setNotifications((prev) => prev.map((n) => ({ ...n, read: true })));
+      setUnreadCount(0);
+    } catch (error) {
+      console.error('Failed to mark notifications as read:', error);
+    }
+  };
 
   return (
     <header className="sticky top-0 z-30 flex h-16 items-center justify-between border-b bg-card/80 backdrop-blur-sm px-6">
@@ -55,26 +100,38 @@ const Navbar = () => {
                 onClick={() => setShowNotifications(false)}
               />
               <div className="absolute right-0 top-full mt-2 w-80 rounded-lg border bg-card shadow-lg z-50 animate-slide-up">
-                <div className="p-4 border-b">
+                <div className="p-4 border-b flex items-center justify-between">
                   <h3 className="font-semibold">Notifikasi</h3>
+                  {unreadCount > 0 && (
+                    <button
+                      onClick={handleReadAll}
+                      className="text-xs text-primary hover:underline"
+                    >
+                      Tandai semua dibaca
+                    </button>
+                  )}
                 </div>
                 <div className="max-h-80 overflow-y-auto">
+                  {notifications.length === 0 && (
+                    <p className="p-4 text-sm text-muted-foreground text-center">Belum ada notifikasi</p>
+                  )}
                   {notifications.map((notif) => (
                     <div
                       key={notif.id}
+                      onClick={() => handleRead(notif)}
                       className={cn(
                         'p-4 border-b last:border-b-0 hover:bg-muted/50 cursor-pointer transition-colors',
-                        notif.unread && 'bg-primary/5'
+                        !notif.read && 'bg-primary/5'
                       )}
                     >
                       <div className="flex items-start gap-3">
-                        {notif.unread && (
+                        {!notif.read && (
                           <span className="h-2 w-2 rounded-full bg-primary mt-2 flex-shrink-0" />
                         )}
-                        <div className={cn(!notif.unread && 'ml-5')}>
+                        <div className={cn(notif.read && 'ml-5')}>
                           <p className="font-medium text-sm">{notif.title}</p>
                           <p className="text-sm text-muted-foreground">{notif.message}</p>
-                          <p className="text-xs text-muted-foreground mt-1">{notif.time}</p>
+                          <p className="text-xs text-muted-foreground mt-1">{getRelativeTime(notif.createdAt)}</p>
                         </div>
                       </div>
                     </div>
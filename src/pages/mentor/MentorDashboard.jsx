@@ -30,6 +30,8 @@ import Badge from '@/components/ui/Badge';
 import Button from '@/components/ui/Button';
 import { PageLoader } from '@/components/ui/Loader';
 import mentorAPI from '@/api/mentor';
+import { subscribeToEvents } from '@/api/events';
+import { getRelativeTime } from '@/utils/formatDate';
 
 const MentorDashboard = () => {
   const [dashboard, setDashboard] = useState(null);
@@ -48,6 +50,14 @@ const MentorDashboard = () => {
     };
 
     fetchDashboard();
+
+    // Reload when something the dashboard shows changes
+    return subscribeToEvents({
+      notification: fetchDashboard,
+      intervention: fetchDashboard,
+      progress: fetchDashboard,
+      resync: fetchDashboard,
+    });
   }, []);
 
   if (isLoading) return <PageLoader />;
@@ -284,7 +294,7 @@ const MentorDashboard = () => {
                         <div className="flex-1">
                           <p className="font-medium text-sm">{notif.title}</p>
                           <p className="text-sm text-muted-foreground">{notif.message}</p>
-                          <p className="text-xs text-muted-foreground mt-1">{notif.time}</p>
+                          <p className="text-xs text-muted-foreground mt-1">{getRelativeTime(notif.createdAt)}</p>
                         </div>
                         {!notif.read && (
                           <div className="h-2 w-2 rounded-full bg-primary" />
@@ -28,6 +28,7 @@ import Input from '@/components/ui/Input';
 import { PageLoader } from '@/components/ui/Loader';
 import useAuthStore from '@/store/authStore';
 import userAPI from '@/api/user';
+import { enablePush, disablePush } from '@/utils/push';
 import { toast } from 'sonner';
 import { cn } from '@/lib/utils';
 
@@ -67,6 +68,10 @@ const Settings = () => {
         });
 
         try {
+            // The browser subscription has to exist before push can be enabled
+            if (section === 'notifications' && key === 'push') {
+                await (newValue ? enablePush() : disablePush());
+            }
             await userAPI.updateSettings(section, { [key]: newValue });
             toast.success('Pengaturan berhasil disimpan');
         } catch (error) {
@@ -328,6 +333,27 @@ const Settings = () => {
                                                 onChange={() => handleToggle('notifications', 'mentorMessages')}
                                             />
                                         </SettingRow>
+                                        <SettingRow
+                                            icon={Moon}
+                                            title="Jam Tenang"
+                                            description="Pengingat belajar tidak dikirim pada rentang waktu ini"
+                                        >
+                                            <div className="flex items-center gap-2">
+                                                <input
+                                                    type="time"
+                                                    value={settings?.notifications?.quietHoursStart || ''}
+                                                    onChange={(e) => handleSelect('notifications', 'quietHoursStart', e.target.value)}
+                                                    className="rounded-lg border bg-background px-3 py-2 text-sm focus:outline-none focus:ring-2 focus:ring-primary"
+                                                />
+                                                <span className="text-sm text-muted-foreground">-</span>
+                                                <input
+                                                    type="time"
+                                                    value={settings?.notifications?.quietHoursEnd || ''}
+                                                    onChange={(e) => handleSelect('notifications', 'quietHoursEnd', e.target.value)}
+                                                    className="rounded-lg border bg-background px-3 py-2 text-sm focus:outline-none focus:ring-2 focus:ring-primary"
+                                                />
+                                            </div>
+                                        </SettingRow>
                                     </CardContent>
                                 </Card>
                             )}
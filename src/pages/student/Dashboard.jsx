@@ -22,6 +22,7 @@ import Button from '@/components/ui/Button';
 import { PageLoader } from '@/components/ui/Loader';
 import useAuthStore from '@/store/authStore';
 import studentAPI from '@/api/student';
+import { subscribeToEvents } from '@/api/events';
 import { formatDuration, getRelativeTime } from '@/utils/formatDate';
 
 const Dashboard = () => {
@@ -42,6 +43,13 @@ const Dashboard = () => {
     };
 
     fetchDashboard();
+
+    // Reload when something the dashboard shows changes
+    return subscribeToEvents({
+      intervention: fetchDashboard,
+      progress: fetchDashboard,
+      resync: fetchDashboard,
+    });
   }, [user?.id]);
 
   if (isLoading) return <PageLoader />;
@@ -251,10 +251,10 @@ export const mockInterventions = [
 
 // Notifications
 export const mockNotifications = [
-  { id: 1, type: 'danger', title: 'Danger Zone', message: 'Ahmad Wijaya tidak aktif selama 5 hari', time: '2 jam lalu', read: false },
-  { id: 2, type: 'warning', title: 'Perlu Perhatian', message: 'Eko Prasetyo menunjukkan penurunan performa', time: '5 jam lalu', read: false },
-  { id: 3, type: 'info', title: 'Pencapaian Baru', message: 'Dewi Lestari menyelesaikan course Machine Learning', time: '1 hari lalu', read: true },
-  { id: 4, type: 'success', title: 'Intervensi Berhasil', message: 'Siti Rahayu kembali aktif setelah reminder', time: '2 hari lalu', read: true },
+  { id: 1, type: 'danger', title: 'Danger Zone', message: 'Ahmad Wijaya tidak aktif selama 5 hari', createdAt: new Date(Date.now() - 2 * 3600000).toISOString(), read: false },
+  { id: 2, type: 'warning', title: 'Perlu Perhatian', message: 'Eko Prasetyo menunjukkan penurunan performa', createdAt: new Date(Date.now() - 5 * 3600000).toISOString(), read: false },
+  { id: 3, type: 'info', title: 'Pencapaian Baru', message: 'Dewi Lestari menyelesaikan course Machine Learning', createdAt: new Date(Date.now() - 24 * 3600000).toISOString(), read: true },
+  { id: 4, type: 'success', title: 'Intervensi Berhasil', message: 'Siti Rahayu kembali aktif setelah reminder', createdAt: new Date(Date.now() - 48 * 3600000).toISOString(), read: true },
 ];
 
 export default {
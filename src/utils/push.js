@@ -0,0 +1,57 @@
+import notificationsAPI from '@/api/notifications';
+
+const SERVICE_WORKER_URL = '/sw.js';
+
+// urlBase64ToUint8Array converts the VAPID public key for pushManager.subscribe
+const urlBase64ToUint8Array = (value) => {
+  const padding = '='.repeat((4 - (value.length % 4)) % 4);
+  const base64 = (value + padding).replace(/-/g, '+').replace(/_/g, '/');
+  const raw = atob(base64);
+  return Uint8Array.from(raw, (char) => char.charCodeAt(0));
+};
+
+export const isPushSupported = () =>
+  'serviceWorker' in navigator && 'PushManager' in window && 'Notification' in window;
+
+// enablePush asks for permission, subscribes the browser and registers the
+// subscription with the API
+export const enablePush = async () => {
+  if (!isPushSupported()) {
+    throw new Error('Push notifications are not supported by this browser');
+  }
+
+  const permission = await Notification.requestPermission();
+  if (permission !== 'granted') {
+    throw new Error('Push notification permission denied');
+  }
+
+  const { publicKey } = await notificationsAPI.getPushPublicKey();
+  const registration = await navigator.serviceWorker.register(SERVICE_WORKER_URL);
+  await navigator.serviceWorker.ready;
+
+  let subscription = await registration.pushManager.getSubscription();
+  if (!subscription) {
+    subscription = await registration.pushManager.subscribe({
+      userVisibleOnly: true,
+      applicationServerKey: urlBase64ToUint8Array(publicKey),
+    });
+  }
+
+  return notificationsAPI.subscribePush(subscription.toJSON());
+};
+
+// disablePush removes the browser subscription and forgets it on the API
+export const disablePush = async () => {
+  if (!isPushSupported()) return;
+
+  const registration = await navigator.serviceWorker.getRegistration(SERVICE_WORKER_URL);
+  const subscription = await registration?.pushManager.getSubscription();
+  if (!subscription) return;
+
+  try {
+    await notificationsAPI.unsubscribePush(subscription.endpoint);
+  } catch (error) {
+    console.error('Failed to remove push subscription:', error);
+  }
+  await subscription.unsubscribe();
+};